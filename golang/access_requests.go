@@ -0,0 +1,163 @@
+package golang
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// AccessRequestStatus is the state of an access request in the approval workflow.
+type AccessRequestStatus string
+
+const (
+	AccessRequestPending  AccessRequestStatus = "pending"
+	AccessRequestApproved AccessRequestStatus = "approved"
+	AccessRequestDenied   AccessRequestStatus = "denied"
+)
+
+// AccessRequest is a user's request for access to a resource, pending until
+// an approver approves or denies it. Approving a request grants the resource
+// to the user.
+type AccessRequest struct {
+	Id            string              `json:"id"`                       // Unique identifier of the request
+	ProjectId     string              `json:"project_id"`               // Project the request belongs to
+	UserId        string              `json:"user_id"`                  // User requesting access
+	ResourceKey   string              `json:"resource_key"`             // Resource access is requested on
+	Reason        string              `json:"reason"`                   // Justification given by the requester
+	Status        AccessRequestStatus `json:"status"`                   // Current state of the request
+	ReviewedBy    string              `json:"reviewed_by,omitempty"`    // ID of the approver who decided the request
+	ReviewComment string              `json:"review_comment,omitempty"` // Comment left by the approver
+	ReviewedAt    *time.Time          `json:"reviewed_at,omitempty"`    // When the request was decided
+	CreatedAt     *time.Time          `json:"created_at"`               // Timestamp when the request was created
+}
+
+// ListAccessRequestsQuery filters and paginates ListAccessRequests.
+type ListAccessRequestsQuery struct {
+	Status AccessRequestStatus // Only requests in this state
+	UserId string              // Only requests made by this user
+	Page   int                 // 1-based page number, the first page if zero
+	Limit  int                 // Maximum number of requests per page, the server default if zero
+}
+
+// AccessRequestList is a page of access requests returned by ListAccessRequests.
+type AccessRequestList struct {
+	Requests []AccessRequest `json:"requests"` // Requests on this page
+	Pagination
+}
+
+type accessRequestInput struct {
+	ResourceKey string `json:"resource_key"`
+	Reason      string `json:"reason"`
+}
+
+type accessReviewInput struct {
+	Comment string `json:"comment,omitempty"`
+}
+
+type AccessRequestResponse struct {
+	Success bool           `json:"success"`
+	Message string         `json:"message"`
+	Data    *AccessRequest `json:"data,omitempty"`
+}
+
+type AccessRequestListResponse struct {
+	Success bool               `json:"success"`
+	Message string             `json:"message"`
+	Data    *AccessRequestList `json:"data,omitempty"`
+}
+
+// RequestAccess files a request for access to the resource on behalf of the
+// user the token belongs to.
+func (s *serviceImpl) RequestAccess(ctx context.Context, resourceKey string, reason string, token string) (*AccessRequest, error) {
+	if resourceKey == "" {
+		return nil, fmt.Errorf("resource key cannot be empty")
+	}
+
+	result := AccessRequestResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodPost,
+		path:   "/access-request/v1/",
+		body:   accessRequestInput{ResourceKey: resourceKey, Reason: reason},
+		token:  token,
+		action: "request access",
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Data == nil {
+		return nil, fmt.Errorf("failed to request access: empty response. Status: %s", resp.Status)
+	}
+
+	return result.Data, nil
+}
+
+// ListAccessRequests searches the access requests matching the query, one page at a time.
+func (s *serviceImpl) ListAccessRequests(ctx context.Context, query ListAccessRequestsQuery, token string) (*AccessRequestList, error) {
+	result := AccessRequestListResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodGet,
+		path:   "/access-request/v1/search",
+		query:  query.values(),
+		token:  token,
+		action: "list access requests",
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Data == nil {
+		return nil, fmt.Errorf("failed to list access requests: empty response. Status: %s", resp.Status)
+	}
+	result.Data.complete(resp, query.Page, query.Limit, len(result.Data.Requests))
+	capturePagination(ctx, result.Data.Pagination)
+
+	return result.Data, nil
+}
+
+// ApproveAccessRequest approves the pending access request with the provided ID.
+func (s *serviceImpl) ApproveAccessRequest(ctx context.Context, id string, comment string, token string) (*AccessRequest, error) {
+	return s.reviewAccessRequest(ctx, id, "approve", comment, token)
+}
+
+// DenyAccessRequest denies the pending access request with the provided ID.
+func (s *serviceImpl) DenyAccessRequest(ctx context.Context, id string, comment string, token string) (*AccessRequest, error) {
+	return s.reviewAccessRequest(ctx, id, "deny", comment, token)
+}
+
+func (s *serviceImpl) reviewAccessRequest(ctx context.Context, id string, decision string, comment string, token string) (*AccessRequest, error) {
+	result := AccessRequestResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodPost,
+		path:   "/access-request/v1/" + url.PathEscape(id) + "/" + decision,
+		body:   accessReviewInput{Comment: comment},
+		token:  token,
+		action: decision + " access request",
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Data == nil {
+		return nil, fmt.Errorf("failed to %s access request: empty response. Status: %s", decision, resp.Status)
+	}
+
+	return result.Data, nil
+}
+
+func (q ListAccessRequestsQuery) values() url.Values {
+	v := url.Values{}
+	if q.Status != "" {
+		v.Set("status", string(q.Status))
+	}
+	if q.UserId != "" {
+		v.Set("user_id", q.UserId)
+	}
+	if q.Page > 0 {
+		v.Set("page", strconv.Itoa(q.Page))
+	}
+	if q.Limit > 0 {
+		v.Set("limit", strconv.Itoa(q.Limit))
+	}
+	return v
+}
@@ -0,0 +1,72 @@
+package golang
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAccessRequestWorkflow(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer valid-token" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"success":false,"message":"Forbidden"}`))
+			return
+		}
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/access-request/v1/":
+			var payload accessRequestInput
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil || payload.ResourceKey != "billing:read" {
+				t.Fatalf("unexpected request payload: %+v, %v", payload, err)
+			}
+			w.Write([]byte(`{"success":true,"data":{"id":"request-id","resource_key":"billing:read","status":"pending"}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/access-request/v1/search":
+			if r.URL.Query().Get("status") != "pending" {
+				t.Fatalf("expected status filter, got %q", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"success":true,"data":{"requests":[{"id":"request-id","status":"pending"}],"total":1}}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/access-request/v1/request-id/approve":
+			w.Write([]byte(`{"success":true,"data":{"id":"request-id","status":"approved","reviewed_by":"approver-id"}}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/access-request/v1/request-id/deny":
+			var payload accessReviewInput
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil || payload.Comment != "not needed" {
+				t.Fatalf("unexpected review payload: %+v, %v", payload, err)
+			}
+			w.Write([]byte(`{"success":true,"data":{"id":"request-id","status":"denied","review_comment":"not needed"}}`))
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret")
+	ctx := context.Background()
+
+	request, err := service.RequestAccess(ctx, "billing:read", "month end close", "valid-token")
+	if err != nil || request.Status != AccessRequestPending {
+		t.Fatalf("expected pending request, got %+v, %v", request, err)
+	}
+
+	list, err := service.ListAccessRequests(ctx, ListAccessRequestsQuery{Status: AccessRequestPending}, "valid-token")
+	if err != nil || len(list.Requests) != 1 {
+		t.Fatalf("expected one pending request, got %+v, %v", list, err)
+	}
+
+	approved, err := service.ApproveAccessRequest(ctx, "request-id", "", "valid-token")
+	if err != nil || approved.Status != AccessRequestApproved {
+		t.Fatalf("expected approved request, got %+v, %v", approved, err)
+	}
+
+	denied, err := service.DenyAccessRequest(ctx, "request-id", "not needed", "valid-token")
+	if err != nil || denied.Status != AccessRequestDenied {
+		t.Fatalf("expected denied request, got %+v, %v", denied, err)
+	}
+
+	if _, err := service.RequestAccess(ctx, "billing:read", "", "invalid-token"); err == nil {
+		t.Fatal("expected an error, got none")
+	}
+}
@@ -0,0 +1,239 @@
+package golang
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Priority is the QoS class of a call. Under client-side rate limiting and
+// while the circuit breaker is half-open, interactive calls are admitted
+// ahead of batch calls sharing the same service.
+type Priority int
+
+const (
+	// PriorityInteractive is for latency-sensitive calls such as auth checks
+	// on an inbound request. It is the default.
+	PriorityInteractive Priority = iota
+	// PriorityBatch is for bulk work such as sync jobs that can wait.
+	PriorityBatch
+
+	numPriorities = 2
+)
+
+// ErrCircuitOpen is returned when the circuit breaker rejects a call.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+type priorityKey struct{}
+
+// WithPriority returns a copy of ctx that marks calls made with it as having
+// the given priority.
+func WithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+// PriorityFromContext returns the priority selected on ctx, defaulting to
+// PriorityInteractive.
+func PriorityFromContext(ctx context.Context) Priority {
+	if p, ok := ctx.Value(priorityKey{}).(Priority); ok && p >= 0 && p < numPriorities {
+		return p
+	}
+	return PriorityInteractive
+}
+
+// WithRateLimit limits the service to rate calls per second with bursts of up
+// to burst calls. Calls over the limit wait, and waiting interactive calls are
+// always admitted before waiting batch calls.
+func WithRateLimit(rate float64, burst int) Option {
+	return func(s *serviceImpl) {
+		if rate <= 0 || burst <= 0 {
+			return
+		}
+		s.limiter = newRateLimiter(rate, burst)
+	}
+}
+
+// WithCircuitBreaker opens the circuit after threshold consecutive failed
+// calls (transport errors or 5xx responses) and rejects calls with
+// ErrCircuitOpen for the cooldown period. After the cooldown the circuit is
+// half-open: a single interactive probe is admitted at a time while batch
+// calls keep being rejected until a probe succeeds and the circuit closes.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(s *serviceImpl) {
+		if threshold <= 0 {
+			return
+		}
+		s.breaker = &circuitBreaker{threshold: threshold, cooldown: cooldown, now: time.Now}
+	}
+}
+
+// rateLimiter is a token bucket whose waiters are served in priority order.
+type rateLimiter struct {
+	mu      sync.Mutex
+	rate    float64 // tokens added per second
+	burst   float64
+	tokens  float64
+	last    time.Time
+	waiters [numPriorities][]chan struct{}
+	timer   *time.Timer
+}
+
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	return &rateLimiter{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+func (l *rateLimiter) refill(now time.Time) {
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+}
+
+// wait blocks until a token is available for a call of the given priority or
+// ctx is done.
+func (l *rateLimiter) wait(ctx context.Context, prio Priority) error {
+	l.mu.Lock()
+	l.refill(time.Now())
+	if l.tokens >= 1 && !l.hasWaiters(prio) {
+		l.tokens--
+		l.mu.Unlock()
+		return nil
+	}
+	ready := make(chan struct{})
+	l.waiters[prio] = append(l.waiters[prio], ready)
+	l.schedule()
+	l.mu.Unlock()
+
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		select {
+		case <-ready:
+			// Admitted concurrently with the cancellation, return the token.
+			l.tokens++
+			l.dispatch()
+		default:
+			l.remove(prio, ready)
+		}
+		return ctx.Err()
+	}
+}
+
+// hasWaiters reports whether calls of the same or higher priority are queued.
+func (l *rateLimiter) hasWaiters(prio Priority) bool {
+	for p := PriorityInteractive; p <= prio; p++ {
+		if len(l.waiters[p]) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *rateLimiter) remove(prio Priority, ready chan struct{}) {
+	queue := l.waiters[prio]
+	for i, w := range queue {
+		if w == ready {
+			l.waiters[prio] = append(queue[:i], queue[i+1:]...)
+			return
+		}
+	}
+}
+
+// dispatch hands out available tokens to queued waiters, highest priority first.
+// It must be called with l.mu held.
+func (l *rateLimiter) dispatch() {
+	l.refill(time.Now())
+	for p := range l.waiters {
+		for len(l.waiters[p]) > 0 && l.tokens >= 1 {
+			l.tokens--
+			close(l.waiters[p][0])
+			l.waiters[p] = l.waiters[p][1:]
+		}
+	}
+	l.schedule()
+}
+
+// schedule arms the timer for the next token if calls are waiting.
+// It must be called with l.mu held.
+func (l *rateLimiter) schedule() {
+	if l.timer != nil || !l.hasWaiters(numPriorities-1) {
+		return
+	}
+	delay := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+	l.timer = time.AfterFunc(delay, func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.timer = nil
+		l.dispatch()
+	})
+}
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreaker tracks consecutive failures and sheds load while the server
+// is unhealthy.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+	state     circuitState
+	failures  int
+	openedAt  time.Time
+	probing   bool
+}
+
+func (b *circuitBreaker) allow(prio Priority) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == circuitOpen && b.now().Sub(b.openedAt) >= b.cooldown {
+		b.state = circuitHalfOpen
+	}
+	switch b.state {
+	case circuitOpen:
+		return ErrCircuitOpen
+	case circuitHalfOpen:
+		if prio != PriorityInteractive || b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// release ends a call that says nothing about the health of the server,
+// such as one canceled by the caller, letting another probe through.
+func (b *circuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+func (b *circuitBreaker) record(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if success {
+		b.state = circuitClosed
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.state == circuitHalfOpen || b.failures >= b.threshold {
+		b.state = circuitOpen
+		b.openedAt = b.now()
+	}
+}
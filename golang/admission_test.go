@@ -0,0 +1,145 @@
+package golang
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterPrefersInteractive(t *testing.T) {
+	l := newRateLimiter(20, 1)
+	if err := l.wait(context.Background(), PriorityBatch); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var mu sync.Mutex
+	var order []Priority
+	var wg sync.WaitGroup
+	start := func(p Priority) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := l.wait(context.Background(), p); err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+			mu.Lock()
+			order = append(order, p)
+			mu.Unlock()
+		}()
+	}
+
+	start(PriorityBatch)
+	time.Sleep(10 * time.Millisecond)
+	start(PriorityInteractive)
+	wg.Wait()
+
+	if len(order) != 2 || order[0] != PriorityInteractive {
+		t.Fatalf("expected interactive call to be admitted first, got %v", order)
+	}
+}
+
+func TestRateLimiterCancellation(t *testing.T) {
+	l := newRateLimiter(0.001, 1)
+	l.wait(context.Background(), PriorityInteractive)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.wait(ctx, PriorityInteractive); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if l.hasWaiters(PriorityBatch) {
+		t.Fatal("expected cancelled waiter to be removed")
+	}
+}
+
+func TestCircuitBreakerHalfOpen(t *testing.T) {
+	now := time.Now()
+	b := &circuitBreaker{threshold: 2, cooldown: time.Second, now: func() time.Time { return now }}
+
+	b.record(false)
+	b.record(false)
+	if err := b.allow(PriorityInteractive); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected circuit to be open, got %v", err)
+	}
+
+	now = now.Add(time.Second)
+	if err := b.allow(PriorityBatch); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected batch call to be rejected while half-open, got %v", err)
+	}
+	if err := b.allow(PriorityInteractive); err != nil {
+		t.Fatalf("expected interactive probe to be admitted, got %v", err)
+	}
+	if err := b.allow(PriorityInteractive); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected a single probe at a time, got %v", err)
+	}
+
+	b.record(true)
+	if err := b.allow(PriorityBatch); err != nil {
+		t.Fatalf("expected circuit to be closed, got %v", err)
+	}
+}
+
+func TestCircuitBreakerCanceledCalls(t *testing.T) {
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Write([]byte(`{"success":true,"data":{"id":"user-id"}}`))
+	}))
+	defer ts.Close()
+	defer close(release)
+
+	service := newService(ts.URL, "client-id", "secret", WithCircuitBreaker(1, time.Minute))
+	for _, ctx := range []context.Context{canceledContext(), expiredContext(t)} {
+		if _, err := service.Me(ctx, "token"); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("expected the caller's error, got %v", err)
+		}
+	}
+	if service.breaker.state != circuitClosed || service.breaker.failures != 0 {
+		t.Fatalf("expected calls stopped by the caller not to count, got %d failures", service.breaker.failures)
+	}
+
+	service.breaker.state, service.breaker.probing = circuitHalfOpen, false
+	if _, err := service.Me(canceledContext(), "token"); err == nil {
+		t.Fatal("expected the canceled probe to fail")
+	}
+	if service.breaker.state != circuitHalfOpen || service.breaker.probing {
+		t.Fatal("expected the canceled probe to be released without opening the circuit")
+	}
+}
+
+// canceledContext returns a context canceled shortly after a call starts.
+func canceledContext() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	return ctx
+}
+
+// expiredContext returns a context whose deadline passes during a call.
+func expiredContext(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func TestServiceCircuitBreaker(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte(`{"success":false,"message":"Bad gateway"}`))
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret", WithCircuitBreaker(1, time.Minute))
+
+	if _, err := service.Me(context.Background(), "token"); err == nil || errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected server error, got %v", err)
+	}
+	if _, err := service.Me(context.Background(), "token"); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected circuit open error, got %v", err)
+	}
+}
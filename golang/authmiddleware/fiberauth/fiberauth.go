@@ -0,0 +1,40 @@
+// Package fiberauth adapts authmiddleware to the Fiber web framework.
+package fiberauth
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/melvinodsa/go-iam-sdk/golang"
+	"github.com/melvinodsa/go-iam-sdk/golang/authmiddleware"
+)
+
+// UserKey is the Fiber locals key holding the authenticated *golang.User.
+const UserKey = "goiam.user"
+
+// Middleware returns a Fiber handler that authenticates requests with m. The
+// resolved user is stored in the locals under UserKey and on the user
+// context, so both User(c) and golang.UserFromContext(c.UserContext()) return it.
+func Middleware(m *authmiddleware.Middleware) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := golang.WithRequestCache(c.UserContext())
+		user, err := m.Authenticate(ctx, c.Get(fiber.HeaderAuthorization))
+		if err != nil {
+			// The error handler writes a net/http response, converted to
+			// Fiber's by the adaptor.
+			return adaptor.HTTPHandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				m.HandleError(w, r, err)
+			})(c)
+		}
+		c.Locals(UserKey, user)
+		c.SetUserContext(golang.ContextWithUser(ctx, user))
+		return c.Next()
+	}
+}
+
+// User returns the authenticated user stored by Middleware, if any.
+func User(c *fiber.Ctx) (*golang.User, bool) {
+	u, ok := c.Locals(UserKey).(*golang.User)
+	return u, ok && u != nil
+}
@@ -0,0 +1,39 @@
+// Package ginauth adapts authmiddleware to the Gin web framework.
+package ginauth
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/melvinodsa/go-iam-sdk/golang"
+	"github.com/melvinodsa/go-iam-sdk/golang/authmiddleware"
+)
+
+// UserKey is the gin.Context key holding the authenticated *golang.User.
+const UserKey = "goiam.user"
+
+// Middleware returns a Gin handler that authenticates requests with m. The
+// resolved user is stored under UserKey and on the request context, so both
+// User(c) and golang.UserFromContext(c.Request.Context()) return it.
+func Middleware(m *authmiddleware.Middleware) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := golang.WithRequestCache(c.Request.Context())
+		user, err := m.Authenticate(ctx, c.GetHeader("Authorization"))
+		if err != nil {
+			m.HandleError(c.Writer, c.Request, err)
+			c.Abort()
+			return
+		}
+		c.Set(UserKey, user)
+		c.Request = c.Request.WithContext(golang.ContextWithUser(ctx, user))
+		c.Next()
+	}
+}
+
+// User returns the authenticated user stored by Middleware, if any.
+func User(c *gin.Context) (*golang.User, bool) {
+	user, ok := c.Get(UserKey)
+	if !ok {
+		return nil, false
+	}
+	u, ok := user.(*golang.User)
+	return u, ok
+}
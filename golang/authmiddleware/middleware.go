@@ -0,0 +1,187 @@
+// Package authmiddleware protects HTTP routes with go-iam access tokens.
+//
+// The middleware extracts the Bearer token from the Authorization header,
+// resolves it to a user through Service.Me and stores the user on the request
+// context, where handlers read it with golang.UserFromContext. The ginauth and
+// fiberauth sub-packages adapt the same Middleware to Gin and Fiber.
+package authmiddleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/melvinodsa/go-iam-sdk/golang"
+)
+
+// ErrMissingToken is returned when a request carries no Bearer token.
+var ErrMissingToken = errors.New("missing bearer token")
+
+// ErrorHandler writes the response for a request that failed authentication.
+type ErrorHandler func(w http.ResponseWriter, r *http.Request, err error)
+
+// Middleware authenticates requests against a go-iam service.
+type Middleware struct {
+	service      golang.Service
+	errorHandler ErrorHandler
+	federator    *golang.Federator
+	audience     string
+	panicHandler golang.PanicHandler
+}
+
+// Option configures a Middleware.
+type Option func(*Middleware)
+
+// WithErrorHandler replaces the default error handler, which responds with
+// the status chosen by StatusFor and a JSON body in the go-iam response envelope.
+func WithErrorHandler(h ErrorHandler) Option {
+	return func(m *Middleware) {
+		m.errorHandler = h
+	}
+}
+
+// WithFederator makes the middleware accept federated tokens minted by
+// upstream services with golang.Federator for audience, the name of this
+// service. They are verified locally, without calling Me; other tokens are
+// still resolved with Me.
+func WithFederator(f *golang.Federator, audience string) Option {
+	return func(m *Middleware) {
+		m.federator, m.audience = f, audience
+	}
+}
+
+// WithPanicHandler sets the handler notified when the error handler panics.
+// The default error response is written in its place.
+func WithPanicHandler(h golang.PanicHandler) Option {
+	return func(m *Middleware) {
+		m.panicHandler = h
+	}
+}
+
+// New creates a Middleware resolving tokens with the given service.
+func New(service golang.Service, opts ...Option) *Middleware {
+	m := &Middleware{
+		service:      service,
+		errorHandler: defaultErrorHandler,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// BearerToken extracts the token from an Authorization header value.
+func BearerToken(authorization string) (string, bool) {
+	scheme, token, ok := strings.Cut(strings.TrimSpace(authorization), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// Authenticate resolves the Authorization header value to a user. If ctx
+// already carries a user, for example because the middleware ran earlier in
+// the chain, that user is returned without calling the server again. A token
+// resolving to no user fails with golang.ErrUnauthorized.
+func (m *Middleware) Authenticate(ctx context.Context, authorization string) (*golang.User, error) {
+	if user, ok := golang.UserFromContext(ctx); ok && user != nil {
+		return user, nil
+	}
+	token, ok := BearerToken(authorization)
+	if !ok {
+		return nil, ErrMissingToken
+	}
+	user, err := m.resolve(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, golang.ErrUnauthorized
+	}
+	return user, nil
+}
+
+// resolve verifies federated tokens locally and resolves others with Me.
+func (m *Middleware) resolve(ctx context.Context, token string) (*golang.User, error) {
+	if m.federator != nil {
+		user, err := m.federator.Verify(token, m.audience)
+		if !errors.Is(err, golang.ErrInvalidFederatedToken) {
+			return user, err
+		}
+	}
+	return m.service.Me(ctx, token)
+}
+
+// Handler wraps next so that it only runs for authenticated requests, with
+// the resolved user available through golang.UserFromContext. The request
+// context carries a golang.WithRequestCache cache, so with
+// golang.FeatureRequestCache enabled handlers calling Me or
+// EvaluateWithContext repeatedly make one upstream call per distinct check.
+func (m *Middleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := golang.WithRequestCache(r.Context())
+		user, err := m.Authenticate(ctx, r.Header.Get("Authorization"))
+		if err != nil {
+			m.HandleError(w, r, err)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(golang.ContextWithUser(ctx, user)))
+	})
+}
+
+// HandleError answers a request that failed authentication with err using
+// the error handler, falling back to the default one if it panics before
+// writing a response. Framework adapters such as ginauth and fiberauth call
+// it so that WithErrorHandler and WithPanicHandler apply to them too.
+func (m *Middleware) HandleError(w http.ResponseWriter, r *http.Request, err error) {
+	rw := &trackingWriter{ResponseWriter: w}
+	if perr := golang.Protect("error handler", m.panicHandler, func() { m.errorHandler(rw, r, err) }); perr != nil && !rw.wroteHeader {
+		defaultErrorHandler(w, r, err)
+	}
+}
+
+// trackingWriter records whether a response was started.
+type trackingWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (w *trackingWriter) WriteHeader(status int) {
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *trackingWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
+
+// ErrorBody returns the go-iam style JSON envelope sent for a failed request.
+func ErrorBody(status int) map[string]any {
+	return map[string]any{"success": false, "message": http.StatusText(status)}
+}
+
+// StatusFor returns the status to answer a request that failed
+// authentication with err: 401 when the token is missing or rejected, 403
+// when it is not allowed to resolve the user, and 502 when go-iam could not
+// be reached or failed.
+func StatusFor(err error) int {
+	switch {
+	case errors.Is(err, ErrMissingToken), errors.Is(err, golang.ErrUnauthorized):
+		return http.StatusUnauthorized
+	case errors.Is(err, golang.ErrForbidden):
+		return http.StatusForbidden
+	default:
+		return http.StatusBadGateway
+	}
+}
+
+func defaultErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	status := StatusFor(err)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(ErrorBody(status))
+}
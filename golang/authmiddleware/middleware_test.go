@@ -0,0 +1,215 @@
+package authmiddleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/melvinodsa/go-iam-sdk/golang"
+)
+
+func newIAMServer(t *testing.T, calls *int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		switch r.Header.Get("Authorization") {
+		case "Bearer valid-token":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"success":true,"data":{"id":"user-id","name":"Test User"}}`))
+		case "Bearer broken-token":
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"success":false,"message":"Internal error"}`))
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"success":false,"message":"Invalid token"}`))
+		}
+	}))
+}
+
+func TestBearerToken(t *testing.T) {
+	tests := map[string]string{
+		"Bearer abc":   "abc",
+		"bearer  abc ": "abc",
+		"Basic abc":    "",
+		"Bearer":       "",
+		"":             "",
+	}
+	for header, want := range tests {
+		got, ok := BearerToken(header)
+		if got != want || ok != (want != "") {
+			t.Errorf("BearerToken(%q) = %q, %v; want %q", header, got, ok, want)
+		}
+	}
+}
+
+func TestHandler(t *testing.T) {
+	var calls int32
+	ts := newIAMServer(t, &calls)
+	defer ts.Close()
+
+	m := New(golang.NewService(ts.URL, "client-id", "secret"))
+	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, ok := golang.UserFromContext(r.Context())
+		if !ok {
+			t.Fatal("expected user on context")
+		}
+		w.Write([]byte(user.Id))
+	})
+	// Stacking the middleware must not resolve the token twice.
+	handler := m.Handler(m.Handler(inner))
+
+	t.Run("Valid Token", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer valid-token")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK || rec.Body.String() != "user-id" {
+			t.Fatalf("expected 200 with user-id, got %d %q", rec.Code, rec.Body.String())
+		}
+		if calls != 1 {
+			t.Fatalf("expected 1 call to the server, got %d", calls)
+		}
+	})
+
+	t.Run("Invalid Token", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer invalid-token")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusUnauthorized {
+			t.Fatalf("expected 401, got %d", rec.Code)
+		}
+	})
+
+	t.Run("Server Error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer broken-token")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadGateway {
+			t.Fatalf("expected 502, got %d", rec.Code)
+		}
+	})
+
+	t.Run("Missing Token", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if rec.Code != http.StatusUnauthorized {
+			t.Fatalf("expected 401, got %d", rec.Code)
+		}
+		if calls != 0 {
+			t.Fatalf("expected no call to the server, got %d", calls)
+		}
+	})
+}
+
+func TestHandlerFederatedToken(t *testing.T) {
+	var calls int32
+	ts := newIAMServer(t, &calls)
+	defer ts.Close()
+
+	federator, err := golang.NewFederator([]byte("0123456789abcdef0123456789abcdef"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	upstream := &golang.User{Id: "user-id", Resources: map[string]golang.UserResource{"billing:read": {Key: "billing:read"}}}
+	token, err := federator.Mint(context.Background(), upstream, golang.MintOptions{Audience: "billing", ResourceKeys: []string{"billing:read"}, TTL: time.Minute})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	m := New(golang.NewService(ts.URL, "client-id", "secret"), WithFederator(federator, "billing"))
+	handler := m.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, _ := golang.UserFromContext(r.Context())
+		fmt.Fprintf(w, "%s %v", user.Id, user.Can("billing:read"))
+	}))
+
+	tests := []struct {
+		token string
+		body  string
+	}{
+		{token, "user-id true"},
+		{"valid-token", "user-id false"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer "+tt.token)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK || rec.Body.String() != tt.body {
+			t.Fatalf("expected 200 with %q, got %d %q", tt.body, rec.Code, rec.Body.String())
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("expected only the regular token to be resolved with Me, got %d calls", calls)
+	}
+
+	other, _ := federator.Mint(context.Background(), upstream, golang.MintOptions{Audience: "reports", ResourceKeys: []string{"billing:read"}, TTL: time.Minute})
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+other)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized || calls != 1 {
+		t.Fatalf("expected a token for another audience to be rejected locally, got %d after %d calls", rec.Code, calls)
+	}
+}
+
+func TestHandlerErrorHandlerPanic(t *testing.T) {
+	var calls int32
+	ts := newIAMServer(t, &calls)
+	defer ts.Close()
+
+	var recovered *golang.PanicError
+	m := New(golang.NewService(ts.URL, "client-id", "secret"),
+		WithErrorHandler(func(w http.ResponseWriter, r *http.Request, err error) { panic("error handler bug") }),
+		WithPanicHandler(func(err *golang.PanicError) { recovered = err }),
+	)
+	handler := m.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer invalid-token")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected the default 401 response, got %d", rec.Code)
+	}
+	if recovered == nil || recovered.Callback != "error handler" {
+		t.Fatalf("expected the panic to be reported, got %v", recovered)
+	}
+}
+
+// nilUserService resolves every token to no user without an error, like a
+// misbehaving server or envelope adapter could.
+type nilUserService struct {
+	golang.UnimplementedService
+}
+
+func (nilUserService) Me(ctx context.Context, token string) (*golang.User, error) {
+	return nil, nil
+}
+
+func TestHandlerNilUser(t *testing.T) {
+	handler := New(nilUserService{}).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("expected the request to be rejected")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer valid-token")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for a token resolving to no user, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,92 @@
+package golang
+
+// PreparedUser is a User pre-parsed into lookup sets for repeated local
+// authorization checks. Preparing a user once per token and reusing it keeps
+// each check to a few map lookups without allocating.
+type PreparedUser struct {
+	user      *User
+	resources map[string]struct{}
+	roles     map[string]struct{}
+}
+
+// PrepareUser builds a PreparedUser from u. Resources are indexed both by
+// their map key and by their Key field, roles by their map key and ID.
+func PrepareUser(u *User) *PreparedUser {
+	p := &PreparedUser{
+		user:      u,
+		resources: map[string]struct{}{},
+		roles:     map[string]struct{}{},
+	}
+	if u == nil {
+		return p
+	}
+	for k, r := range u.Resources {
+		p.resources[k] = struct{}{}
+		if r.Key != "" {
+			p.resources[r.Key] = struct{}{}
+		}
+	}
+	for k, r := range u.Roles {
+		p.roles[k] = struct{}{}
+		if r.Id != "" {
+			p.roles[r.Id] = struct{}{}
+		}
+	}
+	return p
+}
+
+// User returns the user the PreparedUser was built from.
+func (p *PreparedUser) User() *User {
+	return p.user
+}
+
+// HasResource reports whether the user has been granted the resource key.
+func (p *PreparedUser) HasResource(key string) bool {
+	_, ok := p.resources[key]
+	return ok
+}
+
+// HasRole reports whether the user has been assigned the role ID.
+func (p *PreparedUser) HasRole(id string) bool {
+	_, ok := p.roles[id]
+	return ok
+}
+
+// Matcher is a precomputed authorization requirement over resource keys.
+// Matchers are immutable and safe for concurrent use, so they are typically
+// built once at startup, e.g. one per route.
+type Matcher struct {
+	keys []string
+	any  bool
+}
+
+// RequireAll returns a Matcher that allows users granted every one of keys.
+func RequireAll(keys ...string) *Matcher {
+	return &Matcher{keys: append([]string(nil), keys...)}
+}
+
+// RequireAny returns a Matcher that allows users granted at least one of keys.
+func RequireAny(keys ...string) *Matcher {
+	return &Matcher{keys: append([]string(nil), keys...), any: true}
+}
+
+// Allowed reports whether the prepared user satisfies the matcher.
+// A matcher without keys allows every non-nil user.
+func (m *Matcher) Allowed(p *PreparedUser) bool {
+	if p == nil {
+		return false
+	}
+	if len(m.keys) == 0 {
+		return true
+	}
+	for _, k := range m.keys {
+		ok := p.HasResource(k)
+		if m.any && ok {
+			return true
+		}
+		if !m.any && !ok {
+			return false
+		}
+	}
+	return !m.any
+}
@@ -0,0 +1,83 @@
+package golang
+
+import (
+	"fmt"
+	"testing"
+)
+
+func testUser(resources int) *User {
+	u := &User{
+		Id:        "user-id",
+		Resources: map[string]UserResource{},
+		Roles:     map[string]UserRole{"role-1": {Id: "role-1", Name: "admin"}},
+	}
+	for i := 0; i < resources; i++ {
+		key := fmt.Sprintf("resource:%d", i)
+		u.Resources[key] = UserResource{Key: key, Name: key}
+	}
+	return u
+}
+
+func TestMatcher(t *testing.T) {
+	p := PrepareUser(testUser(3))
+
+	tests := []struct {
+		name    string
+		matcher *Matcher
+		want    bool
+	}{
+		{"all granted", RequireAll("resource:0", "resource:2"), true},
+		{"all partially granted", RequireAll("resource:0", "resource:9"), false},
+		{"any granted", RequireAny("resource:9", "resource:1"), true},
+		{"any none granted", RequireAny("resource:8", "resource:9"), false},
+		{"no keys", RequireAll(), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.matcher.Allowed(p); got != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	if RequireAll().Allowed(nil) {
+		t.Fatal("expected nil user to be denied")
+	}
+	if !p.HasRole("role-1") || p.HasRole("role-2") {
+		t.Fatal("unexpected role check result")
+	}
+}
+
+func BenchmarkPrepareUser(b *testing.B) {
+	u := testUser(50)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		PrepareUser(u)
+	}
+}
+
+func BenchmarkMatcherAllowed(b *testing.B) {
+	p := PrepareUser(testUser(50))
+	m := RequireAll("resource:10", "resource:20", "resource:30")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if !m.Allowed(p) {
+			b.Fatal("expected user to be allowed")
+		}
+	}
+}
+
+func BenchmarkMatcherAllowedParallel(b *testing.B) {
+	p := PrepareUser(testUser(50))
+	m := RequireAny("resource:missing", "resource:49")
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if !m.Allowed(p) {
+				b.Fatal("expected user to be allowed")
+			}
+		}
+	})
+}
@@ -0,0 +1,195 @@
+package golang
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HTTP headers of DPoP (RFC 9449).
+const (
+	DPoPHeader      = "DPoP"
+	DPoPNonceHeader = "DPoP-Nonce"
+)
+
+// DPoPKey is the key pair a service proves possession of with DPoP (RFC
+// 9449). Tokens issued to a service using the key are bound to it, so they
+// are rejected when replayed from a host without the private key. It is safe
+// for concurrent use.
+type DPoPKey struct {
+	private *ecdsa.PrivateKey
+	jwk     map[string]string
+	now     func() time.Time
+
+	mu    sync.Mutex
+	nonce string // Last nonce the server required in proofs
+}
+
+// dpopClaims are the JWT claims of a DPoP proof.
+type dpopClaims struct {
+	ID          string `json:"jti"`
+	Method      string `json:"htm"`
+	URL         string `json:"htu"`
+	IssuedAt    int64  `json:"iat"`
+	AccessToken string `json:"ath,omitempty"`
+	Nonce       string `json:"nonce,omitempty"`
+}
+
+// NewDPoPKey generates a P-256 key for DPoP proofs. Keep the key for as long
+// as the tokens bound to it are used.
+func NewDPoPKey() (*DPoPKey, error) {
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("error generating DPoP key: %w", err)
+	}
+	return DPoPKeyFrom(private)
+}
+
+// DPoPKeyFrom uses an existing P-256 private key for DPoP proofs, e.g. one
+// kept in a secret store so tokens survive restarts.
+func DPoPKeyFrom(private *ecdsa.PrivateKey) (*DPoPKey, error) {
+	if private == nil || private.Curve != elliptic.P256() {
+		return nil, fmt.Errorf("DPoP key must be a P-256 key")
+	}
+	public, err := private.PublicKey.ECDH()
+	if err != nil {
+		return nil, fmt.Errorf("invalid DPoP key: %w", err)
+	}
+	point := public.Bytes() // 0x04 || X || Y
+	return &DPoPKey{
+		private: private,
+		jwk: map[string]string{
+			"kty": "EC",
+			"crv": "P-256",
+			"x":   base64.RawURLEncoding.EncodeToString(point[1:33]),
+			"y":   base64.RawURLEncoding.EncodeToString(point[33:]),
+		},
+		now: time.Now,
+	}, nil
+}
+
+// Thumbprint returns the JWK thumbprint (RFC 7638) of the public key, which
+// tokens bound to the key carry in their cnf.jkt claim.
+func (k *DPoPKey) Thumbprint() string {
+	// Members in lexicographic order, without whitespace.
+	canonical := fmt.Sprintf(`{"crv":"P-256","kty":"EC","x":"%s","y":"%s"}`, k.jwk["x"], k.jwk["y"])
+	sum := sha256.Sum256([]byte(canonical))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// WithDPoP makes the service send a DPoP proof signed with key on every
+// call, so the tokens it obtains, e.g. with Verify or a
+// ClientCredentialsTokenSource, are bound to key, and sends tokens with the
+// DPoP authorization scheme instead of Bearer. Only use it in services that
+// send tokens issued to themselves: tokens bound to another client's key
+// cannot be proven with key.
+func WithDPoP(key *DPoPKey) Option {
+	return func(s *serviceImpl) {
+		s.dpop = key
+	}
+}
+
+// WithClientCertificate makes the service present cert in TLS handshakes, so
+// tokens issued to it are bound to the certificate (RFC 8705) and rejected
+// when replayed without the certificate's private key. The transport of the
+// HTTP client in use is copied, never modified; a client whose transport is
+// not an *http.Transport must be configured with the certificate instead.
+// Under js/wasm the browser selects client certificates and the option has
+// no effect.
+func WithClientCertificate(cert tls.Certificate) Option {
+	return func(s *serviceImpl) {
+		s.clientCert = &cert
+	}
+}
+
+// withClientCertificate returns a copy of transport presenting cert, or nil
+// if transport cannot be configured.
+func withClientCertificate(transport http.RoundTripper, cert tls.Certificate) http.RoundTripper {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	t, ok := transport.(*http.Transport)
+	if !ok {
+		return nil
+	}
+	t = t.Clone()
+	if t.TLSClientConfig == nil {
+		t.TLSClientConfig = &tls.Config{}
+	}
+	t.TLSClientConfig.Certificates = []tls.Certificate{cert}
+	return t
+}
+
+// prove sets the DPoP proof of a single attempt of req, unique thanks to id.
+// The authorization scheme of a token set on req is switched to DPoP.
+func (k *DPoPKey) prove(req *http.Request, id string) error {
+	target := *req.URL
+	target.User, target.RawQuery, target.ForceQuery, target.Fragment = nil, "", false, ""
+	claims := dpopClaims{
+		ID:       id,
+		Method:   req.Method,
+		URL:      target.String(),
+		IssuedAt: k.now().Unix(),
+	}
+	if scheme, token, ok := strings.Cut(req.Header.Get("Authorization"), " "); ok && (scheme == "Bearer" || scheme == "DPoP") {
+		sum := sha256.Sum256([]byte(token))
+		claims.AccessToken = base64.RawURLEncoding.EncodeToString(sum[:])
+		req.Header.Set("Authorization", "DPoP "+token)
+	}
+	k.mu.Lock()
+	claims.Nonce = k.nonce
+	k.mu.Unlock()
+
+	proof, err := k.sign(claims)
+	if err != nil {
+		return fmt.Errorf("error signing DPoP proof: %w", err)
+	}
+	req.Header.Set(DPoPHeader, proof)
+	return nil
+}
+
+// sign returns claims as an ES256 JWT carrying the public key.
+func (k *DPoPKey) sign(claims dpopClaims) (string, error) {
+	header, err := json.Marshal(map[string]any{"typ": "dpop+jwt", "alg": "ES256", "jwk": k.jwk})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+	r, s, err := ecdsa.Sign(rand.Reader, k.private, digest[:])
+	if err != nil {
+		return "", err
+	}
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+// challenged records the nonce resp carries for later proofs, and reports
+// whether resp rejected the proof for lacking that nonce, in which case the
+// request was not processed and can be resent.
+func (k *DPoPKey) challenged(resp *http.Response) bool {
+	nonce := resp.Header.Get(DPoPNonceHeader)
+	if nonce == "" {
+		return false
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	changed := nonce != k.nonce
+	k.nonce = nonce
+	return changed && (resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusBadRequest)
+}
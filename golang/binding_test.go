@@ -0,0 +1,157 @@
+package golang
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// verifyProof checks a DPoP proof the way a server would and returns its claims.
+func verifyProof(t *testing.T, proof string) dpopClaims {
+	t.Helper()
+	parts := strings.Split(proof, ".")
+	if len(parts) != 3 {
+		t.Fatalf("expected a JWT, got %q", proof)
+	}
+	var header struct {
+		Typ string            `json:"typ"`
+		Alg string            `json:"alg"`
+		JWK map[string]string `json:"jwk"`
+	}
+	var claims dpopClaims
+	data, _ := base64.RawURLEncoding.DecodeString(parts[0])
+	if err := json.Unmarshal(data, &header); err != nil || header.Typ != "dpop+jwt" || header.Alg != "ES256" {
+		t.Fatalf("unexpected header %s, %v", data, err)
+	}
+	data, _ = base64.RawURLEncoding.DecodeString(parts[1])
+	if err := json.Unmarshal(data, &claims); err != nil {
+		t.Fatalf("unexpected claims %s, %v", data, err)
+	}
+
+	x, _ := base64.RawURLEncoding.DecodeString(header.JWK["x"])
+	y, _ := base64.RawURLEncoding.DecodeString(header.JWK["y"])
+	public := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+	sig, _ := base64.RawURLEncoding.DecodeString(parts[2])
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if len(sig) != 64 || !ecdsa.Verify(public, digest[:], new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])) {
+		t.Fatal("expected a valid signature")
+	}
+	return claims
+}
+
+func TestDPoP(t *testing.T) {
+	key, err := NewDPoPKey()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var ids []string
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		claims := verifyProof(t, r.Header.Get(DPoPHeader))
+		ids = append(ids, claims.ID)
+		if claims.Method != http.MethodGet || claims.URL != "http://"+r.Host+"/me/v1/" {
+			t.Fatalf("unexpected proof target %s %s", claims.Method, claims.URL)
+		}
+		if claims.Nonce != "nonce-1" {
+			w.Header().Set(DPoPNonceHeader, "nonce-1")
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"success":false,"message":"use_dpop_nonce"}`))
+			return
+		}
+		sum := sha256.Sum256([]byte("bound-token"))
+		if r.Header.Get("Authorization") != "DPoP bound-token" || claims.AccessToken != base64.RawURLEncoding.EncodeToString(sum[:]) {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"success":false,"message":"Invalid token"}`))
+			return
+		}
+		w.Write([]byte(`{"success":true,"data":{"id":"user-id"}}`))
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret", WithDPoP(key))
+
+	user, err := service.Me(context.Background(), "bound-token")
+	if err != nil || user.Id != "user-id" {
+		t.Fatalf("expected the user after the nonce challenge, got %+v, %v", user, err)
+	}
+	if len(ids) != 2 || ids[0] == ids[1] {
+		t.Fatalf("expected two proofs with distinct IDs, got %v", ids)
+	}
+
+	ids = nil
+	if _, err := service.Me(context.Background(), "bound-token"); err != nil || len(ids) != 1 {
+		t.Fatalf("expected the nonce to be reused without a challenge, got %d proofs, %v", len(ids), err)
+	}
+	if _, err := service.Me(context.Background(), "stolen-token"); err == nil {
+		t.Fatal("expected an error, got none")
+	}
+}
+
+func TestDPoPKeyThumbprint(t *testing.T) {
+	// RFC 7638 example values are for RSA, check stability and format instead.
+	private, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	a, _ := DPoPKeyFrom(private)
+	b, _ := DPoPKeyFrom(private)
+	if a.Thumbprint() != b.Thumbprint() || len(a.Thumbprint()) != 43 {
+		t.Fatalf("expected a stable 43 character thumbprint, got %q and %q", a.Thumbprint(), b.Thumbprint())
+	}
+
+	other, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if _, err := DPoPKeyFrom(other); err == nil {
+		t.Fatal("expected an error for a P-384 key, got none")
+	}
+}
+
+func TestClientCertificate(t *testing.T) {
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 || r.TLS.PeerCertificates[0].Subject.CommonName != "client-id" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"success":false,"message":"Certificate required"}`))
+			return
+		}
+		w.Write([]byte(`{"success":true,"data":{"id":"user-id"}}`))
+	}))
+	ts.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+	ts.StartTLS()
+	defer ts.Close()
+
+	private, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	template.Subject.CommonName = "client-id"
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &private.PublicKey, private)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	cert := tls.Certificate{Certificate: [][]byte{der}, PrivateKey: private}
+
+	client := ts.Client()
+	if _, err := NewService(ts.URL, "client-id", "secret", WithHTTPClient(client)).Me(context.Background(), "token"); err == nil {
+		t.Fatal("expected an error without certificate, got none")
+	}
+	service := NewService(ts.URL, "client-id", "secret", WithHTTPClient(client), WithClientCertificate(cert))
+	if user, err := service.Me(context.Background(), "token"); err != nil || user.Id != "user-id" {
+		t.Fatalf("expected the user, got %+v, %v", user, err)
+	}
+	if len(client.Transport.(*http.Transport).TLSClientConfig.Certificates) != 0 {
+		t.Fatal("expected the HTTP client to be left unmodified")
+	}
+}
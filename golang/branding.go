@@ -0,0 +1,137 @@
+package golang
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Keys of the customizable texts of the hosted login page.
+const (
+	BrandingTextTitle    = "title"    // Heading of the login page
+	BrandingTextSubtitle = "subtitle" // Line below the heading
+	BrandingTextButton   = "button"   // Label of the sign-in button
+	BrandingTextFooter   = "footer"   // Text at the bottom of the page
+)
+
+// ErrInvalidBranding is returned by Branding.Validate.
+var ErrInvalidBranding = errors.New("invalid branding")
+
+// BrandingColors are the colors of the hosted login page as CSS hex colors,
+// e.g. "#1a73e8". Empty colors use the go-iam defaults.
+type BrandingColors struct {
+	Primary    string `json:"primary,omitempty"`    // Buttons and links
+	Background string `json:"background,omitempty"` // Page background
+	Text       string `json:"text,omitempty"`       // Body text
+}
+
+// Branding customizes the hosted login page of a project, e.g. for a
+// white-label tenant.
+type Branding struct {
+	ProjectId  string            `json:"project_id"`            // ID of the project
+	LogoURL    string            `json:"logo_url,omitempty"`    // HTTPS URL of the logo shown above the form
+	FaviconURL string            `json:"favicon_url,omitempty"` // HTTPS URL of the page icon
+	Colors     BrandingColors    `json:"colors"`                // Colors of the page
+	Texts      map[string]string `json:"texts,omitempty"`       // Texts keyed by BrandingText* constants
+	UpdatedAt  *time.Time        `json:"updated_at"`            // Timestamp when the branding was last updated
+	UpdatedBy  string            `json:"updated_by"`            // ID of the user who last updated the branding
+}
+
+type BrandingResponse struct {
+	Success bool      `json:"success"`
+	Message string    `json:"message"`
+	Data    *Branding `json:"data,omitempty"`
+}
+
+// Validate checks that the URLs are absolute HTTPS URLs, the colors are CSS
+// hex colors and the texts use known keys.
+func (b Branding) Validate() error {
+	for name, u := range map[string]string{"logo": b.LogoURL, "favicon": b.FaviconURL} {
+		if u == "" {
+			continue
+		}
+		parsed, err := url.Parse(u)
+		if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+			return fmt.Errorf("%w: %s URL %q must be an absolute https URL", ErrInvalidBranding, name, u)
+		}
+	}
+	for name, c := range map[string]string{"primary": b.Colors.Primary, "background": b.Colors.Background, "text": b.Colors.Text} {
+		if c != "" && !validHexColor(c) {
+			return fmt.Errorf("%w: %s color %q must be a hex color such as #1a73e8", ErrInvalidBranding, name, c)
+		}
+	}
+	for key := range b.Texts {
+		switch key {
+		case BrandingTextTitle, BrandingTextSubtitle, BrandingTextButton, BrandingTextFooter:
+		default:
+			return fmt.Errorf("%w: unknown text %q", ErrInvalidBranding, key)
+		}
+	}
+	return nil
+}
+
+func validHexColor(c string) bool {
+	if len(c) != 4 && len(c) != 7 || c[0] != '#' {
+		return false
+	}
+	for _, r := range c[1:] {
+		if !('0' <= r && r <= '9' || 'a' <= r && r <= 'f' || 'A' <= r && r <= 'F') {
+			return false
+		}
+	}
+	return true
+}
+
+// GetBranding fetches the hosted login branding of the project with the provided ID.
+func (s *serviceImpl) GetBranding(ctx context.Context, projectID string, token string) (*Branding, error) {
+	result := BrandingResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodGet,
+		path:   "/project/v1/" + url.PathEscape(projectID) + "/branding",
+		token:  token,
+		action: "fetch branding",
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Data == nil {
+		return nil, fmt.Errorf("failed to fetch branding: empty response. Status: %s", resp.Status)
+	}
+
+	return result.Data, nil
+}
+
+// UpdateBranding replaces the hosted login branding of branding.ProjectId
+// after validating it.
+// Branding argument will be updated with the stored branding.
+func (s *serviceImpl) UpdateBranding(ctx context.Context, branding *Branding, token string) error {
+	if branding == nil {
+		return fmt.Errorf("branding cannot be nil")
+	}
+	if branding.ProjectId == "" {
+		return fmt.Errorf("project ID cannot be empty")
+	}
+	if err := branding.Validate(); err != nil {
+		return err
+	}
+
+	result := BrandingResponse{}
+	if _, err := s.call(ctx, apiRequest{
+		method: http.MethodPut,
+		path:   "/project/v1/" + url.PathEscape(branding.ProjectId) + "/branding",
+		body:   branding,
+		token:  token,
+		action: "update branding",
+	}, &result); err != nil {
+		return err
+	}
+
+	if result.Data != nil {
+		*branding = *result.Data
+	}
+
+	return nil
+}
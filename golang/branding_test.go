@@ -0,0 +1,80 @@
+package golang
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestBranding(t *testing.T) {
+	var updates int32
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/project/v1/project-1/branding" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer valid-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"success":false,"message":"Invalid token"}`))
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"success":true,"data":{"project_id":"project-1","colors":{"primary":"#1a73e8"}}}`))
+		case http.MethodPut:
+			atomic.AddInt32(&updates, 1)
+			var payload Branding
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("expected valid payload, got %v", err)
+			}
+			payload.UpdatedBy = "admin-id"
+			json.NewEncoder(w).Encode(BrandingResponse{Success: true, Data: &payload})
+		default:
+			t.Fatalf("unexpected method %s", r.Method)
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret")
+	ctx := context.Background()
+
+	branding, err := service.GetBranding(ctx, "project-1", "valid-token")
+	if err != nil || branding.Colors.Primary != "#1a73e8" {
+		t.Fatalf("unexpected branding %+v, %v", branding, err)
+	}
+
+	branding.LogoURL = "https://cdn.example.com/logo.svg"
+	branding.Colors.Background = "#FFF"
+	branding.Texts = map[string]string{BrandingTextTitle: "Sign in to Acme"}
+	if err := service.UpdateBranding(ctx, branding, "valid-token"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if branding.Texts[BrandingTextTitle] != "Sign in to Acme" || branding.UpdatedBy != "admin-id" {
+		t.Fatalf("expected stored branding, got %+v", branding)
+	}
+
+	if _, err := service.GetBranding(ctx, "project-1", "invalid-token"); err == nil {
+		t.Fatal("expected an error, got none")
+	}
+
+	invalid := []Branding{
+		{ProjectId: "project-1", LogoURL: "http://cdn.example.com/logo.svg"},
+		{ProjectId: "project-1", FaviconURL: "/favicon.ico"},
+		{ProjectId: "project-1", Colors: BrandingColors{Primary: "blue"}},
+		{ProjectId: "project-1", Colors: BrandingColors{Text: "#12345g"}},
+		{ProjectId: "project-1", Texts: map[string]string{"headline": "Welcome"}},
+	}
+	for _, b := range invalid {
+		if err := service.UpdateBranding(ctx, &b, "valid-token"); !errors.Is(err, ErrInvalidBranding) {
+			t.Fatalf("expected ErrInvalidBranding for %+v, got %v", b, err)
+		}
+	}
+	if updates != 1 {
+		t.Fatalf("expected invalid branding not to be sent, got %d updates", updates)
+	}
+}
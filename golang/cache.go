@@ -0,0 +1,193 @@
+package golang
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// Default TTLs of WithCache. Users are resolved from tokens and carry the
+// permissions, so they expire quickly; resources rarely change.
+const (
+	DefaultUserCacheTTL     = 30 * time.Second
+	DefaultResourceCacheTTL = 5 * time.Minute
+	DefaultRoleCacheTTL     = time.Minute
+)
+
+// maxCacheEntries bounds the memory used by the cache. Once reached, new
+// results are not cached until entries expire.
+const maxCacheEntries = 10000
+
+// CacheTTLs sets how long each class of objects is cached by WithCache. A
+// zero TTL selects the class's default, a negative one disables caching of
+// the class.
+type CacheTTLs struct {
+	Users     time.Duration // Me results, per token
+	Resources time.Duration // GetResource results
+	Roles     time.Duration // GetRole results
+}
+
+type cacheClass int
+
+const (
+	cacheUsers cacheClass = iota
+	cacheResources
+	cacheRoles
+)
+
+// WithCache caches the results of Me, GetResource and GetRole across calls,
+// each class of objects for its own TTL, so permissions can be kept fresh
+// while rarely changing objects are fetched less often. Results are cached
+// per token, failed calls are not cached, and updates made through the
+// service invalidate the cached resource or role. Changes made elsewhere are
+// seen once the TTL expires. The cache can be switched off with FeatureCache.
+func WithCache(ttls CacheTTLs) Option {
+	return func(s *serviceImpl) {
+		s.cache = newObjectCache(ttls)
+	}
+}
+
+func newObjectCache(ttls CacheTTLs) *objectCache {
+	return &objectCache{
+		ttls: map[cacheClass]time.Duration{
+			cacheUsers:     cacheTTL(ttls.Users, DefaultUserCacheTTL),
+			cacheResources: cacheTTL(ttls.Resources, DefaultResourceCacheTTL),
+			cacheRoles:     cacheTTL(ttls.Roles, DefaultRoleCacheTTL),
+		},
+		now:     time.Now,
+		entries: map[cacheKey]cacheEntry{},
+	}
+}
+
+func cacheTTL(ttl, def time.Duration) time.Duration {
+	if ttl == 0 {
+		return def
+	}
+	return ttl
+}
+
+type cacheKey struct {
+	class cacheClass
+	id    string
+	token string
+}
+
+// cacheEntry holds an encoded result, so every hit decodes a fresh copy that
+// callers may modify.
+type cacheEntry struct {
+	data    []byte
+	expires time.Time
+}
+
+// objectCache is a TTL cache of API results shared by all calls of a service.
+type objectCache struct {
+	mu      sync.Mutex
+	ttls    map[cacheClass]time.Duration
+	now     func() time.Time
+	entries map[cacheKey]cacheEntry
+}
+
+func (c *objectCache) get(k cacheKey) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[k]
+	if !ok || !c.now().Before(e.expires) {
+		return nil, false
+	}
+	return e.data, true
+}
+
+// set caches data for the TTL of its class and returns when it expires.
+func (c *objectCache) set(k cacheKey, data []byte) time.Time {
+	expires := c.now().Add(c.ttls[k.class])
+	c.setUntil(k, data, expires)
+	return expires
+}
+
+func (c *objectCache) setUntil(k cacheKey, data []byte, expires time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	if len(c.entries) >= maxCacheEntries {
+		for key, e := range c.entries {
+			if !now.Before(e.expires) {
+				delete(c.entries, key)
+			}
+		}
+		if len(c.entries) >= maxCacheEntries {
+			return
+		}
+	}
+	c.entries[k] = cacheEntry{data: data, expires: expires}
+}
+
+// invalidate removes the cached object with the given ID for every token.
+func (c *objectCache) invalidate(class cacheClass, id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k := range c.entries {
+		if k.class == class && k.id == id {
+			delete(c.entries, k)
+		}
+	}
+}
+
+// clear removes every cached object.
+func (c *objectCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.entries)
+}
+
+// cached returns the object of the class with the given ID as seen with
+// token from the service's cache, then from its cache store, calling fetch
+// on a miss. Calls requiring a minimum consistency always fetch.
+func cached[T any](ctx context.Context, s *serviceImpl, class cacheClass, id, token string, fetch func() (*T, error)) (*T, error) {
+	if s.cache == nil || s.cache.ttls[class] < 0 || !s.features.enabled(FeatureCache) || minConsistency(ctx) != "" {
+		return fetch()
+	}
+
+	k := cacheKey{class: class, id: id, token: token}
+	if data, ok := s.cache.get(k); ok {
+		var v T
+		if err := json.Unmarshal(data, &v); err == nil {
+			return &v, nil
+		}
+	}
+	data, generation, storable, ok := s.loadStored(ctx, k)
+	if ok {
+		var v T
+		if err := json.Unmarshal(data, &v); err == nil {
+			return &v, nil
+		}
+	}
+
+	v, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	if data, err := json.Marshal(v); err == nil {
+		expires := s.cache.set(k, data)
+		if storable {
+			s.saveStored(ctx, k, generation, data, expires)
+		}
+	}
+	return v, nil
+}
+
+// invalidate drops the cached object of the class with the given ID.
+func (s *serviceImpl) invalidate(class cacheClass, id string) {
+	if s.cache != nil {
+		s.cache.invalidate(class, id)
+	}
+}
+
+// purge drops every cached object, also from the CacheStore, e.g. once
+// tokens they were fetched with may have been revoked.
+func (s *serviceImpl) purge(ctx context.Context) {
+	if s.cache != nil {
+		s.cache.clear()
+	}
+	s.purgeStored(ctx)
+}
@@ -0,0 +1,88 @@
+package golang
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	var me, resources, roles int32
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/me/v1/":
+			atomic.AddInt32(&me, 1)
+			w.Write([]byte(`{"success":true,"data":{"id":"user-id","roles":{"role-id":{"id":"role-id"}}}}`))
+		case r.URL.Path == "/resource/v1/resource-id" && r.Method == http.MethodGet:
+			atomic.AddInt32(&resources, 1)
+			w.Write([]byte(`{"success":true,"data":{"id":"resource-id","key":"billing:read"}}`))
+		case r.URL.Path == "/resource/v1/resource-id":
+			w.Write([]byte(`{"success":true,"data":{"id":"resource-id","key":"billing:read"}}`))
+		case r.URL.Path == "/role/v1/role-id":
+			atomic.AddInt32(&roles, 1)
+			w.Write([]byte(`{"success":true,"data":{"id":"role-id"}}`))
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	ctx := context.Background()
+	now := time.Now()
+	service := newService(ts.URL, "client-id", "secret", WithCache(CacheTTLs{Users: 10 * time.Second, Roles: -1}))
+	service.cache.now = func() time.Time { return now }
+
+	t.Run("Users", func(t *testing.T) {
+		user, _ := service.Me(ctx, "valid-token")
+		user.Roles["other"] = UserRole{}
+		user, _ = service.Me(ctx, "valid-token")
+		if me != 1 || len(user.Roles) != 1 {
+			t.Fatalf("expected 1 call and an unmodified cached user, got %d calls and %+v", me, user.Roles)
+		}
+		service.Me(ctx, "other-token")
+		now = now.Add(10 * time.Second)
+		service.Me(ctx, "valid-token")
+		if me != 3 {
+			t.Fatalf("expected separate entries per token and expiry after the TTL, got %d calls", me)
+		}
+	})
+
+	t.Run("Resources", func(t *testing.T) {
+		service.GetResource(ctx, "resource-id", "valid-token")
+		now = now.Add(time.Minute)
+		service.GetResource(ctx, "resource-id", "valid-token")
+		if resources != 1 {
+			t.Fatalf("expected the default resource TTL to apply, got %d calls", resources)
+		}
+		if err := service.UpdateResource(ctx, &Resource{ID: "resource-id"}, "valid-token"); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		service.GetResource(ctx, "resource-id", "valid-token")
+		if resources != 2 {
+			t.Fatalf("expected the update to invalidate the resource, got %d calls", resources)
+		}
+	})
+
+	t.Run("Disabled Class", func(t *testing.T) {
+		service.GetRole(ctx, "role-id", "valid-token")
+		service.GetRole(ctx, "role-id", "valid-token")
+		if roles != 2 {
+			t.Fatalf("expected roles not to be cached, got %d calls", roles)
+		}
+	})
+
+	t.Run("Feature Disabled", func(t *testing.T) {
+		atomic.StoreInt32(&me, 0)
+		uncached := NewService(ts.URL, "client-id", "secret", WithCache(CacheTTLs{}), WithFeature(FeatureCache, false))
+		uncached.Me(ctx, "valid-token")
+		uncached.Me(ctx, "valid-token")
+		if me != 2 {
+			t.Fatalf("expected the cache to be bypassed, got %d calls", me)
+		}
+	})
+}
@@ -0,0 +1,129 @@
+package golang
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// User attributes that can be embedded as token claims. Metadata fields are
+// selected with ClaimSourceMetadata followed by the metadata key, e.g.
+// "metadata.plan".
+const (
+	ClaimSourceName      = "name"
+	ClaimSourceEmail     = "email"
+	ClaimSourcePhone     = "phone"
+	ClaimSourceRoles     = "roles"     // IDs of the user's roles
+	ClaimSourceResources = "resources" // Keys of the resources granted to the user
+	ClaimSourceMetadata  = "metadata."
+)
+
+// ErrInvalidClaimsConfig is returned by ClaimsConfig.Validate.
+var ErrInvalidClaimsConfig = errors.New("invalid claims config")
+
+// reservedClaims are set by go-iam on every token and cannot be mapped.
+var reservedClaims = map[string]bool{
+	"iss": true, "sub": true, "aud": true, "exp": true, "nbf": true, "iat": true, "jti": true,
+}
+
+// ClaimMapping embeds a user attribute as a claim of the tokens issued to a client.
+type ClaimMapping struct {
+	Claim  string `json:"claim"`  // Name of the claim in the token
+	Source string `json:"source"` // User attribute, e.g. ClaimSourceEmail or "metadata.plan"
+}
+
+// ClaimsConfig selects the user attributes embedded as claims in the tokens
+// issued to a client, so services validating tokens locally see them.
+type ClaimsConfig struct {
+	ClientId  string         `json:"client_id"`  // ID of the client
+	Claims    []ClaimMapping `json:"claims"`     // Claims added to the client's tokens
+	UpdatedAt *time.Time     `json:"updated_at"` // Timestamp when the configuration was last updated
+	UpdatedBy string         `json:"updated_by"` // ID of the user who last updated the configuration
+}
+
+type ClaimsConfigResponse struct {
+	Success bool          `json:"success"`
+	Message string        `json:"message"`
+	Data    *ClaimsConfig `json:"data,omitempty"`
+}
+
+// Validate checks that every mapping has a known source and a unique claim
+// name that go-iam does not already set.
+func (c ClaimsConfig) Validate() error {
+	seen := map[string]bool{}
+	for _, m := range c.Claims {
+		switch {
+		case m.Claim == "":
+			return fmt.Errorf("%w: claim name cannot be empty", ErrInvalidClaimsConfig)
+		case reservedClaims[m.Claim]:
+			return fmt.Errorf("%w: claim %q is reserved", ErrInvalidClaimsConfig, m.Claim)
+		case seen[m.Claim]:
+			return fmt.Errorf("%w: claim %q is mapped twice", ErrInvalidClaimsConfig, m.Claim)
+		case !validClaimSource(m.Source):
+			return fmt.Errorf("%w: unknown source %q of claim %q", ErrInvalidClaimsConfig, m.Source, m.Claim)
+		}
+		seen[m.Claim] = true
+	}
+	return nil
+}
+
+func validClaimSource(source string) bool {
+	switch source {
+	case ClaimSourceName, ClaimSourceEmail, ClaimSourcePhone, ClaimSourceRoles, ClaimSourceResources:
+		return true
+	}
+	key, ok := strings.CutPrefix(source, ClaimSourceMetadata)
+	return ok && key != ""
+}
+
+// GetClaimsConfig fetches the claims configuration of the client with the provided ID.
+func (s *serviceImpl) GetClaimsConfig(ctx context.Context, clientID string, token string) (*ClaimsConfig, error) {
+	result := ClaimsConfigResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodGet,
+		path:   "/client/v1/" + url.PathEscape(clientID) + "/claims",
+		token:  token,
+		action: "fetch claims config",
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Data == nil {
+		return nil, fmt.Errorf("failed to fetch claims config: empty response. Status: %s", resp.Status)
+	}
+
+	return result.Data, nil
+}
+
+// UpdateClaimsConfig replaces the claims configuration of config.ClientId
+// after validating it. Tokens issued before the update keep their claims.
+// Config argument will be updated with the stored configuration.
+func (s *serviceImpl) UpdateClaimsConfig(ctx context.Context, config *ClaimsConfig, token string) error {
+	if config == nil {
+		return fmt.Errorf("claims config cannot be nil")
+	}
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	result := ClaimsConfigResponse{}
+	if _, err := s.call(ctx, apiRequest{
+		method: http.MethodPut,
+		path:   "/client/v1/" + url.PathEscape(config.ClientId) + "/claims",
+		body:   config,
+		token:  token,
+		action: "update claims config",
+	}, &result); err != nil {
+		return err
+	}
+
+	if result.Data != nil {
+		*config = *result.Data
+	}
+
+	return nil
+}
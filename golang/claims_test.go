@@ -0,0 +1,82 @@
+package golang
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestClaimsConfig(t *testing.T) {
+	var updates int32
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/client/v1/client-1/claims" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer valid-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"success":false,"message":"Invalid token"}`))
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"success":true,"data":{"client_id":"client-1","claims":[{"claim":"email","source":"email"}]}}`))
+		case http.MethodPut:
+			atomic.AddInt32(&updates, 1)
+			var payload ClaimsConfig
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("expected valid payload, got %v", err)
+			}
+			payload.UpdatedBy = "admin-id"
+			json.NewEncoder(w).Encode(ClaimsConfigResponse{Success: true, Data: &payload})
+		default:
+			t.Fatalf("unexpected method %s", r.Method)
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret")
+	ctx := context.Background()
+
+	config, err := service.GetClaimsConfig(ctx, "client-1", "valid-token")
+	if err != nil || len(config.Claims) != 1 || config.Claims[0].Source != ClaimSourceEmail {
+		t.Fatalf("unexpected config %+v, %v", config, err)
+	}
+
+	config.Claims = append(config.Claims, ClaimMapping{Claim: "plan", Source: ClaimSourceMetadata + "plan"}, ClaimMapping{Claim: "roles", Source: ClaimSourceRoles})
+	if err := service.UpdateClaimsConfig(ctx, config, "valid-token"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(config.Claims) != 3 || config.UpdatedBy != "admin-id" {
+		t.Fatalf("expected stored config, got %+v", config)
+	}
+
+	if _, err := service.GetClaimsConfig(ctx, "client-1", "invalid-token"); err == nil {
+		t.Fatal("expected an error, got none")
+	}
+
+	invalid := []ClaimMapping{
+		{Claim: "", Source: ClaimSourceEmail},
+		{Claim: "sub", Source: ClaimSourceEmail},
+		{Claim: "plan", Source: "metadata."},
+		{Claim: "plan", Source: "address"},
+	}
+	for _, m := range invalid {
+		err := service.UpdateClaimsConfig(ctx, &ClaimsConfig{ClientId: "client-1", Claims: []ClaimMapping{m}}, "valid-token")
+		if !errors.Is(err, ErrInvalidClaimsConfig) {
+			t.Fatalf("expected ErrInvalidClaimsConfig for %+v, got %v", m, err)
+		}
+	}
+	duplicate := ClaimsConfig{Claims: []ClaimMapping{{Claim: "mail", Source: ClaimSourceEmail}, {Claim: "mail", Source: ClaimSourceName}}}
+	if err := duplicate.Validate(); !errors.Is(err, ErrInvalidClaimsConfig) {
+		t.Fatalf("expected ErrInvalidClaimsConfig, got %v", err)
+	}
+	if updates != 1 {
+		t.Fatalf("expected invalid configs not to be sent, got %d updates", updates)
+	}
+}
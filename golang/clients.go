@@ -0,0 +1,107 @@
+package golang
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+)
+
+// OAuth grant types a client can be allowed to use.
+const (
+	GrantAuthorizationCode = "authorization_code"
+	GrantClientCredentials = "client_credentials"
+	GrantRefreshToken      = "refresh_token"
+)
+
+// ClientConfig is the token and grant configuration of an OAuth client.
+type ClientConfig struct {
+	ClientId        string     `json:"client_id"`         // ID of the client
+	Name            string     `json:"name"`              // Display name of the client
+	AccessTokenTTL  int64      `json:"access_token_ttl"`  // Lifetime of access tokens in seconds
+	RefreshTokenTTL int64      `json:"refresh_token_ttl"` // Lifetime of refresh tokens in seconds
+	GrantTypes      []string   `json:"grant_types"`       // Grants the client may use, e.g. GrantClientCredentials
+	RedirectURIs    []string   `json:"redirect_uris"`     // Allowed redirect URIs of the authorization code flow
+	UpdatedAt       *time.Time `json:"updated_at"`        // Timestamp when the configuration was last updated
+	UpdatedBy       string     `json:"updated_by"`        // ID of the user who last updated the configuration
+}
+
+type ClientConfigResponse struct {
+	Success bool          `json:"success"`
+	Message string        `json:"message"`
+	Data    *ClientConfig `json:"data,omitempty"`
+}
+
+// Diff returns the names of the settings that differ between c and other,
+// ignoring the client ID, name and update metadata. Grant types and redirect
+// URIs are compared regardless of order. It is meant for keeping the same
+// client consistent across environments.
+func (c ClientConfig) Diff(other ClientConfig) []string {
+	var diff []string
+	if c.AccessTokenTTL != other.AccessTokenTTL {
+		diff = append(diff, "access_token_ttl")
+	}
+	if c.RefreshTokenTTL != other.RefreshTokenTTL {
+		diff = append(diff, "refresh_token_ttl")
+	}
+	if !sameSet(c.GrantTypes, other.GrantTypes) {
+		diff = append(diff, "grant_types")
+	}
+	if !sameSet(c.RedirectURIs, other.RedirectURIs) {
+		diff = append(diff, "redirect_uris")
+	}
+	return diff
+}
+
+func sameSet(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(slices.Compact(a), slices.Compact(b))
+}
+
+// GetClientConfig fetches the token and grant configuration of the client with the provided ID.
+func (s *serviceImpl) GetClientConfig(ctx context.Context, clientID string, token string) (*ClientConfig, error) {
+	result := ClientConfigResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodGet,
+		path:   "/client/v1/" + url.PathEscape(clientID) + "/config",
+		token:  token,
+		action: "fetch client config",
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Data == nil {
+		return nil, fmt.Errorf("failed to fetch client config: empty response. Status: %s", resp.Status)
+	}
+
+	return result.Data, nil
+}
+
+// UpdateClientConfig replaces the token and grant configuration of config.ClientId.
+// Config argument will be updated with the stored configuration.
+func (s *serviceImpl) UpdateClientConfig(ctx context.Context, config *ClientConfig, token string) error {
+	if config == nil {
+		return fmt.Errorf("client config cannot be nil")
+	}
+
+	result := ClientConfigResponse{}
+	if _, err := s.call(ctx, apiRequest{
+		method: http.MethodPut,
+		path:   "/client/v1/" + url.PathEscape(config.ClientId) + "/config",
+		body:   config,
+		token:  token,
+		action: "update client config",
+	}, &result); err != nil {
+		return err
+	}
+
+	if result.Data != nil {
+		*config = *result.Data
+	}
+
+	return nil
+}
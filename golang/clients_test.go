@@ -0,0 +1,81 @@
+package golang
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestClientConfig(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/client/v1/client-1/config" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer valid-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"success":false,"message":"Invalid token"}`))
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"success":true,"data":{"client_id":"client-1","access_token_ttl":3600,"grant_types":["authorization_code","refresh_token"],"redirect_uris":["https://stage.example.com/callback"]}}`))
+		case http.MethodPut:
+			var payload ClientConfig
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("expected valid payload, got %v", err)
+			}
+			payload.UpdatedBy = "admin-id"
+			json.NewEncoder(w).Encode(ClientConfigResponse{Success: true, Data: &payload})
+		default:
+			t.Fatalf("unexpected method %s", r.Method)
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret")
+	ctx := context.Background()
+
+	config, err := service.GetClientConfig(ctx, "client-1", "valid-token")
+	if err != nil || config.AccessTokenTTL != 3600 || len(config.GrantTypes) != 2 {
+		t.Fatalf("unexpected config %+v, %v", config, err)
+	}
+
+	config.AccessTokenTTL = 900
+	if err := service.UpdateClientConfig(ctx, config, "valid-token"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if config.AccessTokenTTL != 900 || config.UpdatedBy != "admin-id" {
+		t.Fatalf("expected stored config, got %+v", config)
+	}
+
+	if _, err := service.GetClientConfig(ctx, "client-1", "invalid-token"); err == nil {
+		t.Fatal("expected an error, got none")
+	}
+}
+
+func TestClientConfigDiff(t *testing.T) {
+	stage := ClientConfig{
+		ClientId:       "stage",
+		AccessTokenTTL: 3600,
+		GrantTypes:     []string{GrantAuthorizationCode, GrantRefreshToken},
+		RedirectURIs:   []string{"https://example.com/callback"},
+	}
+	prod := stage
+	prod.ClientId = "prod"
+	prod.GrantTypes = []string{GrantRefreshToken, GrantAuthorizationCode}
+
+	if diff := stage.Diff(prod); len(diff) != 0 {
+		t.Fatalf("expected no difference, got %v", diff)
+	}
+
+	prod.AccessTokenTTL = 900
+	prod.RedirectURIs = append(prod.RedirectURIs, "https://example.com/other")
+	if diff := stage.Diff(prod); !slices.Equal(diff, []string{"access_token_ttl", "redirect_uris"}) {
+		t.Fatalf("unexpected difference %v", diff)
+	}
+}
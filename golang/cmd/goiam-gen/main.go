@@ -0,0 +1,109 @@
+// Command goiam-gen generates typed Go constants and guards for the resources
+// and roles of a go-iam project.
+//
+// Typical use is through go generate:
+//
+//	//go:generate goiam-gen -spec iam.json -pkg perms -out perms_gen.go
+//
+// The spec is a JSON document of the form
+//
+//	{"project": "...", "resources": [{"key": "...", "name": "..."}], "roles": [{"id": "...", "name": "..."}]}
+//
+// The check subcommand scans a module for references to generated constants
+// and fails when any of them refers to a resource or role that is missing from
+// the live spec exported from the server:
+//
+//	goiam-gen check -spec live.json [module-dir]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/melvinodsa/go-iam-sdk/golang/codegen"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "goiam-gen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	if len(args) > 0 && args[0] == "check" {
+		return check(args[1:])
+	}
+
+	fs := flag.NewFlagSet("goiam-gen", flag.ContinueOnError)
+	specPath := fs.String("spec", "", "path to the JSON spec of resources and roles")
+	pkg := fs.String("pkg", os.Getenv("GOPACKAGE"), "package name of the generated file (defaults to $GOPACKAGE)")
+	out := fs.String("out", "goiam_gen.go", "output file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *specPath == "" {
+		return fmt.Errorf("-spec is required")
+	}
+
+	spec, err := readSpec(*specPath)
+	if err != nil {
+		return err
+	}
+
+	src, err := codegen.Generate(spec, codegen.Options{Package: *pkg, Source: filepath.Base(*specPath)})
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		return fmt.Errorf("error writing output: %w", err)
+	}
+	return nil
+}
+
+func check(args []string) error {
+	fs := flag.NewFlagSet("goiam-gen check", flag.ContinueOnError)
+	specPath := fs.String("spec", "", "path to the JSON spec of the resources and roles that exist on the server")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *specPath == "" {
+		return fmt.Errorf("-spec is required")
+	}
+	dir := "."
+	if fs.NArg() > 0 {
+		dir = fs.Arg(0)
+	}
+
+	live, err := readSpec(*specPath)
+	if err != nil {
+		return err
+	}
+
+	refs, err := codegen.Scan(dir)
+	if err != nil {
+		return err
+	}
+
+	findings := codegen.Check(refs, live)
+	for _, f := range findings {
+		fmt.Fprintln(os.Stderr, f)
+	}
+	if len(findings) > 0 {
+		return fmt.Errorf("%d reference(s) to unknown permissions", len(findings))
+	}
+	return nil
+}
+
+func readSpec(p string) (*codegen.Spec, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, fmt.Errorf("error opening spec: %w", err)
+	}
+	defer f.Close()
+
+	return codegen.ReadSpec(f)
+}
@@ -0,0 +1,271 @@
+// Package codegen generates typed Go constants for the resources and roles of
+// a go-iam project, so applications can refer to permissions by identifier
+// instead of scattering string-literal resource keys through their code.
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"io"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// Spec describes the resources and roles of a project to generate code for.
+// Its JSON form matches the resource and role objects returned by go-iam.
+type Spec struct {
+	Project   string         `json:"project,omitempty"` // Project the resources belong to
+	Resources []SpecResource `json:"resources"`         // Resources to emit constants for
+	Roles     []SpecRole     `json:"roles"`             // Roles to emit constants for
+}
+
+// SpecResource is a single resource entry of a Spec.
+type SpecResource struct {
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// SpecRole is a single role entry of a Spec.
+type SpecRole struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Options controls the generated file.
+type Options struct {
+	Package string // Package name of the generated file
+	Source  string // Human readable origin of the spec, recorded in the header
+}
+
+// ReadSpec decodes a Spec from its JSON form.
+func ReadSpec(r io.Reader) (*Spec, error) {
+	spec := &Spec{}
+	if err := json.NewDecoder(r).Decode(spec); err != nil {
+		return nil, fmt.Errorf("error decoding spec: %w", err)
+	}
+	return spec, nil
+}
+
+// reservedNames are the identifiers the generated file declares besides
+// the constants, which constant names must not collide with.
+var reservedNames = map[string]bool{
+	"ResourceKey":  true,
+	"RoleID":       true,
+	"AllResources": true,
+	"AllRoles":     true,
+}
+
+type constant struct {
+	Name    string // Full identifier, e.g. ResourceBillingRead
+	Short   string // Identifier without its prefix, e.g. BillingRead
+	Value   string
+	Comment string
+}
+
+type templateData struct {
+	Package   string
+	Source    string
+	Project   string
+	Resources []constant
+	Roles     []constant
+}
+
+// Generate renders the Go source for the given spec. Resources become
+// ResourceKey constants with a Can<Name> guard each, and roles become RoleID
+// constants. Output is sorted by identifier so regeneration is stable.
+func Generate(spec *Spec, opts Options) ([]byte, error) {
+	if spec == nil {
+		return nil, fmt.Errorf("spec cannot be nil")
+	}
+	if opts.Package == "" {
+		return nil, fmt.Errorf("package name cannot be empty")
+	}
+
+	data := templateData{Package: opts.Package, Source: opts.Source, Project: spec.Project}
+
+	resources, err := buildConstants(len(spec.Resources), "Resource", func(i int) (string, string, string) {
+		r := spec.Resources[i]
+		return r.Key, r.Key, describe(r.Name, r.Description)
+	})
+	if err != nil {
+		return nil, err
+	}
+	data.Resources = resources
+
+	roles, err := buildConstants(len(spec.Roles), "Role", func(i int) (string, string, string) {
+		r := spec.Roles[i]
+		name := r.Name
+		if name == "" {
+			name = r.Id
+		}
+		return name, r.Id, describe(r.Name, "")
+	})
+	if err != nil {
+		return nil, err
+	}
+	data.Roles = roles
+
+	var buf bytes.Buffer
+	if err := fileTemplate.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("error rendering template: %w", err)
+	}
+
+	out, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("error formatting generated code: %w", err)
+	}
+	return out, nil
+}
+
+// buildConstants converts n spec entries into uniquely named constants.
+// entry returns the text the identifier is derived from, the constant value
+// and its doc comment. Names colliding with reservedNames are suffixed with
+// X, e.g. a resource keyed "key" becomes ResourceKeyX.
+func buildConstants(n int, prefix string, entry func(i int) (string, string, string)) ([]constant, error) {
+	consts := make([]constant, 0, n)
+	seenNames := map[string]string{}
+	seenValues := map[string]bool{}
+	for i := 0; i < n; i++ {
+		base, value, comment := entry(i)
+		if value == "" {
+			return nil, fmt.Errorf("%s %d has an empty identifier", strings.ToLower(prefix), i)
+		}
+		if seenValues[value] {
+			continue
+		}
+		seenValues[value] = true
+
+		short := Identifier(base)
+		for reservedNames[prefix+short] {
+			short += "X"
+		}
+		name := prefix + short
+		if other, ok := seenNames[name]; ok {
+			return nil, fmt.Errorf("%q and %q both map to identifier %s", other, value, name)
+		}
+		seenNames[name] = value
+		consts = append(consts, constant{Name: name, Short: short, Value: value, Comment: comment})
+	}
+	sort.Slice(consts, func(i, j int) bool { return consts[i].Name < consts[j].Name })
+	return consts, nil
+}
+
+func describe(name, description string) string {
+	switch {
+	case name != "" && description != "":
+		return name + ": " + description
+	case name != "":
+		return name
+	default:
+		return description
+	}
+}
+
+// Identifier converts a resource key or role name such as "billing:invoice-read"
+// into an exported Go identifier fragment such as "BillingInvoiceRead".
+func Identifier(s string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	id := b.String()
+	if id == "" {
+		return "X"
+	}
+	if unicode.IsDigit(rune(id[0])) {
+		id = "X" + id
+	}
+	return id
+}
+
+var fileTemplate = template.Must(template.New("file").Parse(`// Code generated by goiam-gen. DO NOT EDIT.
+{{- if .Source}}
+// Source: {{.Source}}
+{{- end}}
+
+package {{.Package}}
+
+import "github.com/melvinodsa/go-iam-sdk/golang"
+
+// ResourceKey is the key of a go-iam resource{{if .Project}} in project {{printf "%q" .Project}}{{end}}.
+type ResourceKey string
+
+// RoleID is the ID of a go-iam role{{if .Project}} in project {{printf "%q" .Project}}{{end}}.
+type RoleID string
+
+{{if .Resources -}}
+const (
+{{- range .Resources}}
+	// {{.Name}} is the resource {{printf "%q" .Value}}{{if .Comment}} ({{.Comment}}){{end}}.
+	{{.Name}} ResourceKey = {{printf "%q" .Value}}
+{{- end}}
+)
+{{- end}}
+
+{{if .Roles -}}
+const (
+{{- range .Roles}}
+	// {{.Name}} is the role {{printf "%q" .Value}}{{if .Comment}} ({{.Comment}}){{end}}.
+	{{.Name}} RoleID = {{printf "%q" .Value}}
+{{- end}}
+)
+{{- end}}
+
+// AllResources lists every resource key known at generation time.
+var AllResources = []ResourceKey{
+{{- range .Resources}}
+	{{.Name}},
+{{- end}}
+}
+
+// AllRoles lists every role ID known at generation time.
+var AllRoles = []RoleID{
+{{- range .Roles}}
+	{{.Name}},
+{{- end}}
+}
+
+// GrantedTo reports whether the resource has been granted to the user.
+func (k ResourceKey) GrantedTo(u *golang.User) bool {
+	if u == nil {
+		return false
+	}
+	if _, ok := u.Resources[string(k)]; ok {
+		return true
+	}
+	for _, r := range u.Resources {
+		if r.Key == string(k) {
+			return true
+		}
+	}
+	return false
+}
+
+// AssignedTo reports whether the role has been assigned to the user.
+func (r RoleID) AssignedTo(u *golang.User) bool {
+	if u == nil {
+		return false
+	}
+	_, ok := u.Roles[string(r)]
+	return ok
+}
+{{range .Resources}}
+// Can{{.Short}} reports whether the user has been granted {{.Name}}.
+func Can{{.Short}}(u *golang.User) bool {
+	return {{.Name}}.GrantedTo(u)
+}
+{{end}}`))
@@ -0,0 +1,137 @@
+package codegen
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"iter"
+	"strings"
+	"testing"
+)
+
+func TestIdentifier(t *testing.T) {
+	tests := map[string]string{
+		"billing:invoice-read": "BillingInvoiceRead",
+		"users.write":          "UsersWrite",
+		"Admin":                "Admin",
+		"2fa/manage":           "X2faManage",
+		"":                     "X",
+	}
+	for in, want := range tests {
+		if got := Identifier(in); got != want {
+			t.Errorf("Identifier(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	spec, err := ReadSpec(strings.NewReader(`{
+		"project": "billing",
+		"resources": [
+			{"key": "invoice:read", "name": "Read invoices"},
+			{"key": "invoice:write", "name": "Write invoices", "description": "Create and edit invoices"},
+			{"key": "invoice:read", "name": "Duplicate"}
+		],
+		"roles": [{"id": "role-1", "name": "billing admin"}]
+	}`))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	src, err := Generate(spec, Options{Package: "perms", Source: "iam.json"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if _, err := parser.ParseFile(token.NewFileSet(), "perms_gen.go", src, 0); err != nil {
+		t.Fatalf("expected generated code to parse, got %v\n%s", err, src)
+	}
+
+	for _, want := range []string{
+		`ResourceInvoiceRead ResourceKey = "invoice:read"`,
+		`ResourceInvoiceWrite ResourceKey = "invoice:write"`,
+		`RoleBillingAdmin RoleID = "role-1"`,
+		`func CanInvoiceWrite(u *golang.User) bool`,
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("expected generated code to contain %q\n%s", want, src)
+		}
+	}
+	if strings.Count(string(src), `= "invoice:read"`) != 1 {
+		t.Errorf("expected duplicate resource keys to be emitted once\n%s", src)
+	}
+}
+
+func TestGenerateReservedNames(t *testing.T) {
+	spec := &Spec{
+		Resources: []SpecResource{{Key: "key"}, {Key: "key-x"}},
+		Roles:     []SpecRole{{Id: "role-1", Name: "ID"}},
+	}
+	_, err := Generate(spec, Options{Package: "perms"})
+	if err == nil {
+		t.Fatal("expected the suffixed name to collide with key-x, got no error")
+	}
+
+	spec.Resources = spec.Resources[:1]
+	src, err := Generate(spec, Options{Package: "perms"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	file, err := parser.ParseFile(token.NewFileSet(), "perms_gen.go", src, 0)
+	if err != nil {
+		t.Fatalf("expected generated code to parse, got %v\n%s", err, src)
+	}
+	declared := map[string]bool{}
+	for name := range declaredNames(file) {
+		if declared[name] {
+			t.Fatalf("expected %s to be declared once\n%s", name, src)
+		}
+		declared[name] = true
+	}
+	for _, want := range []string{
+		`ResourceKeyX ResourceKey = "key"`,
+		`func CanKeyX(u *golang.User) bool`,
+		`RoleIDX RoleID = "role-1"`,
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("expected generated code to contain %q\n%s", want, src)
+		}
+	}
+}
+
+// declaredNames yields the top-level identifiers declared by file, once per
+// declaration.
+func declaredNames(file *ast.File) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for _, decl := range file.Decls {
+			var names []*ast.Ident
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				if d.Recv == nil {
+					names = append(names, d.Name)
+				}
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					switch s := spec.(type) {
+					case *ast.ValueSpec:
+						names = append(names, s.Names...)
+					case *ast.TypeSpec:
+						names = append(names, s.Name)
+					}
+				}
+			}
+			for _, name := range names {
+				if !yield(name.Name) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func TestGenerateCollision(t *testing.T) {
+	spec := &Spec{Resources: []SpecResource{{Key: "a-b"}, {Key: "a.b"}}}
+	if _, err := Generate(spec, Options{Package: "perms"}); err == nil {
+		t.Fatal("expected an error, got none")
+	}
+}
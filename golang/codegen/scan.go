@@ -0,0 +1,287 @@
+package codegen
+
+import (
+	"bufio"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// generatedHeader is the first line of every file written by Generate.
+const generatedHeader = "// Code generated by goiam-gen. DO NOT EDIT."
+
+// Reference is a use of a generated permission constant or guard function in
+// source code.
+type Reference struct {
+	Pos      token.Position // Location of the reference
+	Constant string         // Name of the referenced constant or guard, e.g. ResourceInvoiceRead or CanInvoiceRead
+	Kind     string         // "resource" or "role"
+	Value    string         // Resource key or role ID the constant stands for
+}
+
+// Finding is a reference to a permission that does not exist on the server.
+type Finding struct {
+	Reference
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s refers to %s %q which does not exist on the server", f.Pos, f.Constant, f.Kind, f.Value)
+}
+
+// generatedPackage holds the constants and guards declared by a goiam-gen file.
+type generatedPackage struct {
+	resources map[string]string // constant or guard name -> resource key
+	roles     map[string]string // constant name -> role ID
+}
+
+// Scan walks the Go module rooted at dir and returns every reference to a
+// constant or Can guard function declared in a goiam-gen generated file. Vendor, testdata and
+// hidden directories are skipped.
+func Scan(dir string) ([]Reference, error) {
+	modulePath, err := readModulePath(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	files := map[string][]*ast.File{} // import path -> files
+	generated := map[string]*generatedPackage{}
+
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if p != dir && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(p, ".go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, p, nil, parser.ParseComments)
+		if err != nil {
+			return fmt.Errorf("error parsing %s: %w", p, err)
+		}
+
+		rel, err := filepath.Rel(dir, filepath.Dir(p))
+		if err != nil {
+			return err
+		}
+		importPath := path.Join(modulePath, filepath.ToSlash(rel))
+
+		if isGenerated(file) {
+			generated[importPath] = collectConstants(file, generated[importPath])
+			return nil
+		}
+		files[importPath] = append(files[importPath], file)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var refs []Reference
+	for importPath, pkgFiles := range files {
+		for _, file := range pkgFiles {
+			refs = append(refs, fileReferences(fset, file, importPath, generated)...)
+		}
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].Pos.Filename != refs[j].Pos.Filename {
+			return refs[i].Pos.Filename < refs[j].Pos.Filename
+		}
+		return refs[i].Pos.Offset < refs[j].Pos.Offset
+	})
+	return refs, nil
+}
+
+// Check returns the references whose resource key or role ID is missing from
+// the given spec, typically the live state exported from the server.
+func Check(refs []Reference, live *Spec) []Finding {
+	resources := map[string]bool{}
+	roles := map[string]bool{}
+	if live != nil {
+		for _, r := range live.Resources {
+			resources[r.Key] = true
+		}
+		for _, r := range live.Roles {
+			roles[r.Id] = true
+		}
+	}
+
+	var findings []Finding
+	for _, ref := range refs {
+		known := resources
+		if ref.Kind == "role" {
+			known = roles
+		}
+		if !known[ref.Value] {
+			findings = append(findings, Finding{Reference: ref})
+		}
+	}
+	return findings
+}
+
+func isGenerated(file *ast.File) bool {
+	return len(file.Comments) > 0 && len(file.Comments[0].List) > 0 &&
+		file.Comments[0].List[0].Text == generatedHeader
+}
+
+func collectConstants(file *ast.File, pkg *generatedPackage) *generatedPackage {
+	if pkg == nil {
+		pkg = &generatedPackage{resources: map[string]string{}, roles: map[string]string{}}
+	}
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs, ok := spec.(*ast.ValueSpec)
+			if !ok || len(vs.Names) != 1 || len(vs.Values) != 1 {
+				continue
+			}
+			typ, ok := vs.Type.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			lit, ok := vs.Values[0].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				continue
+			}
+			value, err := strconv.Unquote(lit.Value)
+			if err != nil {
+				continue
+			}
+			switch typ.Name {
+			case "ResourceKey":
+				pkg.resources[vs.Names[0].Name] = value
+			case "RoleID":
+				pkg.roles[vs.Names[0].Name] = value
+			}
+		}
+	}
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil {
+			continue
+		}
+		if value, ok := pkg.resources[guardedConstant(fn)]; ok {
+			pkg.resources[fn.Name.Name] = value
+		}
+	}
+	return pkg
+}
+
+// guardedConstant returns the constant a generated guard function such as
+// CanInvoiceRead checks, from its body "return ResourceInvoiceRead.GrantedTo(u)",
+// or an empty string if fn is not a guard.
+func guardedConstant(fn *ast.FuncDecl) string {
+	if fn.Body == nil || len(fn.Body.List) != 1 {
+		return ""
+	}
+	ret, ok := fn.Body.List[0].(*ast.ReturnStmt)
+	if !ok || len(ret.Results) != 1 {
+		return ""
+	}
+	call, ok := ret.Results[0].(*ast.CallExpr)
+	if !ok {
+		return ""
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "GrantedTo" {
+		return ""
+	}
+	constant, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return ""
+	}
+	return constant.Name
+}
+
+func fileReferences(fset *token.FileSet, file *ast.File, importPath string, generated map[string]*generatedPackage) []Reference {
+	// Map the local names of imported generated packages to their constants.
+	imports := map[string]*generatedPackage{}
+	for _, imp := range file.Imports {
+		p, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+		pkg, ok := generated[p]
+		if !ok {
+			continue
+		}
+		name := path.Base(p)
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		imports[name] = pkg
+	}
+	local := generated[importPath]
+
+	var refs []Reference
+	add := func(pkg *generatedPackage, name string, pos token.Pos) {
+		if v, ok := pkg.resources[name]; ok {
+			refs = append(refs, Reference{Pos: fset.Position(pos), Constant: name, Kind: "resource", Value: v})
+		} else if v, ok := pkg.roles[name]; ok {
+			refs = append(refs, Reference{Pos: fset.Position(pos), Constant: name, Kind: "role", Value: v})
+		}
+	}
+
+	var inspect func(n ast.Node) bool
+	inspect = func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			if x, ok := n.X.(*ast.Ident); ok {
+				if pkg, ok := imports[x.Name]; ok {
+					add(pkg, n.Sel.Name, n.Sel.Pos())
+					return false
+				}
+			}
+			// The receiver may be a constant of this package, as in
+			// ResourceInvoiceRead.GrantedTo(u); the selected field or method
+			// is not a reference.
+			ast.Inspect(n.X, inspect)
+			return false
+		case *ast.Ident:
+			if local != nil {
+				add(local, n.Name, n.Pos())
+			}
+		}
+		return true
+	}
+	ast.Inspect(file, inspect)
+	return refs
+}
+
+func readModulePath(goMod string) (string, error) {
+	f, err := os.Open(goMod)
+	if err != nil {
+		return "", fmt.Errorf("error opening go.mod: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "module ") {
+			return strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module")), `"`), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("error reading go.mod: %w", err)
+	}
+	return "", fmt.Errorf("no module directive in %s", goMod)
+}
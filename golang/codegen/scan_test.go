@@ -0,0 +1,126 @@
+package codegen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFile(t *testing.T, path string, data []byte) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScanAndCheck(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "go.mod"), []byte("module example.com/app\n\ngo 1.23\n"))
+
+	src, err := Generate(&Spec{
+		Resources: []SpecResource{{Key: "invoice:read"}, {Key: "invoice:delete"}},
+		Roles:     []SpecRole{{Id: "role-1", Name: "admin"}},
+	}, Options{Package: "perms"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	writeFile(t, filepath.Join(dir, "perms", "perms_gen.go"), src)
+
+	writeFile(t, filepath.Join(dir, "handlers", "invoice.go"), []byte(`package handlers
+
+import p "example.com/app/perms"
+
+var guarded = []p.ResourceKey{p.ResourceInvoiceRead, p.ResourceInvoiceDelete}
+
+var admin = p.RoleAdmin
+
+var canRead = p.CanInvoiceRead
+`))
+
+	refs, err := Scan(dir)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(refs) != 4 || refs[3].Constant != "CanInvoiceRead" || refs[3].Value != "invoice:read" {
+		t.Fatalf("expected 4 references including the guard, got %d: %+v", len(refs), refs)
+	}
+
+	live := &Spec{
+		Resources: []SpecResource{{Key: "invoice:read"}},
+		Roles:     []SpecRole{{Id: "role-1"}},
+	}
+	findings := Check(refs, live)
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d: %+v", len(findings), findings)
+	}
+	if findings[0].Constant != "ResourceInvoiceDelete" || findings[0].Value != "invoice:delete" {
+		t.Fatalf("unexpected finding: %+v", findings[0])
+	}
+}
+
+func TestScanSamePackageSelector(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "go.mod"), []byte("module example.com/app\n\ngo 1.23\n"))
+
+	src, err := Generate(&Spec{Resources: []SpecResource{{Key: "invoice:read"}}}, Options{Package: "perms"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	writeFile(t, filepath.Join(dir, "perms", "perms_gen.go"), src)
+	writeFile(t, filepath.Join(dir, "perms", "handlers.go"), []byte(`package perms
+
+import "github.com/melvinodsa/go-iam-sdk/golang"
+
+func readInvoice(u *golang.User) bool {
+	return ResourceInvoiceRead.GrantedTo(u)
+}
+`))
+
+	refs, err := Scan(dir)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	var found bool
+	for _, ref := range refs {
+		if ref.Constant == "ResourceInvoiceRead" && ref.Pos.Filename == filepath.Join(dir, "perms", "handlers.go") {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected the selector receiver to be a reference, got %+v", refs)
+	}
+	if findings := Check(refs, &Spec{}); len(findings) != 1 || findings[0].Value != "invoice:read" {
+		t.Fatalf("expected the use of the removed resource to be reported, got %+v", findings)
+	}
+}
+
+func TestScanGuardOfRemovedResource(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "go.mod"), []byte("module example.com/app\n\ngo 1.23\n"))
+
+	src, err := Generate(&Spec{Resources: []SpecResource{{Key: "invoice:delete"}}}, Options{Package: "perms"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	writeFile(t, filepath.Join(dir, "perms", "perms_gen.go"), src)
+	writeFile(t, filepath.Join(dir, "perms", "handlers.go"), []byte(`package perms
+
+import "github.com/melvinodsa/go-iam-sdk/golang"
+
+func deleteInvoice(u *golang.User) bool {
+	return CanInvoiceDelete(u)
+}
+`))
+
+	refs, err := Scan(dir)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	findings := Check(refs, &Spec{})
+	if len(findings) != 1 || findings[0].Constant != "CanInvoiceDelete" || findings[0].Value != "invoice:delete" {
+		t.Fatalf("expected the guard of the removed resource to be reported, got %+v", findings)
+	}
+}
@@ -0,0 +1,163 @@
+package golang
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"sort"
+	"strings"
+	"time"
+)
+
+// EvaluateWithContext decides locally whether the user may access the
+// resource given the runtime attributes. The resource must be granted to the
+// user and every condition of the policies attached to the grant must hold.
+// A grant depending on a policy the user does not carry is denied, since its
+// conditions cannot be checked.
+// Use Service.EvaluateWithContext for decisions that need server-side state.
+func (u *User) EvaluateWithContext(ctx context.Context, resourceKey string, attrs AccessAttributes) (*Evaluation, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	res, ok := u.resource(resourceKey)
+	if !ok {
+		return &Evaluation{Reason: fmt.Sprintf("resource %q is not granted", resourceKey)}, nil
+	}
+	if attrs.Time.IsZero() {
+		attrs.Time = time.Now()
+	}
+
+	policyIds := make([]string, 0, len(res.PolicyIds))
+	for id, enabled := range res.PolicyIds {
+		if enabled {
+			policyIds = append(policyIds, id)
+		}
+	}
+	sort.Strings(policyIds)
+
+	for _, id := range policyIds {
+		policy, ok := u.Policies[id]
+		if !ok {
+			return &Evaluation{Reason: fmt.Sprintf("policy %q is unknown", id)}, nil
+		}
+		for _, c := range policy.Conditions {
+			reason, err := c.evaluate(attrs)
+			if err != nil {
+				return nil, fmt.Errorf("error evaluating policy %q: %w", id, err)
+			}
+			if reason != "" {
+				return &Evaluation{Reason: fmt.Sprintf("policy %q: %s", id, reason)}, nil
+			}
+		}
+	}
+
+	return &Evaluation{Allowed: true}, nil
+}
+
+// evaluate checks the condition against attrs. It returns the reason the
+// condition is not met, or an empty string if it is.
+func (c PolicyCondition) evaluate(attrs AccessAttributes) (string, error) {
+	if c.TimeWindow != nil {
+		ok, err := c.TimeWindow.contains(attrs.Time)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			return "outside of the allowed time window", nil
+		}
+	}
+
+	if len(c.IPRanges) > 0 {
+		addr, err := netip.ParseAddr(attrs.IP)
+		if err != nil {
+			return "caller IP is missing or invalid", nil
+		}
+		inRange := false
+		for _, r := range c.IPRanges {
+			prefix, err := netip.ParsePrefix(r)
+			if err != nil {
+				return "", fmt.Errorf("invalid IP range %q: %w", r, err)
+			}
+			if prefix.Contains(addr.Unmap()) {
+				inRange = true
+				break
+			}
+		}
+		if !inRange {
+			return fmt.Sprintf("IP %s is outside of the allowed ranges", attrs.IP), nil
+		}
+	}
+
+	for k, want := range c.Attributes {
+		if got, ok := attrs.Resource[k]; !ok || got != want {
+			return fmt.Sprintf("resource attribute %q does not match", k), nil
+		}
+	}
+
+	return "", nil
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// contains reports whether t falls in the window.
+func (w *TimeWindow) contains(t time.Time) (bool, error) {
+	loc := time.UTC
+	if w.Timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(w.Timezone); err != nil {
+			return false, fmt.Errorf("invalid time zone %q: %w", w.Timezone, err)
+		}
+	}
+	t = t.In(loc)
+
+	start, err := minuteOfDay(w.Start)
+	if err != nil {
+		return false, err
+	}
+	end, err := minuteOfDay(w.End)
+	if err != nil {
+		return false, err
+	}
+
+	now := t.Hour()*60 + t.Minute()
+	day := t.Weekday()
+	var inWindow bool
+	if start <= end {
+		inWindow = now >= start && now < end
+	} else {
+		// Overnight windows belong to the day they start on.
+		inWindow = now >= start || now < end
+		if now < end {
+			day = (day + 6) % 7
+		}
+	}
+	if !inWindow {
+		return false, nil
+	}
+
+	if len(w.Days) == 0 {
+		return true, nil
+	}
+	for _, d := range w.Days {
+		wd, ok := weekdays[strings.ToLower(d)]
+		if !ok {
+			return false, fmt.Errorf("invalid day %q", d)
+		}
+		if wd == day {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func minuteOfDay(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q: %w", s, err)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
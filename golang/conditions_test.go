@@ -0,0 +1,92 @@
+package golang
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestUserEvaluateWithContext(t *testing.T) {
+	user := &User{
+		Resources: map[string]UserResource{
+			"reports": {Key: "reports", PolicyIds: map[string]bool{"office-hours": true}},
+			"open":    {Key: "open"},
+			"audit":   {Key: "audit", PolicyIds: map[string]bool{"missing": true, "office-hours": false}},
+		},
+		Policies: map[string]UserPolicy{
+			"office-hours": {
+				Name: "Office hours",
+				Conditions: []PolicyCondition{{
+					TimeWindow: &TimeWindow{Start: "09:00", End: "17:00", Days: []string{"mon", "tue", "wed", "thu", "fri"}},
+					IPRanges:   []string{"10.0.0.0/8"},
+					Attributes: map[string]string{"region": "eu"},
+				}},
+			},
+		},
+	}
+
+	// 2024-01-08 is a Monday.
+	monday := time.Date(2024, 1, 8, 10, 30, 0, 0, time.UTC)
+	attrs := AccessAttributes{Time: monday, IP: "10.1.2.3", Resource: map[string]string{"region": "eu"}}
+
+	tests := []struct {
+		name    string
+		key     string
+		mutate  func(a *AccessAttributes)
+		allowed bool
+	}{
+		{"all conditions met", "reports", func(a *AccessAttributes) {}, true},
+		{"outside business hours", "reports", func(a *AccessAttributes) { a.Time = monday.Add(8 * time.Hour) }, false},
+		{"weekend", "reports", func(a *AccessAttributes) { a.Time = monday.AddDate(0, 0, 5) }, false},
+		{"outside office network", "reports", func(a *AccessAttributes) { a.IP = "192.168.1.1" }, false},
+		{"missing IP", "reports", func(a *AccessAttributes) { a.IP = "" }, false},
+		{"attribute mismatch", "reports", func(a *AccessAttributes) { a.Resource = map[string]string{"region": "us"} }, false},
+		{"grant without policies", "open", func(a *AccessAttributes) { a.IP = "" }, true},
+		{"unknown policy", "audit", func(a *AccessAttributes) {}, false},
+		{"not granted", "admin", func(a *AccessAttributes) {}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := attrs
+			tt.mutate(&a)
+			eval, err := user.EvaluateWithContext(context.Background(), tt.key, a)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if eval.Allowed != tt.allowed {
+				t.Fatalf("expected allowed=%v, got %+v", tt.allowed, eval)
+			}
+			if !eval.Allowed && eval.Reason == "" {
+				t.Fatal("expected a reason for the denial")
+			}
+		})
+	}
+}
+
+func TestTimeWindowOvernight(t *testing.T) {
+	w := &TimeWindow{Start: "22:00", End: "06:00", Days: []string{"fri"}}
+
+	// 2024-01-12 is a Friday.
+	friday := time.Date(2024, 1, 12, 23, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		at   time.Time
+		want bool
+	}{
+		{friday, true},
+		{friday.Add(5 * time.Hour), true},   // Saturday 04:00, window started Friday
+		{friday.Add(-2 * time.Hour), false}, // Friday 21:00
+		{friday.Add(-24 * time.Hour), false},
+	} {
+		got, err := w.contains(tt.at)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if got != tt.want {
+			t.Errorf("contains(%v) = %v, want %v", tt.at, got, tt.want)
+		}
+	}
+
+	if _, err := (&TimeWindow{Start: "9am", End: "17:00"}).contains(friday); err == nil {
+		t.Fatal("expected an error for an invalid time of day, got none")
+	}
+}
@@ -0,0 +1,65 @@
+package golang
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Consent is a user's grant of scopes to a third-party client, shown to the
+// user as a connected app.
+type Consent struct {
+	Id         string     `json:"id"`                     // Unique identifier of the consent
+	UserId     string     `json:"user_id"`                // User who granted the consent
+	ClientId   string     `json:"client_id"`              // Client the consent was granted to
+	ClientName string     `json:"client_name"`            // Display name of the client
+	Scopes     []string   `json:"scopes"`                 // Scopes granted to the client
+	GrantedAt  *time.Time `json:"granted_at"`             // When the consent was granted
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"` // When the client last used the consent
+}
+
+type ConsentsResponse struct {
+	Success bool      `json:"success"`
+	Message string    `json:"message"`
+	Data    []Consent `json:"data,omitempty"`
+}
+
+type ConsentResponse struct {
+	Success bool     `json:"success"`
+	Message string   `json:"message"`
+	Data    *Consent `json:"data,omitempty"`
+}
+
+// ListConsents fetches the consents the user with the provided ID granted to third-party clients.
+func (s *serviceImpl) ListConsents(ctx context.Context, userID string, token string) ([]Consent, error) {
+	result := ConsentsResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodGet,
+		path:   "/user/v1/" + url.PathEscape(userID) + "/consent",
+		token:  token,
+		action: "list consents",
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	capturePagination(ctx, listPagination(resp, len(result.Data)))
+
+	return result.Data, nil
+}
+
+// RevokeConsent disconnects the client from the user's account. The client
+// can no longer act on behalf of the user until it is granted consent again.
+func (s *serviceImpl) RevokeConsent(ctx context.Context, userID string, clientID string, token string) error {
+	result := ConsentResponse{}
+	if _, err := s.call(ctx, apiRequest{
+		method: http.MethodDelete,
+		path:   "/user/v1/" + url.PathEscape(userID) + "/consent/" + url.PathEscape(clientID),
+		token:  token,
+		action: "revoke consent",
+	}, &result); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package golang
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestConsents(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer valid-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"success":false,"message":"Invalid token"}`))
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/user/v1/user-id/consent":
+			w.Write([]byte(`{"success":true,"data":[{"id":"consent-id","client_id":"client-1","client_name":"Calendar Sync","scopes":["profile","calendar"]}]}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/user/v1/user-id/consent/client-1":
+			w.Write([]byte(`{"success":true,"data":{"id":"consent-id"}}`))
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"success":false,"message":"Consent not found"}`))
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret")
+	ctx := context.Background()
+
+	consents, err := service.ListConsents(ctx, "user-id", "valid-token")
+	if err != nil || len(consents) != 1 || consents[0].ClientName != "Calendar Sync" || len(consents[0].Scopes) != 2 {
+		t.Fatalf("unexpected consents %+v, %v", consents, err)
+	}
+	if err := service.RevokeConsent(ctx, "user-id", "client-1", "valid-token"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := service.RevokeConsent(ctx, "user-id", "client-2", "valid-token"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if _, err := service.ListConsents(ctx, "user-id", "invalid-token"); !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized, got %v", err)
+	}
+}
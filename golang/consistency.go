@@ -0,0 +1,87 @@
+package golang
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// HTTP headers carrying consistency tokens. Every go-iam response reports the
+// version of the data it reflects in ConsistencyTokenHeader; a request with
+// MinConsistencyHeader is only answered once the server has caught up with
+// that version.
+const (
+	ConsistencyTokenHeader = "X-Consistency-Token"
+	MinConsistencyHeader   = "X-Min-Consistency"
+)
+
+type minConsistencyKey struct{}
+
+type consistencyCaptureKey struct{}
+
+// WithMinConsistency returns a copy of ctx making calls with it read data at
+// least as recent as the write that returned token, instead of racing
+// replication lag. Such calls bypass the request cache and WithCache.
+func WithMinConsistency(ctx context.Context, token string) context.Context {
+	if token == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, minConsistencyKey{}, token)
+}
+
+// ConsistencyCapture records the consistency token of the responses to calls
+// made with the context returned by CaptureConsistency.
+type ConsistencyCapture struct {
+	mu    sync.Mutex
+	token string
+}
+
+// CaptureConsistency returns a copy of ctx recording consistency tokens in
+// the returned capture, e.g. to require later reads to reflect a write:
+//
+//	wctx, capture := golang.CaptureConsistency(ctx)
+//	if err := service.AddResourceToRole(wctx, roleID, resource, token); err != nil {
+//		return err
+//	}
+//	user, err := service.Me(golang.WithMinConsistency(ctx, capture.Token()), token)
+func CaptureConsistency(ctx context.Context) (context.Context, *ConsistencyCapture) {
+	c := &ConsistencyCapture{}
+	return context.WithValue(ctx, consistencyCaptureKey{}, c), c
+}
+
+// Token returns the token of the latest response, empty if none carried one.
+func (c *ConsistencyCapture) Token() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.token
+}
+
+func (c *ConsistencyCapture) observe(token string) {
+	if token == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = token
+}
+
+// minConsistency returns the token set on ctx with WithMinConsistency.
+func minConsistency(ctx context.Context) string {
+	token, _ := ctx.Value(minConsistencyKey{}).(string)
+	return token
+}
+
+// applyConsistency sets the minimum consistency of ctx on req.
+func applyConsistency(ctx context.Context, req *http.Request) {
+	if token := minConsistency(ctx); token != "" {
+		req.Header.Set(MinConsistencyHeader, token)
+	}
+}
+
+// observeConsistency records the consistency token of resp in the capture of
+// ctx, if any.
+func observeConsistency(ctx context.Context, resp *http.Response) {
+	if c, ok := ctx.Value(consistencyCaptureKey{}).(*ConsistencyCapture); ok {
+		c.observe(resp.Header.Get(ConsistencyTokenHeader))
+	}
+}
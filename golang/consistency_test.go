@@ -0,0 +1,75 @@
+package golang
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestConsistency(t *testing.T) {
+	var version int32 = 1
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var current int32
+		switch min := r.Header.Get(MinConsistencyHeader); {
+		case strings.HasPrefix(r.URL.Path, "/role/"):
+			current = atomic.AddInt32(&version, 1)
+		case min == "":
+			// Replicas lag behind the primary when no consistency is required.
+		case min != "v2":
+			t.Errorf("expected min consistency v2, got %q", min)
+		default:
+			current = atomic.LoadInt32(&version)
+		}
+		token := fmt.Sprintf("v%d", current)
+		w.Header().Set(ConsistencyTokenHeader, token)
+		switch r.URL.Path {
+		case "/me/v1/":
+			w.Write([]byte(`{"success":true,"data":{"id":"user-id"}}`))
+		case "/policy/v1/evaluate":
+			w.Write([]byte(`{"success":true,"data":{"allowed":true}}`))
+		default:
+			w.Write([]byte(`{"success":true,"data":{"id":"role-id"}}`))
+		}
+	}))
+	defer ts.Close()
+
+	service := newService(ts.URL, "client-id", "secret")
+
+	wctx, capture := CaptureConsistency(context.Background())
+	if err := service.AddResourceToRole(wctx, "role-id", RoleResource{Id: "resource-id"}, "valid-token"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if capture.Token() != "v2" {
+		t.Fatalf("expected token v2 to be captured, got %q", capture.Token())
+	}
+
+	ctx := context.Background()
+	if user, err := service.Me(ctx, "valid-token"); err != nil || user.ConsistencyToken != "v0" {
+		t.Fatalf("expected a lagging read, got %+v %v", user, err)
+	}
+
+	ctx = WithMinConsistency(ctx, capture.Token())
+	t.Run("Me", func(t *testing.T) {
+		user, err := service.Me(ctx, "valid-token")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if user.ConsistencyToken != "v2" {
+			t.Fatalf("expected consistency token v2, got %q", user.ConsistencyToken)
+		}
+	})
+
+	t.Run("EvaluateWithContext", func(t *testing.T) {
+		eval, err := service.EvaluateWithContext(ctx, "billing:read", AccessAttributes{}, "valid-token")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if eval.ConsistencyToken != "v2" {
+			t.Fatalf("expected consistency token v2, got %q", eval.ConsistencyToken)
+		}
+	})
+}
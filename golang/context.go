@@ -0,0 +1,18 @@
+package golang
+
+import "context"
+
+type userKey struct{}
+
+// ContextWithUser returns a copy of ctx carrying the authenticated user.
+// It is used by the authmiddleware package to hand the user resolved from the
+// request's token to downstream handlers.
+func ContextWithUser(ctx context.Context, user *User) context.Context {
+	return context.WithValue(ctx, userKey{}, user)
+}
+
+// UserFromContext returns the authenticated user stored on ctx, if any.
+func UserFromContext(ctx context.Context) (*User, bool) {
+	user, ok := ctx.Value(userKey{}).(*User)
+	return user, ok && user != nil
+}
@@ -0,0 +1,50 @@
+package golang
+
+import (
+	"context"
+	"fmt"
+)
+
+// Credential is a go-iam client ID and secret pair.
+type Credential struct {
+	ClientID string // ID of the go-iam client
+	Secret   string // Secret of the go-iam client
+}
+
+type credentialKey struct{}
+
+// WithNamedCredential registers an additional credential under the given name.
+// Calls made with a context returned by WithCredential use it instead of the
+// default client ID and secret, which lets a single service act on behalf of
+// several go-iam clients.
+func WithNamedCredential(name, clientID, secret string) Option {
+	return func(s *serviceImpl) {
+		s.credentials[name] = Credential{ClientID: clientID, Secret: secret}
+	}
+}
+
+// WithCredential returns a copy of ctx that selects the named credential for
+// calls made with it. The name must have been registered with WithNamedCredential.
+func WithCredential(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, credentialKey{}, name)
+}
+
+// CredentialFromContext returns the credential name selected on ctx, if any.
+func CredentialFromContext(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(credentialKey{}).(string)
+	return name, ok
+}
+
+// credentialFor resolves the credential selected on ctx, falling back to the
+// default credential when none is selected.
+func (s *serviceImpl) credentialFor(ctx context.Context) (Credential, error) {
+	name, ok := CredentialFromContext(ctx)
+	if !ok {
+		return s.credential, nil
+	}
+	cred, ok := s.credentials[name]
+	if !ok {
+		return Credential{}, fmt.Errorf("unknown credential %q", name)
+	}
+	return cred, nil
+}
@@ -0,0 +1,51 @@
+package golang
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestVerifyWithCredential(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		clientID, secret, _ := r.BasicAuth()
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"success":true,"data":{"access_token":"` + clientID + ":" + secret + `"}}`))
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret",
+		WithNamedCredential("billing-admin", "billing-id", "billing-secret"))
+
+	t.Run("Default Credential", func(t *testing.T) {
+		token, err := service.Verify(context.Background(), "code")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if token != "client-id:secret" {
+			t.Fatalf("expected default credential to be used, got %v", token)
+		}
+	})
+
+	t.Run("Named Credential", func(t *testing.T) {
+		ctx := WithCredential(context.Background(), "billing-admin")
+		token, err := service.Verify(ctx, "code")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if token != "billing-id:billing-secret" {
+			t.Fatalf("expected named credential to be used, got %v", token)
+		}
+	})
+
+	t.Run("Unknown Credential", func(t *testing.T) {
+		ctx := WithCredential(context.Background(), "unknown")
+		_, err := service.Verify(ctx, "code")
+		if err == nil {
+			t.Fatal("expected an error, got none")
+		}
+	})
+}
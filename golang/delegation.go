@@ -0,0 +1,135 @@
+package golang
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+)
+
+// ErrOutsideDelegation is returned when a delegated admin attempts an action
+// that none of their delegation scopes cover.
+var ErrOutsideDelegation = errors.New("action is outside of the delegated scope")
+
+// DelegationScope grants a user admin rights over a subset of the resources
+// and roles of a project, e.g. so a team lead can manage their own members.
+type DelegationScope struct {
+	Id           string     `json:"id"`                   // Unique identifier of the delegation
+	ProjectId    string     `json:"project_id"`           // Project the delegation belongs to
+	UserId       string     `json:"user_id"`              // User the admin rights are delegated to
+	ResourceKeys []string   `json:"resource_keys"`        // Resources the user may grant and revoke
+	RoleIds      []string   `json:"role_ids"`             // Roles the user may assign and unassign
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"` // When the delegation ends, never if nil
+	CreatedAt    *time.Time `json:"created_at"`           // Timestamp when the delegation was created
+	CreatedBy    string     `json:"created_by"`           // ID of the user who created the delegation
+}
+
+// DelegatedAction is an admin action to verify against delegation scopes.
+// Empty fields are not checked, but an action must name a resource or a role.
+type DelegatedAction struct {
+	ResourceKey string // Resource being granted or revoked
+	RoleId      string // Role being assigned or unassigned
+}
+
+type DelegationScopeResponse struct {
+	Success bool             `json:"success"`
+	Message string           `json:"message"`
+	Data    *DelegationScope `json:"data,omitempty"`
+}
+
+type DelegationScopesResponse struct {
+	Success bool              `json:"success"`
+	Message string            `json:"message"`
+	Data    []DelegationScope `json:"data,omitempty"`
+}
+
+// Allows reports whether the scope covers the action at the given time. An
+// action naming neither a resource nor a role is never covered.
+func (d DelegationScope) Allows(action DelegatedAction, at time.Time) bool {
+	if action == (DelegatedAction{}) {
+		return false
+	}
+	if d.ExpiresAt != nil && !at.Before(*d.ExpiresAt) {
+		return false
+	}
+	if action.ResourceKey != "" && !slices.Contains(d.ResourceKeys, action.ResourceKey) {
+		return false
+	}
+	if action.RoleId != "" && !slices.Contains(d.RoleIds, action.RoleId) {
+		return false
+	}
+	return true
+}
+
+// VerifyDelegatedAction returns ErrOutsideDelegation unless one of the scopes
+// covers the action now.
+func VerifyDelegatedAction(scopes []DelegationScope, action DelegatedAction) error {
+	now := time.Now()
+	for _, scope := range scopes {
+		if scope.Allows(action, now) {
+			return nil
+		}
+	}
+	return ErrOutsideDelegation
+}
+
+// CreateDelegation delegates admin rights over the scope's resources and roles to scope.UserId.
+// Scope argument will be updated with the created delegation details.
+func (s *serviceImpl) CreateDelegation(ctx context.Context, scope *DelegationScope, token string) error {
+	if scope == nil {
+		return fmt.Errorf("delegation scope cannot be nil")
+	}
+
+	result := DelegationScopeResponse{}
+	if _, err := s.call(ctx, apiRequest{
+		method: http.MethodPost,
+		path:   "/delegation/v1/",
+		body:   scope,
+		token:  token,
+		action: "create delegation",
+	}, &result); err != nil {
+		return err
+	}
+
+	if result.Data != nil {
+		*scope = *result.Data
+	}
+
+	return nil
+}
+
+// ListDelegations fetches the delegation scopes of the user with the provided ID.
+func (s *serviceImpl) ListDelegations(ctx context.Context, userID string, token string) ([]DelegationScope, error) {
+	result := DelegationScopesResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodGet,
+		path:   "/delegation/v1/",
+		query:  url.Values{"user_id": {userID}},
+		token:  token,
+		action: "list delegations",
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	capturePagination(ctx, listPagination(resp, len(result.Data)))
+
+	return result.Data, nil
+}
+
+// RevokeDelegation removes the delegation scope with the provided ID.
+func (s *serviceImpl) RevokeDelegation(ctx context.Context, id string, token string) error {
+	result := DelegationScopeResponse{}
+	if _, err := s.call(ctx, apiRequest{
+		method: http.MethodDelete,
+		path:   "/delegation/v1/" + url.PathEscape(id),
+		token:  token,
+		action: "revoke delegation",
+	}, &result); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package golang
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestVerifyDelegatedAction(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	scopes := []DelegationScope{
+		{ResourceKeys: []string{"team:read", "team:write"}, RoleIds: []string{"member"}},
+		{ResourceKeys: []string{"billing:read"}, ExpiresAt: &past},
+	}
+
+	tests := []struct {
+		name   string
+		action DelegatedAction
+		err    error
+	}{
+		{"resource in scope", DelegatedAction{ResourceKey: "team:write"}, nil},
+		{"role in scope", DelegatedAction{RoleId: "member"}, nil},
+		{"resource and role in scope", DelegatedAction{ResourceKey: "team:read", RoleId: "member"}, nil},
+		{"resource out of scope", DelegatedAction{ResourceKey: "admin"}, ErrOutsideDelegation},
+		{"role out of scope", DelegatedAction{RoleId: "owner"}, ErrOutsideDelegation},
+		{"expired scope", DelegatedAction{ResourceKey: "billing:read"}, ErrOutsideDelegation},
+		{"empty action", DelegatedAction{}, ErrOutsideDelegation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := VerifyDelegatedAction(scopes, tt.action); !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestCreateDelegation(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/delegation/v1/" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var payload DelegationScope
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("expected valid payload, got %v", err)
+		}
+		if r.Header.Get("Authorization") == "Bearer valid-token" {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"success":true,"data":{"id":"delegation-id","user_id":"` + payload.UserId + `","role_ids":["member"]}}`))
+		} else {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"success":false,"message":"Forbidden"}`))
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret")
+
+	t.Run("Valid Token", func(t *testing.T) {
+		scope := &DelegationScope{UserId: "lead-id", RoleIds: []string{"member"}}
+		if err := service.CreateDelegation(context.Background(), scope, "valid-token"); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if scope.Id != "delegation-id" {
+			t.Fatalf("expected delegation ID to be set, got %+v", scope)
+		}
+	})
+
+	t.Run("Invalid Token", func(t *testing.T) {
+		scope := &DelegationScope{UserId: "lead-id"}
+		if err := service.CreateDelegation(context.Background(), scope, "invalid-token"); err == nil {
+			t.Fatal("expected an error, got none")
+		}
+	})
+}
@@ -0,0 +1,221 @@
+package golang
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DefaultMaxClockSkew is the clock skew Doctor tolerates if
+// DoctorOptions.MaxClockSkew is zero.
+const DefaultMaxClockSkew = 30 * time.Second
+
+// Checks run by Doctor, in order.
+const (
+	CheckConnectivity = "connectivity" // The server answers at the base URL
+	CheckCredentials  = "credentials"  // The client ID and secret are accepted
+	CheckClockSkew    = "clock_skew"   // The local clock agrees with the server's
+	CheckCapabilities = "capabilities" // The server supports the required capabilities
+	CheckCallbackURL  = "callback_url" // The callback URL is registered for the client
+)
+
+// CheckStatus is the outcome of a Doctor check.
+type CheckStatus string
+
+const (
+	CheckPassed  CheckStatus = "passed"
+	CheckWarning CheckStatus = "warning" // Likely to cause problems, but not broken
+	CheckFailed  CheckStatus = "failed"
+	CheckSkipped CheckStatus = "skipped" // Not applicable or blocked by a failed check
+)
+
+// ServerInfo describes the go-iam server.
+type ServerInfo struct {
+	Version      string   `json:"version"`      // Version of the server
+	Capabilities []string `json:"capabilities"` // Optional features the server supports
+}
+
+type ServerInfoResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    *ServerInfo `json:"data,omitempty"`
+}
+
+// DoctorOptions are the expectations Doctor checks the configuration
+// against. Checks whose expectation is unset are skipped.
+type DoctorOptions struct {
+	RequiredCapabilities []string      // Capabilities the application relies on
+	CallbackURL          string        // Redirect URI the application receives codes on
+	MaxClockSkew         time.Duration // Tolerated clock skew, DefaultMaxClockSkew if zero
+}
+
+// DoctorCheck is the outcome of a single Doctor check.
+type DoctorCheck struct {
+	Name   string      `json:"name"`             // One of the Check* names
+	Status CheckStatus `json:"status"`           // Outcome of the check
+	Detail string      `json:"detail,omitempty"` // What was found, and how to fix it on failure
+	Err    error       `json:"-"`                // Error the check failed with, if any
+}
+
+// DoctorReport is the result of Doctor.
+type DoctorReport struct {
+	Checks    []DoctorCheck `json:"checks"`           // Every check, in the order they ran
+	Server    *ServerInfo   `json:"server,omitempty"` // Server information, if the server reports it
+	ClockSkew time.Duration `json:"clock_skew"`       // Server clock minus local clock, at one second resolution
+}
+
+// OK reports whether no check failed. Warnings do not count as failures.
+func (r *DoctorReport) OK() bool {
+	return r.Err() == nil
+}
+
+// Err joins the errors of the failed checks, nil if none failed.
+func (r *DoctorReport) Err() error {
+	var errs []error
+	for _, c := range r.Checks {
+		if c.Status != CheckFailed {
+			continue
+		}
+		err := c.Err
+		if err == nil {
+			err = errors.New(c.Detail)
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", c.Name, err))
+	}
+	return errors.Join(errs...)
+}
+
+// String lists the checks one per line, e.g. "clock_skew: warning: ...".
+func (r *DoctorReport) String() string {
+	var b strings.Builder
+	for _, c := range r.Checks {
+		fmt.Fprintf(&b, "%s: %s", c.Name, c.Status)
+		if c.Detail != "" {
+			fmt.Fprintf(&b, ": %s", c.Detail)
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// Doctor checks the configuration of the service against the server, for
+// startup self-checks and troubleshooting: that the server is reachable,
+// that the credential selected on ctx is valid, that the clocks agree, that
+// the server supports the required capabilities and that the callback URL is
+// registered for the client. Every check is reported, problems included; the
+// report fails a check rather than returning an error.
+func (s *serviceImpl) Doctor(ctx context.Context, opts DoctorOptions) *DoctorReport {
+	ctx = scopeIdempotencyKey(ctx, "")
+	report := &DoctorReport{}
+	add := func(name string, status CheckStatus, detail string, err error) {
+		report.Checks = append(report.Checks, DoctorCheck{Name: name, Status: status, Detail: detail, Err: err})
+	}
+	skipRest := func(after string, reason string) {
+		names := []string{CheckConnectivity, CheckCredentials, CheckClockSkew, CheckCapabilities, CheckCallbackURL}
+		for _, name := range names[slices.Index(names, after)+1:] {
+			add(name, CheckSkipped, reason, nil)
+		}
+	}
+
+	sent := time.Now()
+	result := ServerInfoResponse{}
+	resp, infoErr := s.call(ctx, apiRequest{
+		method: http.MethodGet,
+		path:   "/meta/v1/info",
+		basic:  true,
+		action: "fetch server info",
+	}, &result)
+	local := sent.Add(time.Since(sent) / 2)
+	var apiErr *APIError
+	if resp == nil || (infoErr != nil && !errors.As(infoErr, &apiErr)) {
+		add(CheckConnectivity, CheckFailed, fmt.Sprintf("cannot reach the server at %s, check the base URL and network access", s.baseURL), infoErr)
+		skipRest(CheckConnectivity, "the server is unreachable")
+		return report
+	}
+	add(CheckConnectivity, CheckPassed, "", nil)
+	if infoErr == nil {
+		report.Server = result.Data
+	}
+
+	token, err := s.exchangeClientCredentials(ctx)
+	switch {
+	case errors.Is(err, ErrUnauthorized), errors.Is(err, ErrForbidden):
+		add(CheckCredentials, CheckFailed, "the client ID or secret is rejected, check them and that the client is enabled", err)
+	case err != nil:
+		add(CheckCredentials, CheckFailed, "cannot exchange the client credentials", err)
+	default:
+		add(CheckCredentials, CheckPassed, "", nil)
+	}
+
+	check, skew := clockSkewCheck(resp, local, opts.MaxClockSkew)
+	report.Checks, report.ClockSkew = append(report.Checks, check), skew
+
+	switch {
+	case len(opts.RequiredCapabilities) == 0:
+		add(CheckCapabilities, CheckSkipped, "no capabilities are required", nil)
+	case report.Server == nil && token == nil:
+		add(CheckCapabilities, CheckSkipped, "the credentials are invalid", nil)
+	case report.Server == nil:
+		add(CheckCapabilities, CheckWarning, "the server does not report its capabilities, it may be too old", infoErr)
+	default:
+		var missing []string
+		for _, c := range opts.RequiredCapabilities {
+			if !slices.Contains(report.Server.Capabilities, c) {
+				missing = append(missing, c)
+			}
+		}
+		if len(missing) > 0 {
+			add(CheckCapabilities, CheckFailed, fmt.Sprintf("server %s lacks %s, upgrade it", report.Server.Version, strings.Join(missing, ", ")), nil)
+		} else {
+			add(CheckCapabilities, CheckPassed, "", nil)
+		}
+	}
+
+	switch {
+	case opts.CallbackURL == "":
+		add(CheckCallbackURL, CheckSkipped, "no callback URL given", nil)
+	case token == nil:
+		add(CheckCallbackURL, CheckSkipped, "the credentials are invalid", nil)
+	default:
+		cred, _ := s.credentialFor(ctx)
+		config, err := s.GetClientConfig(ctx, cred.ClientID, token.AccessToken)
+		switch {
+		case err != nil:
+			add(CheckCallbackURL, CheckWarning, "cannot fetch the client configuration to check the callback URL", err)
+		case !slices.Contains(config.RedirectURIs, opts.CallbackURL):
+			add(CheckCallbackURL, CheckFailed, fmt.Sprintf("%s is not a redirect URI of client %s, register it", opts.CallbackURL, cred.ClientID), nil)
+		default:
+			add(CheckCallbackURL, CheckPassed, "", nil)
+		}
+	}
+
+	return report
+}
+
+// clockSkewCheck compares the Date header of resp with the local time at
+// which the server answered, and returns the check with the measured skew.
+func clockSkewCheck(resp *http.Response, local time.Time, max time.Duration) (DoctorCheck, time.Duration) {
+	check := DoctorCheck{Name: CheckClockSkew, Status: CheckPassed}
+	if max <= 0 {
+		max = DefaultMaxClockSkew
+	}
+	date, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		check.Status, check.Detail = CheckSkipped, "the server sends no Date header"
+		return check, 0
+	}
+	skew := date.Sub(local).Round(time.Second)
+	if skew.Abs() > max {
+		direction := "behind"
+		if skew < 0 {
+			direction = "ahead of"
+		}
+		check.Status = CheckFailed
+		check.Detail = fmt.Sprintf("the local clock is %v %s the server's, tokens may be rejected, sync it with NTP", skew.Abs(), direction)
+	}
+	return check, skew
+}
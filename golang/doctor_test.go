@@ -0,0 +1,98 @@
+package golang
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDoctor(t *testing.T) {
+	var skew time.Duration
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", time.Now().Add(skew).UTC().Format(http.TimeFormat))
+		if id, secret, _ := r.BasicAuth(); r.URL.Path != "/client/v1/client-id/config" && (id != "client-id" || secret != "secret") {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"success":false,"message":"Invalid client credentials"}`))
+			return
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "GET /meta/v1/info":
+			w.Write([]byte(`{"success":true,"data":{"version":"1.4.0","capabilities":["dpop","support-sessions"]}}`))
+		case "POST /auth/v1/token":
+			w.Write([]byte(`{"success":true,"data":{"access_token":"service-token","expires_in":3600}}`))
+		case "GET /client/v1/client-id/config":
+			if r.Header.Get("Authorization") != "Bearer service-token" {
+				t.Fatalf("expected the exchanged token, got %q", r.Header.Get("Authorization"))
+			}
+			w.Write([]byte(`{"success":true,"data":{"client_id":"client-id","redirect_uris":["https://app.example.com/callback"]}}`))
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+	ctx := context.Background()
+
+	status := func(report *DoctorReport) string {
+		var s []string
+		for _, c := range report.Checks {
+			s = append(s, c.Name+"="+string(c.Status))
+		}
+		return strings.Join(s, " ")
+	}
+
+	t.Run("Healthy", func(t *testing.T) {
+		skew = 0
+		report := NewService(ts.URL, "client-id", "secret").Doctor(ctx, DoctorOptions{
+			RequiredCapabilities: []string{"dpop"},
+			CallbackURL:          "https://app.example.com/callback",
+		})
+		want := "connectivity=passed credentials=passed clock_skew=passed capabilities=passed callback_url=passed"
+		if got := status(report); got != want || !report.OK() || report.Server.Version != "1.4.0" {
+			t.Fatalf("expected %q, got %q:\n%s", want, got, report)
+		}
+	})
+
+	t.Run("Misconfigured", func(t *testing.T) {
+		skew = 2 * time.Minute
+		report := NewService(ts.URL, "client-id", "secret").Doctor(ctx, DoctorOptions{
+			RequiredCapabilities: []string{"dpop", "scim"},
+			CallbackURL:          "https://app.example.com/other",
+		})
+		want := "connectivity=passed credentials=passed clock_skew=failed capabilities=failed callback_url=failed"
+		if got := status(report); got != want || report.OK() {
+			t.Fatalf("expected %q, got %q:\n%s", want, got, report)
+		}
+		if report.ClockSkew < time.Minute || !strings.Contains(report.Checks[2].Detail, "behind") {
+			t.Fatalf("expected the local clock to be reported behind, got %v: %s", report.ClockSkew, report.Checks[2].Detail)
+		}
+		if !strings.Contains(report.Checks[3].Detail, "scim") {
+			t.Fatalf("expected the missing capability to be named, got %s", report.Checks[3].Detail)
+		}
+	})
+
+	t.Run("Invalid Credentials", func(t *testing.T) {
+		skew = 0
+		report := NewService(ts.URL, "client-id", "wrong").Doctor(ctx, DoctorOptions{
+			RequiredCapabilities: []string{"dpop"},
+			CallbackURL:          "https://app.example.com/callback",
+		})
+		want := "connectivity=passed credentials=failed clock_skew=passed capabilities=skipped callback_url=skipped"
+		if got := status(report); got != want || !errors.Is(report.Err(), ErrUnauthorized) {
+			t.Fatalf("expected %q with ErrUnauthorized, got %q: %v", want, got, report.Err())
+		}
+	})
+
+	t.Run("Unreachable", func(t *testing.T) {
+		report := NewService("http://127.0.0.1:1", "client-id", "secret").Doctor(ctx, DoctorOptions{})
+		want := "connectivity=failed credentials=skipped clock_skew=skipped capabilities=skipped callback_url=skipped"
+		if got := status(report); got != want || report.OK() {
+			t.Fatalf("expected %q, got %q", want, got)
+		}
+	})
+}
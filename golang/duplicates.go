@@ -0,0 +1,79 @@
+package golang
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Attributes on which two user accounts can look like duplicates.
+const (
+	DuplicateReasonEmail = "email" // Same email address, ignoring case
+	DuplicateReasonPhone = "phone" // Same phone number
+	DuplicateReasonName  = "name"  // Same display name
+)
+
+// DuplicateCandidate is an account that may belong to the same person as the
+// user FindPossibleDuplicates was called for.
+type DuplicateCandidate struct {
+	User    User     `json:"user"`    // The possibly duplicate account
+	Reasons []string `json:"reasons"` // Matching attributes, DuplicateReason* constants
+	Score   float64  `json:"score"`   // Confidence between 0 and 1 that the accounts are the same person
+}
+
+type DuplicateCandidatesResponse struct {
+	Success bool                 `json:"success"`
+	Message string               `json:"message"`
+	Data    []DuplicateCandidate `json:"data,omitempty"`
+}
+
+// FindPossibleDuplicates lists the accounts that may belong to the same
+// person as the user with the provided ID, e.g. created through different
+// social logins, most likely first.
+func (s *serviceImpl) FindPossibleDuplicates(ctx context.Context, userID string, token string) ([]DuplicateCandidate, error) {
+	result := DuplicateCandidatesResponse{}
+	if _, err := s.call(ctx, apiRequest{
+		method: http.MethodGet,
+		path:   "/user/v1/" + url.PathEscape(userID) + "/duplicates",
+		token:  token,
+		action: "find possible duplicates",
+	}, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
+}
+
+// MergeUsers merges the duplicate account into the primary one: the roles,
+// resources and policies of the duplicate are added to the primary, its
+// identities and tokens are re-pointed to the primary and it is deleted. It
+// returns the merged primary user. The service's cache is cleared, since it
+// may hold either account.
+func (s *serviceImpl) MergeUsers(ctx context.Context, primaryID string, duplicateID string, token string) (*User, error) {
+	if primaryID == "" || duplicateID == "" {
+		return nil, fmt.Errorf("user IDs cannot be empty")
+	}
+	if primaryID == duplicateID {
+		return nil, fmt.Errorf("cannot merge user %q into itself", primaryID)
+	}
+
+	defer s.purge(ctx)
+
+	result := UserResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodPost,
+		path:   "/user/v1/" + url.PathEscape(primaryID) + "/merge",
+		body:   map[string]string{"duplicate_id": duplicateID},
+		token:  token,
+		action: "merge users",
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Data == nil {
+		return nil, fmt.Errorf("failed to merge users: empty response. Status: %s", resp.Status)
+	}
+
+	return result.Data, nil
+}
@@ -0,0 +1,89 @@
+package golang
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFindPossibleDuplicates(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/user/v1/user-id/duplicates" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") == "Bearer valid-token" {
+			w.Write([]byte(`{"success":true,"data":[{"user":{"id":"other-id","email":"User@example.com"},"reasons":["email"],"score":0.9}]}`))
+		} else {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"success":false,"message":"Invalid token"}`))
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret")
+
+	t.Run("Valid Token", func(t *testing.T) {
+		candidates, err := service.FindPossibleDuplicates(context.Background(), "user-id", "valid-token")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(candidates) != 1 || candidates[0].User.Id != "other-id" || candidates[0].Reasons[0] != DuplicateReasonEmail {
+			t.Fatalf("unexpected candidates %+v", candidates)
+		}
+	})
+
+	t.Run("Invalid Token", func(t *testing.T) {
+		if _, err := service.FindPossibleDuplicates(context.Background(), "user-id", "invalid-token"); err == nil {
+			t.Fatal("expected an error, got none")
+		}
+	})
+}
+
+func TestMergeUsers(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/user/v1/user-id/merge" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var payload map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil || payload["duplicate_id"] != "other-id" {
+			t.Fatalf("unexpected payload %v, %v", payload, err)
+		}
+		if r.Header.Get("Authorization") == "Bearer valid-token" {
+			w.Write([]byte(`{"success":true,"data":{"id":"user-id","roles":{"role-1":{"id":"role-1"},"role-2":{"id":"role-2"}}}}`))
+		} else {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"success":false,"message":"Invalid token"}`))
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret")
+
+	t.Run("Valid Token", func(t *testing.T) {
+		user, err := service.MergeUsers(context.Background(), "user-id", "other-id", "valid-token")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !user.HasRole("role-1") || !user.HasRole("role-2") {
+			t.Fatalf("expected the merged roles, got %+v", user.Roles)
+		}
+	})
+
+	t.Run("Invalid Token", func(t *testing.T) {
+		if _, err := service.MergeUsers(context.Background(), "user-id", "other-id", "invalid-token"); err == nil {
+			t.Fatal("expected an error, got none")
+		}
+	})
+
+	t.Run("Same User", func(t *testing.T) {
+		if _, err := service.MergeUsers(context.Background(), "user-id", "user-id", "valid-token"); err == nil {
+			t.Fatal("expected an error, got none")
+		}
+	})
+}
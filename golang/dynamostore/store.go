@@ -0,0 +1,197 @@
+// Package dynamostore implements golang.CacheStore on an Amazon DynamoDB
+// table, for WithServerless and WithCacheStore in AWS Lambda.
+//
+// The table needs a string partition key named "pk". Enable Time to Live on
+// its "expires" attribute so DynamoDB deletes expired entries; until it does,
+// Get reports them as absent. The store calls the DynamoDB JSON API directly,
+// signing requests with the credentials Lambda provides in the environment,
+// so it does not depend on the AWS SDK:
+//
+//	store := dynamostore.New("goiam-cache", "")
+//	service := golang.NewService(baseURL, clientID, secret, golang.WithServerless(store))
+package dynamostore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/melvinodsa/go-iam-sdk/golang"
+)
+
+var _ golang.CacheStore = (*Store)(nil)
+
+// ErrMissingCredentials is returned by calls of a Store without AWS
+// credentials.
+var ErrMissingCredentials = errors.New("missing AWS credentials")
+
+// Credentials are the AWS credentials requests are signed with.
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string // Set for temporary credentials, e.g. of a Lambda role
+}
+
+// EnvCredentials returns the credentials of the AWS_ACCESS_KEY_ID,
+// AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN environment variables, which
+// Lambda sets to the credentials of the function's role.
+func EnvCredentials() Credentials {
+	return Credentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+}
+
+// Store is a golang.CacheStore on a DynamoDB table. It is safe for
+// concurrent use.
+type Store struct {
+	table       string
+	region      string
+	endpoint    string
+	credentials Credentials
+	client      *http.Client
+	now         func() time.Time
+}
+
+// Option configures a Store.
+type Option func(*Store)
+
+// WithCredentials signs requests with credentials instead of the ones of the
+// environment.
+func WithCredentials(credentials Credentials) Option {
+	return func(s *Store) {
+		s.credentials = credentials
+	}
+}
+
+// WithEndpoint sends requests to endpoint instead of the regional DynamoDB
+// endpoint, e.g. to DynamoDB Local or a VPC endpoint.
+func WithEndpoint(endpoint string) Option {
+	return func(s *Store) {
+		s.endpoint = strings.TrimSuffix(endpoint, "/")
+	}
+}
+
+// WithHTTPClient sends requests with client instead of http.DefaultClient.
+func WithHTTPClient(client *http.Client) Option {
+	return func(s *Store) {
+		s.client = client
+	}
+}
+
+// New returns a Store on the table in region, the region of the AWS_REGION
+// environment variable if empty.
+func New(table, region string, opts ...Option) *Store {
+	if region == "" {
+		region = os.Getenv("AWS_REGION")
+	}
+	s := &Store{
+		table:       table,
+		region:      region,
+		endpoint:    "https://dynamodb." + region + ".amazonaws.com",
+		credentials: EnvCredentials(),
+		client:      http.DefaultClient,
+		now:         time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// attribute is a DynamoDB attribute value of the JSON API.
+type attribute struct {
+	S string `json:"S,omitempty"`
+	N string `json:"N,omitempty"`
+	B []byte `json:"B,omitempty"`
+}
+
+// Get returns the value stored for key, if it has not expired.
+func (s *Store) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	var out struct {
+		Item map[string]attribute `json:"Item"`
+	}
+	err := s.do(ctx, "GetItem", map[string]any{
+		"TableName":      s.table,
+		"Key":            map[string]attribute{"pk": {S: key}},
+		"ConsistentRead": true,
+	}, &out)
+	if err != nil || out.Item == nil {
+		return nil, false, err
+	}
+	expires, err := strconv.ParseInt(out.Item["expires"].N, 10, 64)
+	if err != nil || s.now().Unix() >= expires {
+		return nil, false, nil
+	}
+	return out.Item["value"].B, true, nil
+}
+
+// Set stores value for key until ttl has passed.
+func (s *Store) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if ttl <= 0 {
+		return nil
+	}
+	expires := s.now().Add(ttl)
+	return s.do(ctx, "PutItem", map[string]any{
+		"TableName": s.table,
+		"Item": map[string]attribute{
+			"pk":      {S: key},
+			"value":   {B: value},
+			"expires": {N: strconv.FormatInt(expires.Unix(), 10)},
+		},
+	}, nil)
+}
+
+// do calls the action of the DynamoDB API with input, decoding the response
+// into out if not nil.
+func (s *Store) do(ctx context.Context, action string, input any, out any) error {
+	if s.credentials.AccessKeyID == "" || s.credentials.SecretAccessKey == "" {
+		return ErrMissingCredentials
+	}
+	body, err := json.Marshal(input)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s input: %w", action, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint+"/", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create %s request: %w", action, err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.0")
+	req.Header.Set("X-Amz-Target", "DynamoDB_20120810."+action)
+	sign(req, body, s.credentials, s.region, "dynamodb", s.now())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call %s: %w", action, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read %s response: %w", action, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}
+		json.Unmarshal(data, &apiErr)
+		kind := apiErr.Type[strings.LastIndex(apiErr.Type, "#")+1:]
+		return fmt.Errorf("%s failed with status %d: %s %s", action, resp.StatusCode, kind, apiErr.Message)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to unmarshal %s response: %w", action, err)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package dynamostore
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/melvinodsa/go-iam-sdk/golang"
+)
+
+// newDynamoServer serves GetItem and PutItem on the table "cache" in memory.
+func newDynamoServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	var mu sync.Mutex
+	items := map[string]map[string]attribute{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=key-id/") ||
+			r.Header.Get("X-Amz-Security-Token") != "session-token" {
+			t.Errorf("expected a signed request, got %v", r.Header)
+		}
+		var input struct {
+			TableName string
+			Key       map[string]attribute
+			Item      map[string]attribute
+		}
+		json.NewDecoder(r.Body).Decode(&input)
+		if input.TableName != "cache" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"__type":"com.amazonaws.dynamodb.v20120810#ResourceNotFoundException","message":"Requested resource not found"}`))
+			return
+		}
+		switch r.Header.Get("X-Amz-Target") {
+		case "DynamoDB_20120810.GetItem":
+			json.NewEncoder(w).Encode(map[string]any{"Item": items[input.Key["pk"].S]})
+		case "DynamoDB_20120810.PutItem":
+			items[input.Item["pk"].S] = input.Item
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected target %q", r.Header.Get("X-Amz-Target"))
+		}
+	}))
+}
+
+func TestStore(t *testing.T) {
+	ts := newDynamoServer(t)
+	defer ts.Close()
+
+	credentials := WithCredentials(Credentials{AccessKeyID: "key-id", SecretAccessKey: "secret", SessionToken: "session-token"})
+	store := New("cache", "eu-west-1", credentials, WithEndpoint(ts.URL))
+	ctx := context.Background()
+
+	if _, ok, err := store.Get(ctx, "key"); ok || err != nil {
+		t.Fatalf("expected a missing key, got %v, %v", ok, err)
+	}
+	if err := store.Set(ctx, "key", []byte("value"), time.Minute); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if value, ok, err := store.Get(ctx, "key"); !ok || err != nil || string(value) != "value" {
+		t.Fatalf("expected the value, got %q, %v, %v", value, ok, err)
+	}
+
+	// DynamoDB deletes expired items with a delay.
+	store.now = func() time.Time { return time.Now().Add(time.Minute) }
+	if _, ok, err := store.Get(ctx, "key"); ok || err != nil {
+		t.Fatalf("expected an expired item to be absent, got %v, %v", ok, err)
+	}
+
+	missing := New("missing", "eu-west-1", credentials, WithEndpoint(ts.URL))
+	if err := missing.Set(ctx, "key", []byte("value"), time.Minute); err == nil || !strings.Contains(err.Error(), "ResourceNotFoundException") {
+		t.Fatalf("expected the DynamoDB error, got %v", err)
+	}
+
+	unsigned := New("cache", "eu-west-1", WithCredentials(Credentials{}), WithEndpoint(ts.URL))
+	if _, _, err := unsigned.Get(ctx, "key"); err != ErrMissingCredentials {
+		t.Fatalf("expected ErrMissingCredentials, got %v", err)
+	}
+}
+
+func TestStoreWithServerless(t *testing.T) {
+	dynamo := newDynamoServer(t)
+	defer dynamo.Close()
+
+	var calls int32
+	iam := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(`{"success":true,"data":{"id":"user-id"}}`))
+	}))
+	defer iam.Close()
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "key-id")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_SESSION_TOKEN", "session-token")
+	t.Setenv("AWS_REGION", "eu-west-1")
+	ctx := context.Background()
+
+	// Every service stands for a cold start of a function instance.
+	for range 2 {
+		service := golang.NewService(iam.URL, "client-id", "secret", golang.WithServerless(New("cache", "", WithEndpoint(dynamo.URL))))
+		if user, err := service.Me(ctx, "token"); err != nil || user.Id != "user-id" {
+			t.Fatalf("expected the user, got %+v, %v", user, err)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("expected the second instance to resolve the token from DynamoDB, got %d calls", calls)
+	}
+}
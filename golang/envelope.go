@@ -0,0 +1,82 @@
+package golang
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// EnvelopeAdapter rewrites the body of a response into the envelope shape the
+// SDK expects, {"success":..,"message":..,"code":..,"data":..}, e.g. to talk
+// to go-iam versions using other field names. It is called with the status
+// code and body of every response, which may not be JSON, and returns the
+// body to decode.
+type EnvelopeAdapter func(status int, body []byte) ([]byte, error)
+
+// WithEnvelopeAdapter makes the service pass response bodies through adapter
+// before decoding them. A panicking adapter fails the call with a *PanicError.
+func WithEnvelopeAdapter(adapter EnvelopeAdapter) Option {
+	return func(s *serviceImpl) {
+		s.envelopeAdapter = adapter
+	}
+}
+
+// CompatibleEnvelope is an EnvelopeAdapter for the envelopes of older and
+// self-hosted go-iam versions. Missing standard fields are filled in from
+// their alternates:
+//
+//   - data from result
+//   - message from error, either a string or an object with message and code
+//   - success from whether the status is 2xx and error is absent or empty
+//
+// Bodies that are not JSON objects and standard envelopes are left as is.
+func CompatibleEnvelope(status int, body []byte) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil || fields == nil {
+		return body, nil
+	}
+	changed := false
+
+	if _, ok := fields["data"]; !ok {
+		if result, ok := fields["result"]; ok {
+			fields["data"], changed = result, true
+		}
+	}
+
+	rawErr, hasErr := fields["error"]
+	hasErr = hasErr && !isEmptyJSON(rawErr)
+	if _, ok := fields["message"]; !ok && hasErr {
+		var msg string
+		var obj struct {
+			Message string `json:"message"`
+			Code    string `json:"code"`
+		}
+		if json.Unmarshal(rawErr, &msg) == nil {
+			fields["message"], _ = json.Marshal(msg)
+		} else if json.Unmarshal(rawErr, &obj) == nil {
+			fields["message"], _ = json.Marshal(obj.Message)
+			if _, ok := fields["code"]; !ok && obj.Code != "" {
+				fields["code"], _ = json.Marshal(obj.Code)
+			}
+		}
+		changed = true
+	}
+
+	if _, ok := fields["success"]; !ok {
+		fields["success"], _ = json.Marshal(!hasErr && status >= 200 && status < 300)
+		changed = true
+	}
+
+	if !changed {
+		return body, nil
+	}
+	return json.Marshal(fields)
+}
+
+// isEmptyJSON reports whether v is null, false, an empty string, object or array.
+func isEmptyJSON(v json.RawMessage) bool {
+	switch string(bytes.TrimSpace(v)) {
+	case "null", "false", `""`, "{}", "[]":
+		return true
+	}
+	return false
+}
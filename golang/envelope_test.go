@@ -0,0 +1,88 @@
+package golang
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCompatibleEnvelope(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   string
+	}{
+		{"Standard", http.StatusOK, `{"success":true,"data":{"id":"x"}}`, `{"success":true,"data":{"id":"x"}}`},
+		{"Result", http.StatusOK, `{"result":{"id":"x"}}`, `{"data":{"id":"x"},"result":{"id":"x"},"success":true}`},
+		{"String Error", http.StatusOK, `{"error":"Invalid token"}`, `{"error":"Invalid token","message":"Invalid token","success":false}`},
+		{"Object Error", http.StatusOK, `{"success":false,"error":{"message":"Taken","code":"DUPLICATE"}}`, `{"code":"DUPLICATE","error":{"message":"Taken","code":"DUPLICATE"},"message":"Taken","success":false}`},
+		{"Null Error", http.StatusOK, `{"error":null,"result":[]}`, `{"data":[],"error":null,"result":[],"success":true}`},
+		{"Error Status", http.StatusBadGateway, `{"message":"Internal server error"}`, `{"message":"Internal server error","success":false}`},
+		{"Not JSON", http.StatusOK, `<html></html>`, `<html></html>`},
+		{"Not An Object", http.StatusOK, `[1,2]`, `[1,2]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CompatibleEnvelope(tt.status, []byte(tt.body))
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if string(got) != tt.want {
+				t.Fatalf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWithEnvelopeAdapter(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("Authorization") {
+		case "Bearer valid-token":
+			w.Write([]byte(`{"result":{"id":"user-id","name":"Test User"}}`))
+		case "Bearer proxied-token":
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte(`{"message":"Internal server error"}`))
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":{"message":"Invalid token","code":"TOKEN_INVALID"}}`))
+		}
+	}))
+	defer ts.Close()
+
+	t.Run("Without Adapter", func(t *testing.T) {
+		service := NewService(ts.URL, "client-id", "secret")
+		if _, err := service.Me(context.Background(), "valid-token"); err == nil {
+			t.Fatal("expected the alternate envelope to fail, got no error")
+		}
+	})
+
+	service := NewService(ts.URL, "client-id", "secret", WithEnvelopeAdapter(CompatibleEnvelope))
+
+	t.Run("Valid Token", func(t *testing.T) {
+		user, err := service.Me(context.Background(), "valid-token")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if user.Name != "Test User" {
+			t.Fatalf("expected Test User, got %+v", user)
+		}
+	})
+
+	t.Run("Invalid Token", func(t *testing.T) {
+		_, err := service.Me(context.Background(), "invalid-token")
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.Message != "Invalid token" || apiErr.Code != "TOKEN_INVALID" {
+			t.Fatalf("expected an APIError with the mapped message and code, got %v", err)
+		}
+	})
+
+	t.Run("Error Status Without Error Field", func(t *testing.T) {
+		user, err := service.Me(context.Background(), "proxied-token")
+		if user != nil || !errors.Is(err, ErrServer) {
+			t.Fatalf("expected ErrServer, got %+v, %v", user, err)
+		}
+	})
+}
@@ -0,0 +1,230 @@
+package golang
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Sentinel errors matched by APIError with errors.Is, so callers can tell
+// failure classes apart without inspecting status codes:
+//
+//	if errors.Is(err, golang.ErrUnauthorized) {
+//		// ask the user to log in again
+//	}
+var (
+	// ErrUnauthorized means the token is missing, invalid or expired (401).
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrForbidden means the token is valid but not allowed to make the call (403).
+	ErrForbidden = errors.New("forbidden")
+	// ErrNotFound means the entity the call refers to does not exist (404).
+	ErrNotFound = errors.New("not found")
+	// ErrConflict means the entity the call creates already exists (409).
+	ErrConflict = errors.New("conflict")
+	// ErrRateLimited means the server rejected the call for exceeding its rate limit (429).
+	ErrRateLimited = errors.New("rate limited")
+	// ErrServer means the server failed to handle the call (5xx).
+	ErrServer = errors.New("server error")
+)
+
+// APIError is returned when the go-iam server answers a call with an error,
+// either through a non-2xx status or an envelope with success set to false.
+// Use errors.As to inspect it.
+type APIError struct {
+	StatusCode int    // HTTP status code of the response
+	Status     string // HTTP status line of the response, e.g. "404 Not Found"
+	Message    string // Message from the response envelope, if any
+	Code       string // Error code from the response envelope, if any
+	Method     string // HTTP method of the request
+	Path       string // Path of the request below the base URL
+	Action     string // The call that failed, e.g. "create resource"
+	RequestID  string // ID sent in RequestIDHeader, to correlate with server logs
+	Err        error  // Underlying error, e.g. when the body could not be decoded
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("failed to %s: %s", e.Action, e.Status)
+	if e.Message != "" {
+		msg = fmt.Sprintf("failed to %s: %s. Status: %s", e.Action, e.Message, e.Status)
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the status code of the error belongs to the class of
+// the sentinel target.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServer:
+		return e.StatusCode >= http.StatusInternalServerError
+	}
+	return false
+}
+
+// ItemError is the failure of a single item of a bulk call.
+type ItemError struct {
+	Index int    // Position of the item in the input of the call
+	ID    string // ID or key of the item, empty if it has none or it is secret
+	Err   error  // Why the item failed
+}
+
+func (e *ItemError) Error() string {
+	if e.ID == "" {
+		return fmt.Sprintf("item %d: %v", e.Index, e.Err)
+	}
+	return fmt.Sprintf("item %d (%q): %v", e.Index, e.ID, e.Err)
+}
+
+func (e *ItemError) Unwrap() error {
+	return e.Err
+}
+
+// BulkError aggregates the failed items of a bulk call, such as
+// SyncResources, ResolveTokens or GetUsers, so callers can report exactly
+// which items failed. It unwraps to the ItemErrors, hence errors.Is matches
+// the sentinel of any failed item and errors.As finds the first *ItemError:
+//
+//	var bulk *golang.BulkError
+//	if errors.As(err, &bulk) {
+//		for _, item := range bulk.Items {
+//			log.Printf("%s failed: %v", item.ID, item.Err)
+//		}
+//	}
+type BulkError struct {
+	Action string       // The bulk call that failed, e.g. "sync resources"
+	Items  []*ItemError // Failed items, by index
+}
+
+func (e *BulkError) Error() string {
+	msgs := make([]string, len(e.Items))
+	for i, item := range e.Items {
+		msgs[i] = item.Error()
+	}
+	noun := "items"
+	if len(e.Items) == 1 {
+		noun = "item"
+	}
+	return fmt.Sprintf("failed to %s: %d %s failed: %s", e.Action, len(e.Items), noun, strings.Join(msgs, "; "))
+}
+
+func (e *BulkError) Unwrap() []error {
+	errs := make([]error, len(e.Items))
+	for i, item := range e.Items {
+		errs[i] = item
+	}
+	return errs
+}
+
+// Indexes returns the input positions of the failed items.
+func (e *BulkError) Indexes() []int {
+	indexes := make([]int, len(e.Items))
+	for i, item := range e.Items {
+		indexes[i] = item.Index
+	}
+	return indexes
+}
+
+// IDs returns the IDs of the failed items, skipping items without one.
+func (e *BulkError) IDs() []string {
+	var ids []string
+	for _, item := range e.Items {
+		if item.ID != "" {
+			ids = append(ids, item.ID)
+		}
+	}
+	return ids
+}
+
+// bulkError returns a *BulkError for the failed items sorted by index, or
+// nil if there are none.
+func bulkError(action string, items []*ItemError) error {
+	if len(items) == 0 {
+		return nil
+	}
+	slices.SortStableFunc(items, func(a, b *ItemError) int { return a.Index - b.Index })
+	return &BulkError{Action: action, Items: items}
+}
+
+// ResponseTooLargeError is returned when a response body is larger than the
+// limit set with WithMaxResponseBytes. The body is not read past the limit.
+type ResponseTooLargeError struct {
+	Limit      int64  // Maximum response size in bytes
+	StatusCode int    // HTTP status code of the response
+	Action     string // The call that failed, e.g. "list resources"
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("failed to %s: response body exceeds %d bytes. Status: %d", e.Action, e.Limit, e.StatusCode)
+}
+
+// NonJSONResponseError is returned, wrapped in an APIError for non-2xx
+// responses, when the response is not the JSON envelope of go-iam, typically
+// an HTML error page served by a proxy or load balancer in front of it.
+type NonJSONResponseError struct {
+	ContentType string // Content type of the response, if any
+	Snippet     string // Start of the body with whitespace collapsed, or the title of an HTML page
+}
+
+func (e *NonJSONResponseError) Error() string {
+	contentType := e.ContentType
+	if contentType == "" {
+		contentType = "unknown content type"
+	}
+	return fmt.Sprintf("unexpected non-JSON response (%s), likely from a proxy: %q", contentType, e.Snippet)
+}
+
+// maxSnippetLength bounds the body excerpt kept by NonJSONResponseError.
+const maxSnippetLength = 120
+
+// nonJSONResponse returns a *NonJSONResponseError if the response, which
+// could not be decoded, was not sent as JSON, or nil otherwise.
+func nonJSONResponse(contentType string, body []byte) error {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") {
+		return nil
+	}
+
+	text := string(body)
+	if title, ok := htmlTitle(text); ok {
+		text = title
+	}
+	text = strings.Join(strings.Fields(text), " ")
+	if len(text) > maxSnippetLength {
+		text = text[:maxSnippetLength] + "..."
+	}
+	text = strings.ToValidUTF8(text, "")
+	return &NonJSONResponseError{ContentType: contentType, Snippet: text}
+}
+
+// titlePattern matches the title element of an HTML page. Unlike lowering
+// the page first, it keeps byte offsets valid for bodies that are not UTF-8.
+var titlePattern = regexp.MustCompile(`(?is)<title>(.*?)</title>`)
+
+// htmlTitle returns the contents of the title element of an HTML page.
+func htmlTitle(page string) (string, bool) {
+	m := titlePattern.FindStringSubmatch(page)
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
@@ -0,0 +1,157 @@
+package golang
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestAPIError(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		target error
+	}{
+		{"unauthorized", http.StatusUnauthorized, `{"success":false,"message":"Token expired","code":"token_expired"}`, ErrUnauthorized},
+		{"forbidden", http.StatusForbidden, `{"success":false,"message":"Forbidden"}`, ErrForbidden},
+		{"not found", http.StatusNotFound, `{"success":false,"message":"Resource not found"}`, ErrNotFound},
+		{"rate limited", http.StatusTooManyRequests, `{"success":false,"message":"Slow down"}`, ErrRateLimited},
+		{"server error", http.StatusBadGateway, `<html>Bad Gateway</html>`, ErrServer},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer ts.Close()
+
+			_, err := NewService(ts.URL, "client-id", "secret").GetResource(context.Background(), "resource-id", "token")
+			if !errors.Is(err, tt.target) {
+				t.Fatalf("expected %v, got %v", tt.target, err)
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("expected an APIError, got %T", err)
+			}
+			if apiErr.StatusCode != tt.status || apiErr.Path != "/resource/v1/resource-id" || apiErr.Method != http.MethodGet {
+				t.Fatalf("unexpected APIError %+v", apiErr)
+			}
+			for _, other := range []error{ErrUnauthorized, ErrForbidden, ErrNotFound, ErrRateLimited, ErrServer} {
+				if other != tt.target && errors.Is(err, other) {
+					t.Fatalf("expected error not to match %v", other)
+				}
+			}
+		})
+	}
+}
+
+func TestAPIErrorEnvelope(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"success":false,"message":"Token expired","code":"token_expired"}`))
+	}))
+	defer ts.Close()
+
+	_, err := NewService(ts.URL, "client-id", "secret").Me(context.Background(), "expired-token")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an APIError, got %v", err)
+	}
+	if apiErr.Message != "Token expired" || apiErr.Code != "token_expired" {
+		t.Fatalf("unexpected APIError %+v", apiErr)
+	}
+	if err.Error() != "failed to fetch user information: Token expired. Status: 401 Unauthorized" {
+		t.Fatalf("unexpected message %q", err.Error())
+	}
+}
+
+func TestNonJSONResponse(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		contentType string
+		body        string
+		snippet     string
+	}{
+		{"HTML Error Page", http.StatusBadGateway, "text/html", "<html><head><title>502 Bad Gateway</title></head><body>nginx</body></html>", "502 Bad Gateway"},
+		{"Plain Text", http.StatusServiceUnavailable, "text/plain", "upstream connect error\n  or disconnect", "upstream connect error or disconnect"},
+		{"Latin-1 Error Page", http.StatusBadGateway, "text/html; charset=iso-8859-1", "<html><head><meta name=\"description\" content=\"\xe9\xe9\xe9\xe9\xe9\xe9\xe9\xe9\xe9\xe9\xe9\xe9\xe9\xe9\xe9\xe9\"><TITLE>502 Passerelle</TITLE></head></html>", "502 Passerelle"},
+		{"Latin-1 Text", http.StatusBadGateway, "text/plain", "Passerelle \xe9chou\xe9e", "Passerelle choue"},
+		{"HTML With Success Status", http.StatusOK, "text/html; charset=utf-8", "<html><body>Please log in</body></html>", "<html><body>Please log in</body></html>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer ts.Close()
+
+			_, err := NewService(ts.URL, "client-id", "secret").Me(context.Background(), "token")
+			var nonJSON *NonJSONResponseError
+			if !errors.As(err, &nonJSON) {
+				t.Fatalf("expected a NonJSONResponseError, got %v", err)
+			}
+			if nonJSON.Snippet != tt.snippet || nonJSON.ContentType != tt.contentType {
+				t.Fatalf("unexpected NonJSONResponseError %+v", nonJSON)
+			}
+			if tt.status >= http.StatusInternalServerError && !errors.Is(err, ErrServer) {
+				t.Fatalf("expected the error to match ErrServer, got %v", err)
+			}
+		})
+	}
+
+	t.Run("Malformed JSON", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte(`{"success":`))
+		}))
+		defer ts.Close()
+
+		_, err := NewService(ts.URL, "client-id", "secret").Me(context.Background(), "token")
+		var nonJSON *NonJSONResponseError
+		if err == nil || errors.As(err, &nonJSON) {
+			t.Fatalf("expected a decoding error, got %v", err)
+		}
+	})
+}
+
+func TestBulkError(t *testing.T) {
+	if err := ResolutionsErr([]TokenResolution{{Token: "a", User: &User{Id: "user-1"}}}); err != nil {
+		t.Fatalf("expected no error when every token resolves, got %v", err)
+	}
+
+	err := ResolutionsErr([]TokenResolution{
+		{Token: "a", User: &User{Id: "user-1"}},
+		{Token: "secret-b", Message: "Token expired"},
+		{Token: "secret-c"},
+	})
+	var bulk *BulkError
+	if !errors.As(err, &bulk) || !slices.Equal(bulk.Indexes(), []int{1, 2}) || !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("expected tokens 1 and 2 to fail with ErrUnauthorized, got %v", err)
+	}
+	if strings.Contains(err.Error(), "secret") || !strings.Contains(err.Error(), "Token expired") {
+		t.Fatalf("expected the message without the tokens, got %q", err)
+	}
+
+	err = MissingUsersErr([]string{"user-1", "user-2", "user-3"}, []User{{Id: "user-2"}})
+	var item *ItemError
+	if !errors.As(err, &item) || item.ID != "user-1" || !errors.Is(err, ErrNotFound) || errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("expected user-1 to be the first missing user, got %v", err)
+	}
+	if !errors.As(err, &bulk) || !slices.Equal(bulk.IDs(), []string{"user-1", "user-3"}) {
+		t.Fatalf("expected user-1 and user-3 to be missing, got %v", err)
+	}
+	want := `failed to fetch users: 2 items failed: item 0 ("user-1"): not found; item 2 ("user-3"): not found`
+	if err.Error() != want {
+		t.Fatalf("expected %q, got %q", want, err)
+	}
+}
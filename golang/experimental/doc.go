@@ -0,0 +1,14 @@
+// Package experimental is the home of SDK subsystems in preview, each in its
+// own sub-package, e.g. golang/experimental/<name>.
+//
+// Unlike the rest of the module, experimental packages are not covered by
+// the compatibility promise: their API may change or be removed in any minor
+// release, until the subsystem graduates into package golang. The files of
+// the sub-packages carry the goiam_preview build constraint, so they only
+// build with "-tags goiam_preview" and depending on one is an explicit
+// decision.
+//
+// Experimental subsystems never add methods to golang.Service; they take a
+// golang.Service, or a narrower interface, as an argument instead, so that
+// trying one does not affect mocks and wrappers of Service.
+package experimental
@@ -0,0 +1,8 @@
+// Package scan iterates over the users or resources of a project matching a
+// query, for sync jobs that may be interrupted, resuming from a persisted
+// cursor. It extends Service.ScanUsers and Service.ScanResources, which scan
+// every user or resource, with the filters of SearchUsers and ListResources.
+//
+// The package is in preview: its API may change in any minor release, and it
+// only builds with the goiam_preview build tag, see package experimental.
+package scan
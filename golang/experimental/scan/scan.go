@@ -0,0 +1,102 @@
+//go:build goiam_preview
+
+package scan
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	"github.com/melvinodsa/go-iam-sdk/golang"
+)
+
+// defaultPageSize is the page size Users and Resources fetch with when
+// ScanOptions.PageSize is not set.
+const defaultPageSize = 100
+
+// UserSearcher is the part of golang.Service Users needs.
+type UserSearcher interface {
+	SearchUsers(ctx context.Context, query golang.SearchUsersQuery, token string) (*golang.UserList, error)
+}
+
+// ResourceLister is the part of golang.Service Resources needs.
+type ResourceLister interface {
+	ListResources(ctx context.Context, query golang.ListResourcesQuery, token string) (*golang.ResourceList, error)
+}
+
+// Users iterates over the users matching query in ID order, with the
+// guarantees of Service.ScanUsers:
+//
+//	query := golang.SearchUsersQuery{Metadata: map[string]string{"tenant": "acme"}}
+//	for user, err := range scan.Users(ctx, service, query, golang.ScanOptions{Cursor: saved, Checkpoint: save}, token) {
+//		if err != nil {
+//			return err
+//		}
+//		// process user
+//	}
+//
+// The After, Page and Limit fields of query are set by the scan. Users that
+// stop matching the query during the scan may be missed, as may users
+// created during it.
+func Users(ctx context.Context, service UserSearcher, query golang.SearchUsersQuery, opts golang.ScanOptions, token string) iter.Seq2[golang.User, error] {
+	return pages(ctx, opts, func(u golang.User) string { return u.Id }, func(after string, limit int) ([]golang.User, error) {
+		query.After, query.Page, query.Limit = after, 0, limit
+		list, err := service.SearchUsers(ctx, query, token)
+		if err != nil {
+			return nil, err
+		}
+		return list.Users, nil
+	})
+}
+
+// Resources iterates over the resources matching query in ID order, with
+// the guarantees of Users.
+func Resources(ctx context.Context, service ResourceLister, query golang.ListResourcesQuery, opts golang.ScanOptions, token string) iter.Seq2[golang.Resource, error] {
+	return pages(ctx, opts, func(r golang.Resource) string { return r.ID }, func(after string, limit int) ([]golang.Resource, error) {
+		query.After, query.Page, query.Limit = after, 0, limit
+		list, err := service.ListResources(ctx, query, token)
+		if err != nil {
+			return nil, err
+		}
+		return list.Resources, nil
+	})
+}
+
+// pages iterates over the pages returned by list, each starting after the ID
+// of the last item of the previous one, until an empty page is returned. A
+// short page does not end the scan since servers may cap the page size.
+func pages[T any](ctx context.Context, opts golang.ScanOptions, id func(T) string, list func(after string, limit int) ([]T, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		limit := opts.PageSize
+		if limit <= 0 {
+			limit = defaultPageSize
+		}
+		cursor := opts.Cursor
+		for {
+			items, err := list(cursor, limit)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			cursor = ""
+			if len(items) > 0 {
+				cursor = id(items[len(items)-1])
+			}
+			if opts.Checkpoint != nil {
+				if err := opts.Checkpoint(ctx, cursor); err != nil {
+					yield(zero, fmt.Errorf("failed to save scan checkpoint: %w", err))
+					return
+				}
+			}
+			if cursor == "" {
+				return
+			}
+		}
+	}
+}
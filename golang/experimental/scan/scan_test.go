@@ -0,0 +1,172 @@
+//go:build goiam_preview
+
+package scan
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/melvinodsa/go-iam-sdk/golang"
+	"github.com/melvinodsa/go-iam-sdk/golang/golangtest"
+)
+
+func TestUsers(t *testing.T) {
+	var mu sync.Mutex
+	var ids []string
+	for i := 1; i <= 7; i++ {
+		ids = append(ids, fmt.Sprintf("user-%d", i))
+	}
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.URL.Path != "/user/v1/search" || r.URL.Query().Get("page") != "" || r.URL.Query().Get("email") != "@example.com" {
+			t.Fatalf("unexpected request %s", r.URL)
+		}
+		// The server caps pages at 2 users, whatever the requested limit.
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		users := []golang.User{}
+		for _, id := range ids {
+			if id > r.URL.Query().Get("after") && len(users) < min(limit, 2) {
+				users = append(users, golang.User{Id: id})
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"success": true, "data": golang.UserList{Users: users}})
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := golang.NewService(ts.URL, "client-id", "secret")
+	ctx := context.Background()
+	var saved []string
+	query := golang.SearchUsersQuery{Email: "@example.com", Page: 4}
+	opts := golang.ScanOptions{PageSize: 3, Checkpoint: func(ctx context.Context, cursor string) error {
+		saved = append(saved, cursor)
+		return nil
+	}}
+
+	// The first run is interrupted while processing user-3, and user-2 is
+	// deleted before the next run.
+	var seen []string
+	for user, err := range Users(ctx, service, query, opts, "token") {
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if user.Id == "user-3" {
+			break
+		}
+		seen = append(seen, user.Id)
+	}
+	if !slices.Equal(saved, []string{"user-2"}) {
+		t.Fatalf("expected the cursor to stay before the interrupted page, got %v", saved)
+	}
+	mu.Lock()
+	ids = slices.DeleteFunc(ids, func(id string) bool { return id == "user-2" })
+	mu.Unlock()
+
+	opts.Cursor = saved[len(saved)-1]
+	for user, err := range Users(ctx, service, query, opts, "token") {
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		seen = append(seen, user.Id)
+	}
+	want := []string{"user-1", "user-2", "user-3", "user-4", "user-5", "user-6", "user-7"}
+	if !slices.Equal(seen, want) {
+		t.Fatalf("expected every user once across both runs, got %v", seen)
+	}
+	if saved[len(saved)-1] != "" {
+		t.Fatalf("expected the cursor to be reset once complete, got %v", saved)
+	}
+
+	failing := errors.New("database unavailable")
+	opts = golang.ScanOptions{Checkpoint: func(ctx context.Context, cursor string) error { return failing }}
+	var n int
+	for _, err := range Users(ctx, service, query, opts, "token") {
+		if err != nil {
+			if !errors.Is(err, failing) || n != 2 {
+				t.Fatalf("expected the checkpoint error after the first page, got %v after %d users", err, n)
+			}
+			break
+		}
+		n++
+	}
+}
+
+func TestResources(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("key") != "billing" {
+			t.Errorf("expected the key filter, got %s", r.URL)
+		}
+		switch r.URL.Query().Get("after") {
+		case "":
+			w.Write([]byte(`{"success":true,"data":{"resources":[{"id":"id-1"},{"id":"id-2"}]}}`))
+		case "id-2":
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"success":false,"message":"Internal error"}`))
+		default:
+			t.Errorf("unexpected cursor %q", r.URL.Query().Get("after"))
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	var ids []string
+	var errs []error
+	for resource, err := range Resources(context.Background(), golang.NewService(ts.URL, "client-id", "secret"), golang.ListResourcesQuery{Key: "billing"}, golang.ScanOptions{PageSize: 2}, "token") {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		ids = append(ids, resource.ID)
+	}
+	if !slices.Equal(ids, []string{"id-1", "id-2"}) || len(errs) != 1 || !errors.Is(errs[0], golang.ErrServer) {
+		t.Fatalf("expected both resources and then the error, got %v and %v", ids, errs)
+	}
+}
+
+func TestUsersFakeService(t *testing.T) {
+	fake := golangtest.NewFakeService()
+	for _, id := range []string{"user-a", "user-b", "user-c", "user-d"} {
+		fake.AddUser(golang.User{Id: id, Email: id + "@example.com"})
+	}
+	fake.AddUser(golang.User{Id: "user-bb", Email: "user-bb@other.com"})
+	query := golang.SearchUsersQuery{Email: "@example.com"}
+	fake.AddToken("valid-token", "user-a")
+	ctx := context.Background()
+
+	var cursor string
+	opts := golang.ScanOptions{PageSize: 2, Checkpoint: func(ctx context.Context, c string) error {
+		cursor = c
+		return nil
+	}}
+	for user, err := range Users(ctx, fake, query, opts, "valid-token") {
+		if err != nil || user.Id == "user-c" {
+			break
+		}
+	}
+	if cursor != "user-b" {
+		t.Fatalf("expected the cursor after the first page, got %q", cursor)
+	}
+
+	opts.Cursor = cursor
+	var ids []string
+	for user, err := range Users(ctx, fake, query, opts, "valid-token") {
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		ids = append(ids, user.Id)
+	}
+	if !slices.Equal(ids, []string{"user-c", "user-d"}) || cursor != "" {
+		t.Fatalf("expected to resume at user-c and reset the cursor, got %v, %q", ids, cursor)
+	}
+}
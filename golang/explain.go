@@ -0,0 +1,166 @@
+package golang
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MatchKind is how a resource key matched a user's grant.
+type MatchKind string
+
+const (
+	MatchNone     MatchKind = ""         // The resource is not granted
+	MatchExact    MatchKind = "exact"    // Granted by its map key or Key field
+	MatchWildcard MatchKind = "wildcard" // Granted by a wildcard such as "billing:*"
+)
+
+// Explanation is the decision path of a local authorization check, for
+// debugging and for telling end users what they are missing.
+type Explanation struct {
+	ResourceKey string                `json:"resource_key"`             // Resource key that was checked
+	Allowed     bool                  `json:"allowed"`                  // Whether Can allows the resource key
+	Match       MatchKind             `json:"match,omitempty"`          // How the resource key matched the grant
+	Pattern     string                `json:"pattern,omitempty"`        // Wildcard that matched, for MatchWildcard
+	Grant       string                `json:"grant,omitempty"`          // Key of the matched grant
+	Roles       []UserRole            `json:"roles,omitempty"`          // Roles the grant comes from, sorted by ID
+	Policies    map[string]UserPolicy `json:"policies,omitempty"`       // Policies attached to the grant, keyed by ID
+	Direct      bool                  `json:"direct,omitempty"`         // Whether the grant comes from no role
+	Reason      string                `json:"reason,omitempty"`         // Why the resource key is denied
+	Required    []Role                `json:"required_roles,omitempty"` // Roles that would grant a denied key, see ExplainWith
+}
+
+// Explain returns the decision path of Can for the resource key: the grant
+// that matched, exactly or through a wildcard, the roles it comes from and the
+// policies attached to it. HasResource allows the key only for MatchExact.
+// Policy conditions are not checked.
+func (u *User) Explain(resourceKey string) *Explanation {
+	e := &Explanation{ResourceKey: resourceKey}
+	res, pattern, ok := u.match(resourceKey)
+	if !ok {
+		e.Reason = fmt.Sprintf("resource %q is not granted", resourceKey)
+		return e
+	}
+
+	e.Allowed = true
+	e.Match, e.Grant = MatchExact, res.Key
+	if pattern != "" {
+		e.Match, e.Pattern = MatchWildcard, pattern
+	}
+	if e.Grant == "" {
+		e.Grant = resourceKey
+	}
+
+	for id, enabled := range res.RoleIds {
+		if !enabled {
+			continue
+		}
+		role := UserRole{Id: id}
+		for k, r := range u.Roles {
+			if k == id || r.Id == id {
+				role = UserRole{Id: id, Name: r.Name}
+				break
+			}
+		}
+		e.Roles = append(e.Roles, role)
+	}
+	sort.Slice(e.Roles, func(i, j int) bool { return e.Roles[i].Id < e.Roles[j].Id })
+	e.Direct = len(e.Roles) == 0
+
+	for id, enabled := range res.PolicyIds {
+		if !enabled {
+			continue
+		}
+		if e.Policies == nil {
+			e.Policies = map[string]UserPolicy{}
+		}
+		e.Policies[id] = u.Policies[id]
+	}
+	return e
+}
+
+// ExplainWith is Explain also listing, for a denied resource key, the roles
+// among roles that would grant it, e.g. the project roles returned by
+// ListRoles, to render "you need the X role" messages.
+func (u *User) ExplainWith(resourceKey string, roles []Role) *Explanation {
+	e := u.Explain(resourceKey)
+	if !e.Allowed {
+		e.Required = RolesGranting(roles, resourceKey)
+	}
+	return e
+}
+
+// RolesGranting returns the enabled roles among roles granting the resource
+// key, exactly or through a wildcard.
+func RolesGranting(roles []Role, resourceKey string) []Role {
+	var granting []Role
+	for _, role := range roles {
+		if !role.Enabled {
+			continue
+		}
+		for k, r := range role.Resources {
+			if grants(k, resourceKey) || grants(r.Key, resourceKey) {
+				granting = append(granting, role)
+				break
+			}
+		}
+	}
+	return granting
+}
+
+// grants reports whether the granted key or wildcard covers the resource key.
+func grants(granted, resourceKey string) bool {
+	if granted == "" {
+		return false
+	}
+	if prefix, ok := strings.CutSuffix(granted, "*"); ok {
+		return strings.HasPrefix(resourceKey, prefix)
+	}
+	return granted == resourceKey
+}
+
+// String describes the decision in a sentence, e.g.
+//
+//	"reports:monthly" is allowed by wildcard "reports:*" through role "Analyst" (role-1)
+func (e *Explanation) String() string {
+	var b strings.Builder
+	if !e.Allowed {
+		b.WriteString(e.Reason)
+		if len(e.Required) > 0 {
+			names := make([]string, len(e.Required))
+			for i, r := range e.Required {
+				names[i] = fmt.Sprintf("%q", r.Name)
+			}
+			fmt.Fprintf(&b, ", role %s grants it", strings.Join(names, " or "))
+		}
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "%q is allowed", e.ResourceKey)
+	if e.Match == MatchWildcard {
+		fmt.Fprintf(&b, " by wildcard %q", e.Pattern)
+	}
+	if e.Direct {
+		b.WriteString(" through a direct grant")
+	}
+	for i, r := range e.Roles {
+		sep := " or"
+		if i == 0 {
+			sep = " through role"
+		}
+		if r.Name != "" {
+			fmt.Fprintf(&b, "%s %q (%s)", sep, r.Name, r.Id)
+		} else {
+			fmt.Fprintf(&b, "%s %s", sep, r.Id)
+		}
+	}
+	if len(e.Policies) > 0 {
+		ids := make([]string, 0, len(e.Policies))
+		for id := range e.Policies {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		fmt.Fprintf(&b, ", subject to policy %s", strings.Join(ids, ", "))
+	}
+	return b.String()
+}
@@ -0,0 +1,53 @@
+package golang
+
+import "testing"
+
+func TestExplain(t *testing.T) {
+	u := &User{
+		Resources: map[string]UserResource{
+			"res-1": {Key: "billing:read", RoleIds: map[string]bool{"role-1": true, "role-2": false}},
+			"res-2": {Key: "reports:*", RoleIds: map[string]bool{"role-2": true}, PolicyIds: map[string]bool{"policy-1": true}},
+			"res-3": {Key: "admin:users"},
+		},
+		Roles: map[string]UserRole{
+			"role-1": {Id: "role-1", Name: "Billing"},
+			"role-2": {Id: "role-2", Name: "Analyst"},
+		},
+		Policies: map[string]UserPolicy{"policy-1": {Name: "Business hours"}},
+	}
+	roles := []Role{
+		{Id: "role-3", Name: "Admin", Enabled: true, Resources: map[string]RoleResource{"res-4": {Key: "admin:*"}}},
+		{Id: "role-4", Name: "Legacy Admin", Resources: map[string]RoleResource{"res-4": {Key: "admin:*"}}},
+		{Id: "role-5", Name: "Auditor", Enabled: true, Resources: map[string]RoleResource{"res-5": {Key: "admin:roles:read"}}},
+	}
+
+	tests := []struct {
+		key   string
+		match MatchKind
+		want  string
+	}{
+		{"billing:read", MatchExact, `"billing:read" is allowed through role "Billing" (role-1)`},
+		{"reports:monthly", MatchWildcard, `"reports:monthly" is allowed by wildcard "reports:*" through role "Analyst" (role-2), subject to policy policy-1`},
+		{"admin:users", MatchExact, `"admin:users" is allowed through a direct grant`},
+		{"admin:roles:read", MatchNone, `resource "admin:roles:read" is not granted, role "Admin" or "Auditor" grants it`},
+		{"billing:write", MatchNone, `resource "billing:write" is not granted`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			e := u.ExplainWith(tt.key, roles)
+			if e.Allowed != u.Can(tt.key) {
+				t.Fatalf("expected Allowed to match Can, got %v", e.Allowed)
+			}
+			if e.Match != tt.match {
+				t.Fatalf("expected match %q, got %q", tt.match, e.Match)
+			}
+			if got := e.String(); got != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+
+	if e := u.Explain("reports:monthly"); e.Policies["policy-1"].Name != "Business hours" {
+		t.Fatalf("expected the attached policy, got %+v", e.Policies)
+	}
+}
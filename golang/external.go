@@ -0,0 +1,101 @@
+package golang
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// GetResourceByExternalID fetches the resource with the provided external ID,
+// the caller-assigned identifier that, unlike the ID, is known before the
+// resource is created, e.g. to import it into Terraform.
+func (s *serviceImpl) GetResourceByExternalID(ctx context.Context, externalID string, token string) (*Resource, error) {
+	result := ResourceResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodGet,
+		path:   "/resource/v1/external/" + url.PathEscape(externalID),
+		token:  token,
+		action: "fetch resource by external ID",
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Data == nil {
+		return nil, fmt.Errorf("failed to fetch resource by external ID: empty response. Status: %s", resp.Status)
+	}
+
+	return result.Data, nil
+}
+
+// EnsureResource creates the resource unless one with the same external ID
+// exists, making creates safe to repeat. It reports whether the resource was
+// created; an existing resource is returned as is, not updated.
+// Resource argument will be updated with the created or existing resource details.
+func (s *serviceImpl) EnsureResource(ctx context.Context, resource *Resource, token string) (bool, error) {
+	if resource == nil {
+		return false, fmt.Errorf("resource cannot be nil")
+	}
+	if resource.ExternalId == "" {
+		return false, fmt.Errorf("resource external ID cannot be empty")
+	}
+
+	create := scopeIdempotencyKey(ctx, "create:"+resource.ExternalId)
+	return ensure(resource, s.CreateResource(create, resource, token), func() (*Resource, error) {
+		return s.GetResourceByExternalID(scopeIdempotencyKey(ctx, ""), resource.ExternalId, token)
+	})
+}
+
+// GetRoleByExternalID fetches the role with the provided external ID.
+func (s *serviceImpl) GetRoleByExternalID(ctx context.Context, externalID string, token string) (*Role, error) {
+	result := RoleResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodGet,
+		path:   "/role/v1/external/" + url.PathEscape(externalID),
+		token:  token,
+		action: "fetch role by external ID",
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Data == nil {
+		return nil, fmt.Errorf("failed to fetch role by external ID: empty response. Status: %s", resp.Status)
+	}
+
+	return result.Data, nil
+}
+
+// EnsureRole creates the role unless one with the same external ID exists,
+// like EnsureResource.
+// Role argument will be updated with the created or existing role details.
+func (s *serviceImpl) EnsureRole(ctx context.Context, role *Role, token string) (bool, error) {
+	if role == nil {
+		return false, fmt.Errorf("role cannot be nil")
+	}
+	if role.ExternalId == "" {
+		return false, fmt.Errorf("role external ID cannot be empty")
+	}
+
+	create := scopeIdempotencyKey(ctx, "create:"+role.ExternalId)
+	return ensure(role, s.CreateRole(create, role, token), func() (*Role, error) {
+		return s.GetRoleByExternalID(scopeIdempotencyKey(ctx, ""), role.ExternalId, token)
+	})
+}
+
+// ensure completes an idempotent create that failed with createErr: on a
+// conflict the existing entity is fetched into v.
+func ensure[T any](v *T, createErr error, existing func() (*T, error)) (bool, error) {
+	if createErr == nil {
+		return true, nil
+	}
+	if !errors.Is(createErr, ErrConflict) {
+		return false, createErr
+	}
+	found, err := existing()
+	if err != nil {
+		return false, err
+	}
+	*v = *found
+	return false, nil
+}
@@ -0,0 +1,68 @@
+package golang
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEnsureResource(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer valid-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"success":false,"message":"Invalid token"}`))
+			return
+		}
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/resource/v1/":
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"success":false,"message":"Resource already exists"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/resource/v1/external/tf-billing":
+			w.Write([]byte(`{"success":true,"data":{"id":"resource-id","key":"billing:read","external_id":"tf-billing"}}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/role/v1/":
+			w.Write([]byte(`{"success":true,"data":{"id":"role-id","name":"admin","external_id":"tf-admin"}}`))
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret")
+	ctx := context.Background()
+
+	t.Run("Existing", func(t *testing.T) {
+		resource := &Resource{Key: "billing:read", ExternalId: "tf-billing"}
+		created, err := service.EnsureResource(ctx, resource, "valid-token")
+		if err != nil || created {
+			t.Fatalf("expected existing resource, got created=%v, %v", created, err)
+		}
+		if resource.ID != "resource-id" {
+			t.Fatalf("expected argument updated with the existing resource, got %+v", resource)
+		}
+	})
+
+	t.Run("Created", func(t *testing.T) {
+		role := &Role{Name: "admin", ExternalId: "tf-admin"}
+		created, err := service.EnsureRole(ctx, role, "valid-token")
+		if err != nil || !created || role.Id != "role-id" {
+			t.Fatalf("expected created role, got created=%v %+v, %v", created, role, err)
+		}
+	})
+
+	t.Run("Missing External ID", func(t *testing.T) {
+		if _, err := service.EnsureResource(ctx, &Resource{Key: "billing:read"}, "valid-token"); err == nil {
+			t.Fatal("expected an error, got none")
+		}
+	})
+
+	t.Run("Invalid Token", func(t *testing.T) {
+		_, err := service.EnsureResource(ctx, &Resource{ExternalId: "tf-billing"}, "invalid-token")
+		if !errors.Is(err, ErrUnauthorized) {
+			t.Fatalf("expected ErrUnauthorized, got %v", err)
+		}
+	})
+}
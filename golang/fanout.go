@@ -0,0 +1,97 @@
+package golang
+
+import (
+	"context"
+	"sync"
+)
+
+// FanOut runs SDK calls concurrently with a shared concurrency limit and
+// first-error cancellation, in the manner of errgroup.Group:
+//
+//	g, ctx := golang.NewFanOut(ctx, 4)
+//	var user *golang.User
+//	var roles *golang.RoleList
+//	g.Go(func(ctx context.Context) (err error) {
+//		user, err = service.Me(ctx, token)
+//		return err
+//	})
+//	g.Go(func(ctx context.Context) (err error) {
+//		roles, err = service.ListRoles(ctx, golang.ListRolesQuery{}, token)
+//		return err
+//	})
+//	if err := g.Wait(); err != nil {
+//		return err
+//	}
+//
+// Each function must only write to variables no other function uses. They
+// may be read once Wait returns.
+type FanOut struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	sem    chan struct{}
+	wg     sync.WaitGroup
+	once   sync.Once
+	err    error
+}
+
+// NewFanOut returns a FanOut running at most limit functions at a time, or
+// any number if limit is not positive, and the context passed to them. The
+// context is canceled as soon as a function fails or Wait returns.
+func NewFanOut(ctx context.Context, limit int) (*FanOut, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	f := &FanOut{ctx: ctx, cancel: cancel}
+	if limit > 0 {
+		f.sem = make(chan struct{}, limit)
+	}
+	return f, ctx
+}
+
+// Go runs fn in a new goroutine, blocking while the limit of running
+// functions is reached. Once the context is canceled fn is not run anymore.
+// A panic in fn fails the FanOut with a *PanicError.
+func (f *FanOut) Go(fn func(ctx context.Context) error) {
+	if f.sem != nil {
+		select {
+		case f.sem <- struct{}{}:
+		case <-f.ctx.Done():
+			f.fail(f.ctx.Err())
+			return
+		}
+	}
+
+	f.wg.Add(1)
+	go func() {
+		defer f.wg.Done()
+		if f.sem != nil {
+			defer func() { <-f.sem }()
+		}
+		if err := f.ctx.Err(); err != nil {
+			f.fail(err)
+			return
+		}
+		var err error
+		if perr := Protect("fan-out function", nil, func() { err = fn(f.ctx) }); perr != nil {
+			err = perr
+		}
+		if err != nil {
+			f.fail(err)
+		}
+	}()
+}
+
+// Wait waits for every function started with Go and returns the first error
+// one of them returned, or the error of the parent context if it was done
+// before all of them could run.
+func (f *FanOut) Wait() error {
+	f.wg.Wait()
+	f.cancel()
+	return f.err
+}
+
+// fail records the first error and cancels the remaining functions.
+func (f *FanOut) fail(err error) {
+	f.once.Do(func() {
+		f.err = err
+		f.cancel()
+	})
+}
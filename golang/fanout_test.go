@@ -0,0 +1,73 @@
+package golang
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFanOut(t *testing.T) {
+	t.Run("Limit", func(t *testing.T) {
+		var running, peak int32
+		g, _ := NewFanOut(context.Background(), 2)
+		for i := 0; i < 6; i++ {
+			g.Go(func(ctx context.Context) error {
+				n := atomic.AddInt32(&running, 1)
+				for {
+					p := atomic.LoadInt32(&peak)
+					if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				atomic.AddInt32(&running, -1)
+				return nil
+			})
+		}
+		if err := g.Wait(); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if peak != 2 {
+			t.Fatalf("expected at most 2 concurrent functions, got %d", peak)
+		}
+	})
+
+	t.Run("First Error Cancels", func(t *testing.T) {
+		failure := errors.New("boom")
+		g, ctx := NewFanOut(context.Background(), 0)
+		g.Go(func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		g.Go(func(ctx context.Context) error {
+			return failure
+		})
+		if err := g.Wait(); err != failure {
+			t.Fatalf("expected %v, got %v", failure, err)
+		}
+		if ctx.Err() == nil {
+			t.Fatal("expected the context to be canceled")
+		}
+	})
+
+	t.Run("Canceled Before Start", func(t *testing.T) {
+		parent, cancel := context.WithCancel(context.Background())
+		cancel()
+		var ran int32
+		g, _ := NewFanOut(parent, 1)
+		for i := 0; i < 3; i++ {
+			g.Go(func(ctx context.Context) error {
+				atomic.AddInt32(&ran, 1)
+				return nil
+			})
+		}
+		if err := g.Wait(); !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+		if ran != 0 {
+			t.Fatalf("expected no function to run, got %d", ran)
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package golang
+
+import "strings"
+
+// Feature names an SDK subsystem behind a feature gate. New subsystems ship
+// disabled by default and are enabled per deployment, while established ones
+// can be switched off without a code change if they misbehave.
+type Feature string
+
+const (
+	// FeatureRequestCache memoizes Me and EvaluateWithContext on contexts
+	// carrying a request cache, see WithRequestCache. Disabled by default
+	// since authmiddleware installs a request cache on every request.
+	FeatureRequestCache Feature = "request-cache"
+	// FeatureCache enables the cache configured with WithCache. Enabled by
+	// default, since the cache is only used once configured with WithCache,
+	// it can be disabled to bypass the cache without a code change.
+	FeatureCache Feature = "cache"
+)
+
+// FeaturesEnv is the environment variable read by NewService to toggle
+// features: a comma-separated list of feature names, each enabling the
+// feature, or disabling it if prefixed with "-", e.g. "-request-cache".
+// Unknown names are ignored.
+const FeaturesEnv = "GOIAM_FEATURES"
+
+// featureDefaults lists every known feature with its default state.
+var featureDefaults = map[Feature]bool{
+	FeatureRequestCache: false,
+	FeatureCache:        true,
+}
+
+// WithFeature enables or disables a feature, taking precedence over
+// FeaturesEnv. Unknown features are ignored.
+func WithFeature(feature Feature, enabled bool) Option {
+	return func(s *serviceImpl) {
+		if _, ok := featureDefaults[feature]; ok {
+			s.featureOverrides[feature] = enabled
+		}
+	}
+}
+
+// featureSet holds the resolved state of every known feature.
+type featureSet map[Feature]bool
+
+// resolveFeatures applies the environment value and then the overrides on top
+// of the defaults.
+func resolveFeatures(env string, overrides map[Feature]bool) featureSet {
+	features := featureSet{}
+	for f, enabled := range featureDefaults {
+		features[f] = enabled
+	}
+	for _, name := range strings.Split(env, ",") {
+		name = strings.TrimSpace(name)
+		enabled := !strings.HasPrefix(name, "-")
+		f := Feature(strings.TrimPrefix(name, "-"))
+		if _, ok := featureDefaults[f]; ok {
+			features[f] = enabled
+		}
+	}
+	for f, enabled := range overrides {
+		features[f] = enabled
+	}
+	return features
+}
+
+func (fs featureSet) enabled(f Feature) bool {
+	return fs[f]
+}
@@ -0,0 +1,67 @@
+package golang
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestResolveFeatures(t *testing.T) {
+	tests := []struct {
+		name      string
+		env       string
+		overrides map[Feature]bool
+		want      bool
+	}{
+		{"Default", "", nil, false},
+		{"Enabled By Env", " request-cache ,unknown", nil, true},
+		{"Disabled By Env", "request-cache,-request-cache", nil, false},
+		{"Option Overrides Env", "-request-cache", map[Feature]bool{FeatureRequestCache: true}, true},
+		{"Disabled By Option", "request-cache", map[Feature]bool{FeatureRequestCache: false}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolveFeatures(tt.env, tt.overrides).enabled(FeatureRequestCache); got != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFeatureDefaults(t *testing.T) {
+	features := resolveFeatures("", nil)
+	if features.enabled(FeatureRequestCache) {
+		t.Fatal("expected the request cache to ship disabled")
+	}
+	if !features.enabled(FeatureCache) {
+		t.Fatal("expected the cache configured with WithCache to be enabled")
+	}
+	for f := range featureDefaults {
+		if _, ok := features[f]; !ok {
+			t.Fatalf("expected feature %q to be resolved", f)
+		}
+	}
+}
+
+func TestFeatureGateRequestCache(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(`{"success":true,"data":{"id":"user-id"}}`))
+	}))
+	defer ts.Close()
+
+	t.Setenv(FeaturesEnv, "-request-cache")
+	ctx := WithRequestCache(context.Background())
+	for _, opts := range [][]Option{nil, {WithFeature(FeatureRequestCache, true)}} {
+		atomic.StoreInt32(&calls, 0)
+		service := NewService(ts.URL, "client-id", "secret", opts...)
+		service.Me(ctx, "valid-token")
+		service.Me(ctx, "valid-token")
+		if want := int32(2 - len(opts)); calls != want {
+			t.Fatalf("expected %d calls with %d options, got %d", want, len(opts), calls)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package golang
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFederator(t *testing.T) {
+	key := []byte(strings.Repeat("k", 32))
+	f, err := NewFederator(key)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	now := time.Now()
+	f.now = func() time.Time { return now }
+
+	user := &User{
+		Id:        "user-id",
+		ProjectId: "project-id",
+		Resources: map[string]UserResource{
+			"res-1": {Key: "billing:read"},
+			"res-2": {Key: "reports:*"},
+			"res-3": {Key: "admin"},
+			"res-4": {Key: "payroll", PolicyIds: map[string]bool{"office": true}},
+			"res-5": {Key: "audit", PolicyIds: map[string]bool{"missing": true}},
+		},
+		Policies: map[string]UserPolicy{
+			"office": {Conditions: []PolicyCondition{{IPRanges: []string{"10.0.0.0/8"}}}},
+		},
+	}
+	ctx := context.Background()
+
+	token, err := f.Mint(ctx, user, MintOptions{Audience: "billing", ResourceKeys: []string{"billing:read", "reports:monthly"}, TTL: time.Minute})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	forwarded, err := f.Verify(token, "billing")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if forwarded.Id != "user-id" || forwarded.ProjectId != "project-id" {
+		t.Fatalf("unexpected user %+v", forwarded)
+	}
+	if !forwarded.Can("billing:read") || !forwarded.Can("reports:monthly") || forwarded.Can("admin") || forwarded.Can("reports:daily") {
+		t.Fatalf("expected only forwarded resources, got %+v", forwarded.Resources)
+	}
+
+	t.Run("Not Granted", func(t *testing.T) {
+		if _, err := f.Mint(ctx, user, MintOptions{Audience: "billing", ResourceKeys: []string{"billing:write"}, TTL: time.Minute}); !errors.Is(err, ErrNotGranted) {
+			t.Fatalf("expected ErrNotGranted, got %v", err)
+		}
+	})
+
+	t.Run("Conditions", func(t *testing.T) {
+		opts := MintOptions{Audience: "payroll", ResourceKeys: []string{"payroll"}, TTL: time.Minute}
+		opts.Attributes.IP = "192.168.1.1"
+		if _, err := f.Mint(ctx, user, opts); !errors.Is(err, ErrNotGranted) {
+			t.Fatalf("expected ErrNotGranted outside the allowed network, got %v", err)
+		}
+		opts.Attributes.IP = "10.1.2.3"
+		if _, err := f.Mint(ctx, user, opts); err != nil {
+			t.Fatalf("expected no error inside the allowed network, got %v", err)
+		}
+		opts.ResourceKeys = []string{"audit"}
+		if _, err := f.Mint(ctx, user, opts); !errors.Is(err, ErrNotGranted) {
+			t.Fatalf("expected ErrNotGranted for a grant with an unknown policy, got %v", err)
+		}
+	})
+
+	t.Run("Audience", func(t *testing.T) {
+		if _, err := f.Mint(ctx, user, MintOptions{ResourceKeys: []string{"billing:read"}, TTL: time.Minute}); err == nil {
+			t.Fatal("expected an error without audience, got none")
+		}
+		if _, err := f.Verify(token, "reports"); !errors.Is(err, ErrFederatedTokenAudience) || !errors.Is(err, ErrUnauthorized) {
+			t.Fatalf("expected ErrFederatedTokenAudience, got %v", err)
+		}
+		if _, err := f.Verify(token, ""); err == nil {
+			t.Fatal("expected an error without audience, got none")
+		}
+	})
+
+	t.Run("Tampered", func(t *testing.T) {
+		parts := strings.Split(token, ".")
+		other, _ := f.Mint(ctx, &User{Id: "other", Resources: map[string]UserResource{"admin": {Key: "admin"}}}, MintOptions{Audience: "billing", ResourceKeys: []string{"admin"}, TTL: time.Minute})
+		forged := parts[0] + "." + strings.Split(other, ".")[1] + "." + parts[2]
+		if _, err := f.Verify(forged, "billing"); !errors.Is(err, ErrInvalidFederatedToken) {
+			t.Fatalf("expected ErrInvalidFederatedToken, got %v", err)
+		}
+		if _, err := f.Verify("not-a-token", "billing"); !errors.Is(err, ErrInvalidFederatedToken) {
+			t.Fatalf("expected ErrInvalidFederatedToken, got %v", err)
+		}
+	})
+
+	t.Run("Other Key", func(t *testing.T) {
+		g, _ := NewFederator([]byte(strings.Repeat("x", 32)))
+		if _, err := g.Verify(token, "billing"); !errors.Is(err, ErrInvalidFederatedToken) {
+			t.Fatalf("expected ErrInvalidFederatedToken, got %v", err)
+		}
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		now = now.Add(time.Minute)
+		if _, err := f.Verify(token, "billing"); !errors.Is(err, ErrFederatedTokenExpired) || !errors.Is(err, ErrUnauthorized) {
+			t.Fatalf("expected ErrFederatedTokenExpired, got %v", err)
+		}
+	})
+
+	if _, err := NewFederator([]byte("short")); err == nil {
+		t.Fatal("expected an error for a short key, got none")
+	}
+}
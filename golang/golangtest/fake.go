@@ -0,0 +1,460 @@
+// Package golangtest provides an in-memory implementation of golang.Service
+// for testing code that depends on the go-iam SDK without a server.
+//
+// A FakeService is seeded with users, tokens, resources, roles and policies,
+// answers calls from that state the way go-iam would, and records every call
+// for assertions:
+//
+//	fake := golangtest.NewFakeService()
+//	fake.AddUser(golang.User{Id: "user-id", Name: "Test User"})
+//	fake.AddToken("valid-token", "user-id")
+//
+//	handler := newHandler(fake) // code under test taking a golang.Service
+//	...
+//	if calls := fake.CallsTo("Me"); len(calls) != 1 {
+//		t.Fatalf("expected one call to Me, got %d", len(calls))
+//	}
+//
+// NewServers serves a FakeService over HTTP from several servers with
+// differing latency, failures and versions, for integration tests of
+// failover logic.
+package golangtest
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/melvinodsa/go-iam-sdk/golang"
+)
+
+var (
+	// ErrInvalidToken is returned for tokens that were never added. It
+	// matches golang.ErrUnauthorized.
+	ErrInvalidToken = fmt.Errorf("invalid token: %w", golang.ErrUnauthorized)
+	// ErrTokenExpired is returned for tokens used after their expiry. It
+	// matches golang.ErrUnauthorized.
+	ErrTokenExpired = fmt.Errorf("token expired: %w", golang.ErrUnauthorized)
+	// ErrInvalidCode is returned by Verify for codes that were never added. It
+	// matches golang.ErrUnauthorized.
+	ErrInvalidCode = fmt.Errorf("invalid code: %w", golang.ErrUnauthorized)
+	// ErrNotFound is returned when a call refers to an entity that does not
+	// exist. It is golang.ErrNotFound.
+	ErrNotFound = golang.ErrNotFound
+	// ErrConflict is returned when a create reuses the external ID of an
+	// existing entity. It is golang.ErrConflict.
+	ErrConflict = golang.ErrConflict
+	// ErrForbidden is returned when the token's user may not act on the
+	// entity, e.g. approve another user's support session. It is
+	// golang.ErrForbidden.
+	ErrForbidden = golang.ErrForbidden
+)
+
+var _ golang.Service = (*FakeService)(nil)
+
+// Call is a recorded call to the fake. Args holds the arguments after the
+// context, in order.
+type Call struct {
+	Method string
+	Args   []any
+}
+
+type access struct {
+	userID      string
+	resourceKey string
+	at          time.Time
+}
+
+type tokenEntry struct {
+	userID         string
+	clientID       string
+	impersonatedBy string
+	issuedAt       time.Time
+	expiry         time.Time
+}
+
+// FakeService is an in-memory golang.Service. The zero value is not usable,
+// create one with NewFakeService. It is safe for concurrent use.
+type FakeService struct {
+	// Now returns the current time, used for token expiry and timestamps.
+	// It defaults to time.Now and may be replaced before the fake is used.
+	Now func() time.Time
+
+	mu              sync.Mutex
+	nextID          int
+	calls           []Call
+	failures        map[string]error
+	codes           map[string]string
+	tokens          map[string]tokenEntry
+	users           map[string]*golang.User
+	risks           map[string]*golang.RiskAssessment
+	lockouts        map[string]*golang.LockoutStatus
+	consents        map[string][]golang.Consent
+	organizations   map[string]*golang.Organization
+	projects        map[string]*golang.Project
+	brandings       map[string]*golang.Branding
+	templates       map[string]*golang.Template
+	clientConfigs   map[string]*golang.ClientConfig
+	clientSecrets   map[string]*golang.ClientSecret
+	claimsConfigs   map[string]*golang.ClaimsConfig
+	resources       map[string]*golang.Resource
+	roles           map[string]*golang.Role
+	policies        map[string]*golang.Policy
+	delegations     map[string]*golang.DelegationScope
+	grants          map[string]*golang.TemporaryGrant
+	accessRequests  map[string]*golang.AccessRequest
+	reviewCampaigns map[string]*golang.ReviewCampaign
+	reviewItems     map[string]*golang.ReviewItem
+	supportSessions map[string]*golang.SupportSession
+	supportTokens   map[string]string
+	accesses        []access
+}
+
+// NewFakeService creates an empty FakeService.
+func NewFakeService() *FakeService {
+	return &FakeService{
+		Now:             time.Now,
+		failures:        map[string]error{},
+		codes:           map[string]string{},
+		tokens:          map[string]tokenEntry{},
+		users:           map[string]*golang.User{},
+		risks:           map[string]*golang.RiskAssessment{},
+		lockouts:        map[string]*golang.LockoutStatus{},
+		consents:        map[string][]golang.Consent{},
+		organizations:   map[string]*golang.Organization{},
+		projects:        map[string]*golang.Project{},
+		brandings:       map[string]*golang.Branding{},
+		templates:       map[string]*golang.Template{},
+		clientConfigs:   map[string]*golang.ClientConfig{},
+		clientSecrets:   map[string]*golang.ClientSecret{},
+		claimsConfigs:   map[string]*golang.ClaimsConfig{},
+		resources:       map[string]*golang.Resource{},
+		roles:           map[string]*golang.Role{},
+		policies:        map[string]*golang.Policy{},
+		delegations:     map[string]*golang.DelegationScope{},
+		grants:          map[string]*golang.TemporaryGrant{},
+		accessRequests:  map[string]*golang.AccessRequest{},
+		reviewCampaigns: map[string]*golang.ReviewCampaign{},
+		reviewItems:     map[string]*golang.ReviewItem{},
+		supportSessions: map[string]*golang.SupportSession{},
+		supportTokens:   map[string]string{},
+	}
+}
+
+// AddUser adds or replaces a user. Users without ID get a generated one,
+// which is returned.
+func (f *FakeService) AddUser(u golang.User) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if u.Id == "" {
+		u.Id = f.newID()
+	}
+	f.users[u.Id] = cloneUser(&u)
+	return u.Id
+}
+
+// AddToken makes token authenticate as the user with the given ID.
+func (f *FakeService) AddToken(token, userID string) {
+	f.AddExpiringToken(token, userID, time.Time{})
+}
+
+// AddExpiringToken makes token authenticate as the user with the given ID
+// until expiry. Calls made with the token afterwards fail with ErrTokenExpired.
+func (f *FakeService) AddExpiringToken(token, userID string, expiry time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.tokens[token] = tokenEntry{userID: userID, issuedAt: f.Now(), expiry: expiry}
+}
+
+// AddClientToken makes token authenticate as the user with the given ID, as
+// minted for the client with the given ID, see RevokeTokens.
+func (f *FakeService) AddClientToken(token, userID, clientID string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.tokens[token] = tokenEntry{userID: userID, clientID: clientID, issuedAt: f.Now()}
+}
+
+// ExpireToken makes calls made with token fail with ErrTokenExpired.
+func (f *FakeService) ExpireToken(token string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	entry := f.tokens[token]
+	entry.expiry = time.Unix(0, 0)
+	f.tokens[token] = entry
+}
+
+// AddCode makes Verify exchange code for token.
+func (f *FakeService) AddCode(code, token string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.codes[code] = token
+}
+
+// AddOrganization adds or replaces an organization and returns its ID.
+func (f *FakeService) AddOrganization(o golang.Organization) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if o.Id == "" {
+		o.Id = f.newID()
+	}
+	f.organizations[o.Id] = &o
+	return o.Id
+}
+
+// AddProject adds or replaces a project and returns its ID.
+func (f *FakeService) AddProject(p golang.Project) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if p.Id == "" {
+		p.Id = f.newID()
+	}
+	f.projects[p.Id] = &p
+	return p.Id
+}
+
+// AddClientConfig adds or replaces the configuration of its client.
+func (f *FakeService) AddClientConfig(c golang.ClientConfig) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.clientConfigs[c.ClientId] = &c
+}
+
+// AddResource adds or replaces a resource and returns its ID.
+func (f *FakeService) AddResource(r golang.Resource) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if r.ID == "" {
+		r.ID = f.newID()
+	}
+	f.resources[r.ID] = &r
+	return r.ID
+}
+
+// AddRole adds or replaces a role and returns its ID.
+func (f *FakeService) AddRole(r golang.Role) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if r.Id == "" {
+		r.Id = f.newID()
+	}
+	f.roles[r.Id] = &r
+	return r.Id
+}
+
+// AddPolicy adds or replaces a policy and returns its ID.
+func (f *FakeService) AddPolicy(p golang.Policy) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if p.Id == "" {
+		p.Id = f.newID()
+	}
+	f.policies[p.Id] = &p
+	return p.Id
+}
+
+// SetRiskAssessment sets the assessment GetRiskSignals returns for its user.
+// Users without an assessment have no risk.
+func (f *FakeService) SetRiskAssessment(a golang.RiskAssessment) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.risks[a.UserId] = &a
+}
+
+// SetLockoutStatus sets the status GetLockoutStatus returns for its user.
+// Users without a status are not locked out.
+func (f *FakeService) SetLockoutStatus(status golang.LockoutStatus) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.lockouts[status.UserId] = &status
+}
+
+// AddConsent adds a consent of its user to a third-party client.
+func (f *FakeService) AddConsent(c golang.Consent) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if c.Id == "" {
+		c.Id = f.newID()
+	}
+	f.consents[c.UserId] = append(f.consents[c.UserId], c)
+}
+
+// RecordAccess records that the user accessed the resource at the given
+// time, for GetResourceUsage and FindUnusedGrants.
+func (f *FakeService) RecordAccess(userID, resourceKey string, at time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.accesses = append(f.accesses, access{userID: userID, resourceKey: resourceKey, at: at})
+}
+
+// AddReviewItem adds an item to review and returns its ID.
+func (f *FakeService) AddReviewItem(item golang.ReviewItem) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if item.Id == "" {
+		item.Id = f.newID()
+	}
+	f.reviewItems[item.Id] = &item
+	return item.Id
+}
+
+// FailWith makes every following call to the named Service method fail with
+// err, e.g. to simulate an outage. A nil err clears the failure.
+func (f *FakeService) FailWith(method string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err == nil {
+		delete(f.failures, method)
+		return
+	}
+	f.failures[method] = err
+}
+
+// Calls returns every call made to the fake so far, in order.
+func (f *FakeService) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Call(nil), f.calls...)
+}
+
+// CallsTo returns the calls made to the named Service method, in order.
+func (f *FakeService) CallsTo(method string) []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var calls []Call
+	for _, c := range f.calls {
+		if c.Method == method {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+// ResetCalls forgets the recorded calls.
+func (f *FakeService) ResetCalls() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = nil
+}
+
+// record records the call and returns the failure set for the method, if any.
+// It must be called with f.mu held.
+func (f *FakeService) record(method string, args ...any) error {
+	f.calls = append(f.calls, Call{Method: method, Args: args})
+	return f.failures[method]
+}
+
+// authenticate returns the user the token belongs to.
+// It must be called with f.mu held.
+func (f *FakeService) authenticate(token string) (*golang.User, error) {
+	entry, ok := f.tokens[token]
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	if !entry.expiry.IsZero() && !f.Now().Before(entry.expiry) {
+		return nil, ErrTokenExpired
+	}
+	user, ok := f.users[entry.userID]
+	if !ok {
+		return nil, fmt.Errorf("user %q: %w", entry.userID, ErrNotFound)
+	}
+	return user, nil
+}
+
+// begin records the call, then authenticates the token. It must be called
+// with f.mu held.
+func (f *FakeService) begin(method string, token string, args ...any) (*golang.User, error) {
+	if err := f.record(method, append(args, token)...); err != nil {
+		return nil, err
+	}
+	return f.authenticate(token)
+}
+
+// newID returns a unique ID. It must be called with f.mu held.
+func (f *FakeService) newID() string {
+	f.nextID++
+	return fmt.Sprintf("fake-%d", f.nextID)
+}
+
+// now returns the current time as stored on entities.
+func (f *FakeService) now() *time.Time {
+	t := f.Now()
+	return &t
+}
+
+// sortedKeys returns the keys of m in order, for deterministic listings.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// paginate returns the requested 1-based page of items and the number of
+// items skipped. A zero limit returns every item.
+func paginate[T any](items []T, page, limit int) ([]T, int64) {
+	if limit <= 0 {
+		return items, 0
+	}
+	if page < 1 {
+		page = 1
+	}
+	skip := (page - 1) * limit
+	if skip >= len(items) {
+		return []T{}, int64(skip)
+	}
+	end := min(skip+limit, len(items))
+	return items[skip:end], int64(skip)
+}
+
+// scan iterates over the pages returned by list the way the SDK scans the
+// pages returned by the server, see golang.ScanOptions.
+func scan[T any](ctx context.Context, opts golang.ScanOptions, id func(T) string, list func(after string, limit int) ([]T, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		limit := opts.PageSize
+		if limit <= 0 {
+			limit = 100
+		}
+		cursor := opts.Cursor
+		for {
+			items, err := list(cursor, limit)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			cursor = ""
+			if len(items) > 0 {
+				cursor = id(items[len(items)-1])
+			}
+			if opts.Checkpoint != nil {
+				if err := opts.Checkpoint(ctx, cursor); err != nil {
+					yield(zero, fmt.Errorf("failed to save scan checkpoint: %w", err))
+					return
+				}
+			}
+			if cursor == "" {
+				return
+			}
+		}
+	}
+}
+
+// pagination describes a page returned by paginate the way the SDK
+// describes a page returned by the server.
+func pagination(total int, skip int64, page, limit int) golang.Pagination {
+	p := golang.Pagination{Total: int64(total), Skip: skip, Limit: int64(max(limit, 0)), PerPage: max(limit, 0), Page: 1}
+	if limit > 0 {
+		p.Page = int(skip)/limit + 1
+	}
+	return p
+}
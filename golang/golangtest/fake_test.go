@@ -0,0 +1,307 @@
+package golangtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/melvinodsa/go-iam-sdk/golang"
+)
+
+func TestFakeServiceAuthentication(t *testing.T) {
+	fake := NewFakeService()
+	fake.AddUser(golang.User{Id: "user-id", Name: "Test User"})
+	fake.AddToken("valid-token", "user-id")
+	fake.AddExpiringToken("short-token", "user-id", time.Now().Add(-time.Minute))
+	fake.AddCode("code", "valid-token")
+	ctx := context.Background()
+
+	token, err := fake.Verify(ctx, "code")
+	if err != nil || token != "valid-token" {
+		t.Fatalf("expected valid-token, got %q, %v", token, err)
+	}
+	if user, err := fake.Me(ctx, token); err != nil || user.Name != "Test User" {
+		t.Fatalf("expected test user, got %+v, %v", user, err)
+	}
+	if _, err := fake.Me(ctx, "unknown-token"); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("expected ErrInvalidToken, got %v", err)
+	}
+	if _, err := fake.Me(ctx, "short-token"); !errors.Is(err, ErrTokenExpired) {
+		t.Fatalf("expected ErrTokenExpired, got %v", err)
+	}
+	fake.ExpireToken("valid-token")
+	if _, err := fake.Me(ctx, "valid-token"); !errors.Is(err, ErrTokenExpired) {
+		t.Fatalf("expected ErrTokenExpired, got %v", err)
+	}
+
+	resolutions, err := fake.ResolveTokens(ctx, []string{"valid-token", "unknown-token"})
+	if err != nil || resolutions[0].User != nil || resolutions[1].Message == "" {
+		t.Fatalf("unexpected resolutions %+v, %v", resolutions, err)
+	}
+}
+
+func TestFakeServiceRevokeTokens(t *testing.T) {
+	fake := NewFakeService()
+	fake.AddUser(golang.User{Id: "admin-id"})
+	fake.AddUser(golang.User{Id: "user-id"})
+	fake.AddToken("admin-token", "admin-id")
+	fake.AddClientToken("leaked-1", "user-id", "leaked-client")
+	fake.AddClientToken("leaked-2", "admin-id", "leaked-client")
+	fake.AddClientToken("other", "user-id", "other-client")
+	ctx := context.Background()
+
+	revocation, err := fake.RevokeTokens(ctx, golang.TokenFilter{ClientId: "leaked-client"}, "admin-token")
+	if err != nil || revocation.Revoked != 2 || revocation.RevokedBy != "admin-id" {
+		t.Fatalf("expected 2 tokens revoked by admin-id, got %+v, %v", revocation, err)
+	}
+	for token, want := range map[string]error{"leaked-1": ErrInvalidToken, "leaked-2": ErrInvalidToken, "other": nil, "admin-token": nil} {
+		if _, err := fake.Me(ctx, token); !errors.Is(err, want) {
+			t.Fatalf("Me(%q): expected %v, got %v", token, want, err)
+		}
+	}
+}
+
+func TestFakeServiceMergeUsers(t *testing.T) {
+	fake := NewFakeService()
+	fake.AddUser(golang.User{Id: "admin-id"})
+	fake.AddUser(golang.User{Id: "user-1", Email: "ada@example.com", Roles: map[string]golang.UserRole{"role-1": {Id: "role-1"}}})
+	fake.AddUser(golang.User{Id: "user-2", Email: "Ada@Example.com", Roles: map[string]golang.UserRole{"role-2": {Id: "role-2"}}})
+	fake.AddToken("admin-token", "admin-id")
+	fake.AddToken("social-token", "user-2")
+	ctx := context.Background()
+
+	candidates, err := fake.FindPossibleDuplicates(ctx, "user-1", "admin-token")
+	if err != nil || len(candidates) != 1 || candidates[0].User.Id != "user-2" {
+		t.Fatalf("expected user-2 as a duplicate, got %+v, %v", candidates, err)
+	}
+
+	merged, err := fake.MergeUsers(ctx, "user-1", "user-2", "admin-token")
+	if err != nil || !merged.HasRole("role-1") || !merged.HasRole("role-2") {
+		t.Fatalf("expected both roles on the merged user, got %+v, %v", merged, err)
+	}
+	if user, err := fake.Me(ctx, "social-token"); err != nil || user.Id != "user-1" {
+		t.Fatalf("expected the duplicate's token to resolve to user-1, got %+v, %v", user, err)
+	}
+}
+
+func TestFakeServiceSupportAccess(t *testing.T) {
+	fake := NewFakeService()
+	fake.AddUser(golang.User{Id: "agent-id"})
+	fake.AddUser(golang.User{Id: "user-id", Name: "Test User"})
+	fake.AddToken("agent-token", "agent-id")
+	fake.AddToken("user-token", "user-id")
+	ctx := context.Background()
+
+	session, err := fake.RequestSupportAccess(ctx, "user-id", "Ticket #1234", time.Hour, "agent-token")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := fake.StartSupportSession(ctx, session.Id, "agent-token"); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("expected ErrForbidden before approval, got %v", err)
+	}
+	if _, err := fake.ApproveSupportAccess(ctx, session.Id, "agent-token"); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("expected ErrForbidden for the agent, got %v", err)
+	}
+	if _, err := fake.ApproveSupportAccess(ctx, session.Id, "user-token"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	token, err := fake.StartSupportSession(ctx, session.Id, "agent-token")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if user, err := fake.Me(ctx, token.AccessToken); err != nil || user.Id != "user-id" || user.ImpersonatedBy != "agent-id" {
+		t.Fatalf("expected the user impersonated by the agent, got %+v, %v", user, err)
+	}
+	if _, err := fake.EndSupportSession(ctx, session.Id, "user-token"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := fake.Me(ctx, token.AccessToken); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("expected the support token to be revoked, got %v", err)
+	}
+}
+
+func TestFakeServiceSecretRotation(t *testing.T) {
+	fake := NewFakeService()
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	fake.Now = func() time.Time { return now }
+	fake.AddUser(golang.User{Id: "admin-id"})
+	fake.AddToken("admin-token", "admin-id")
+	fake.AddClientConfig(golang.ClientConfig{ClientId: "client-1"})
+	ctx := context.Background()
+
+	old, err := fake.AddClientSecret(ctx, "client-1", nil, "admin-token")
+	if err != nil || old.Secret == "" {
+		t.Fatalf("expected a new secret, got %+v, %v", old, err)
+	}
+	if _, err := fake.AddClientSecret(ctx, "client-1", nil, "admin-token"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := fake.RetireClientSecret(ctx, "client-1", old.Id, time.Hour, "admin-token"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if secrets, _ := fake.ListClientSecrets(ctx, "client-1", "admin-token"); len(secrets) != 2 || secrets[0].Secret != "" {
+		t.Fatalf("expected both secrets during the overlap without their values, got %+v", secrets)
+	}
+
+	now = now.Add(time.Hour)
+	if secrets, _ := fake.ListClientSecrets(ctx, "client-1", "admin-token"); len(secrets) != 1 || secrets[0].Id == old.Id {
+		t.Fatalf("expected only the new secret after the overlap, got %+v", secrets)
+	}
+	if _, err := fake.RetireClientSecret(ctx, "client-1", old.Id, 0, "admin-token"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for an expired secret, got %v", err)
+	}
+}
+
+func TestFakeServiceUnusedGrants(t *testing.T) {
+	fake := NewFakeService()
+	now := time.Date(2030, 4, 1, 0, 0, 0, 0, time.UTC)
+	fake.Now = func() time.Time { return now }
+	fake.AddUser(golang.User{Id: "user-id", Resources: map[string]golang.UserResource{
+		"billing:read":  {Key: "billing:read"},
+		"billing:write": {Key: "billing:write"},
+	}})
+	fake.AddToken("admin-token", "user-id")
+	fake.RecordAccess("user-id", "billing:read", now.Add(-24*time.Hour))
+	fake.RecordAccess("user-id", "billing:write", now.Add(-100*24*time.Hour))
+
+	report, err := fake.FindUnusedGrants(context.Background(), "user-id", 90*24*time.Hour, "admin-token")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(report.Unused) != 1 || report.Unused[0].Grant != "billing:write" {
+		t.Fatalf("expected the grant last used before the window to be unused, got %+v", report.Unused)
+	}
+}
+
+func TestFakeServiceCallsAndFailures(t *testing.T) {
+	fake := NewFakeService()
+	fake.AddUser(golang.User{Id: "user-id"})
+	fake.AddToken("valid-token", "user-id")
+	ctx := context.Background()
+
+	outage := errors.New("outage")
+	fake.FailWith("Me", outage)
+	if _, err := fake.Me(ctx, "valid-token"); !errors.Is(err, outage) {
+		t.Fatalf("expected injected failure, got %v", err)
+	}
+	fake.FailWith("Me", nil)
+	if _, err := fake.Me(ctx, "valid-token"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := fake.GetResource(ctx, "missing", "valid-token"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	calls := fake.CallsTo("Me")
+	if len(calls) != 2 || calls[0].Args[0] != "valid-token" {
+		t.Fatalf("unexpected calls %+v", calls)
+	}
+	if len(fake.Calls()) != 3 {
+		t.Fatalf("expected 3 calls, got %+v", fake.Calls())
+	}
+	fake.ResetCalls()
+	if len(fake.Calls()) != 0 {
+		t.Fatal("expected calls to be reset")
+	}
+}
+
+func TestFakeServiceRolesGrantResources(t *testing.T) {
+	fake := NewFakeService()
+	fake.AddUser(golang.User{Id: "user-id", Roles: map[string]golang.UserRole{"role-id": {Id: "role-id"}}})
+	fake.AddToken("valid-token", "user-id")
+	fake.AddRole(golang.Role{Id: "role-id", Name: "billing"})
+	ctx := context.Background()
+
+	if err := fake.AddResourceToRole(ctx, "role-id", golang.RoleResource{Id: "res-id", Key: "billing:read"}, "valid-token"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	user, _ := fake.Me(ctx, "valid-token")
+	if !user.Can("billing:read") {
+		t.Fatalf("expected resource granted through role, got %+v", user.Resources)
+	}
+
+	if err := fake.RemoveResourceFromRole(ctx, "role-id", "res-id", "valid-token"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !user.Can("billing:read") {
+		t.Fatal("expected earlier result not to change")
+	}
+	if user, _ := fake.Me(ctx, "valid-token"); user.Can("billing:read") {
+		t.Fatal("expected resource revoked with the role")
+	}
+}
+
+func TestFakeServiceListResources(t *testing.T) {
+	fake := NewFakeService()
+	fake.AddUser(golang.User{Id: "user-id"})
+	fake.AddToken("valid-token", "user-id")
+	for _, key := range []string{"billing:read", "billing:write", "reports:read"} {
+		fake.AddResource(golang.Resource{Key: key, Enabled: true})
+	}
+
+	list, err := fake.ListResources(context.Background(), golang.ListResourcesQuery{Key: "billing", Page: 2, Limit: 1}, "valid-token")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if list.Total != 2 || list.Skip != 1 || len(list.Resources) != 1 || list.Resources[0].Key != "billing:write" {
+		t.Fatalf("unexpected page %+v", list)
+	}
+}
+
+func TestFakeServiceEnsureResource(t *testing.T) {
+	fake := NewFakeService()
+	fake.AddUser(golang.User{Id: "user-id"})
+	fake.AddToken("valid-token", "user-id")
+	ctx := context.Background()
+
+	first := &golang.Resource{Key: "billing:read", ExternalId: "tf-billing"}
+	if created, err := fake.EnsureResource(ctx, first, "valid-token"); err != nil || !created {
+		t.Fatalf("expected resource created, got %v, %v", created, err)
+	}
+	second := &golang.Resource{Key: "billing:read", ExternalId: "tf-billing"}
+	if created, err := fake.EnsureResource(ctx, second, "valid-token"); err != nil || created || second.ID != first.ID {
+		t.Fatalf("expected existing resource %q, got %+v, %v, %v", first.ID, second, created, err)
+	}
+	if err := fake.CreateResource(ctx, &golang.Resource{ExternalId: "tf-billing"}, "valid-token"); !errors.Is(err, golang.ErrConflict) {
+		t.Fatalf("expected ErrConflict, got %v", err)
+	}
+	if r, err := fake.GetResourceByExternalID(ctx, "tf-billing", "valid-token"); err != nil || r.ID != first.ID {
+		t.Fatalf("expected resource %q, got %+v, %v", first.ID, r, err)
+	}
+}
+
+func TestFakeServiceScanUsers(t *testing.T) {
+	fake := NewFakeService()
+	for _, id := range []string{"user-a", "user-b", "user-c"} {
+		fake.AddUser(golang.User{Id: id})
+	}
+	fake.AddToken("valid-token", "user-a")
+	ctx := context.Background()
+
+	var cursor string
+	opts := golang.ScanOptions{PageSize: 2, Checkpoint: func(ctx context.Context, c string) error {
+		cursor = c
+		return nil
+	}}
+	for user, err := range fake.ScanUsers(ctx, opts, "valid-token") {
+		if err != nil || user.Id == "user-c" {
+			break
+		}
+	}
+	if cursor != "user-b" {
+		t.Fatalf("expected the cursor after the first page, got %q", cursor)
+	}
+
+	opts.Cursor = cursor
+	var ids []string
+	for user, err := range fake.ScanUsers(ctx, opts, "valid-token") {
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		ids = append(ids, user.Id)
+	}
+	if len(ids) != 1 || ids[0] != "user-c" || cursor != "" {
+		t.Fatalf("expected to resume at user-c and reset the cursor, got %v, %q", ids, cursor)
+	}
+}
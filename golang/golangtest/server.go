@@ -0,0 +1,167 @@
+package golangtest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/melvinodsa/go-iam-sdk/golang"
+)
+
+// VersionHeader is the response header carrying Behavior.Version.
+const VersionHeader = "X-Go-IAM-Version"
+
+// Behavior shapes how a Server answers requests, so that one test can run
+// servers that are slow, failing or on different versions side by side.
+type Behavior struct {
+	Latency time.Duration // Delay before every response, cut short if the request is canceled
+	Status  int           // If set, every request fails with this status
+	Version string        // If set, sent in the VersionHeader of every response
+}
+
+// Server is an HTTP server speaking the go-iam API for a subset of the
+// endpoints, answered from a FakeService: Verify, Me, GetResource and
+// ListResources. Other endpoints answer 501 Not Implemented.
+type Server struct {
+	*httptest.Server
+	Fake *FakeService
+
+	mu       sync.Mutex
+	behavior Behavior
+	requests atomic.Int64
+}
+
+// NewServers starts one Server per behavior, all backed by fake, and closes
+// them when the test ends. Point a golang.Service at each Server's URL to
+// exercise failover between go-iam environments:
+//
+//	servers := golangtest.NewServers(t, fake,
+//		golangtest.Behavior{Status: http.StatusServiceUnavailable},
+//		golangtest.Behavior{Latency: 50 * time.Millisecond},
+//	)
+//	primary := golang.NewService(servers[0].URL, "client-id", "secret")
+//	secondary := golang.NewService(servers[1].URL, "client-id", "secret")
+func NewServers(t testing.TB, fake *FakeService, behaviors ...Behavior) []*Server {
+	t.Helper()
+	servers := make([]*Server, len(behaviors))
+	for i, b := range behaviors {
+		s := &Server{Fake: fake, behavior: b}
+		s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+		t.Cleanup(s.Close)
+		servers[i] = s
+	}
+	return servers
+}
+
+// SetBehavior changes the behavior for the following requests, e.g. to take
+// a server down in the middle of a test.
+func (s *Server) SetBehavior(b Behavior) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.behavior = b
+}
+
+// Requests returns the number of requests the server received.
+func (s *Server) Requests() int64 {
+	return s.requests.Load()
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.requests.Add(1)
+	s.mu.Lock()
+	b := s.behavior
+	s.mu.Unlock()
+
+	if b.Latency > 0 {
+		t := time.NewTimer(b.Latency)
+		defer t.Stop()
+		select {
+		case <-t.C:
+		case <-r.Context().Done():
+			return
+		}
+	}
+	if b.Version != "" {
+		w.Header().Set(VersionHeader, b.Version)
+	}
+	if b.Status != 0 {
+		writeEnvelope(w, b.Status, nil, http.StatusText(b.Status))
+		return
+	}
+
+	ctx := r.Context()
+	token := bearerToken(r)
+	var data any
+	var err error
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/auth/v1/verify":
+		var accessToken string
+		accessToken, err = s.Fake.Verify(ctx, r.URL.Query().Get("code"))
+		data = golang.AuthVerifyCodeResponse{AccessToken: accessToken}
+	case r.Method == http.MethodGet && r.URL.Path == "/me/v1/":
+		data, err = s.Fake.Me(ctx, token)
+	case r.Method == http.MethodGet && r.URL.Path == "/resource/v1/search":
+		data, err = s.Fake.ListResources(ctx, listResourcesQuery(r), token)
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/resource/v1/"):
+		data, err = s.Fake.GetResource(ctx, strings.TrimPrefix(r.URL.Path, "/resource/v1/"), token)
+	default:
+		writeEnvelope(w, http.StatusNotImplemented, nil, "not implemented by golangtest.Server")
+		return
+	}
+	if err != nil {
+		writeEnvelope(w, statusFor(err), nil, err.Error())
+		return
+	}
+	writeEnvelope(w, http.StatusOK, data, "")
+}
+
+func writeEnvelope(w http.ResponseWriter, status int, data any, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]any{
+		"success": status == http.StatusOK,
+		"message": message,
+		"data":    data,
+	})
+}
+
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, golang.ErrUnauthorized):
+		return http.StatusUnauthorized
+	case errors.Is(err, golang.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, golang.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, golang.ErrConflict):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func bearerToken(r *http.Request) string {
+	scheme, token, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return token
+}
+
+func listResourcesQuery(r *http.Request) golang.ListResourcesQuery {
+	q := r.URL.Query()
+	query := golang.ListResourcesQuery{Name: q.Get("name"), Key: q.Get("key")}
+	if enabled, err := strconv.ParseBool(q.Get("enabled")); err == nil {
+		query.Enabled = &enabled
+	}
+	query.Page, _ = strconv.Atoi(q.Get("page"))
+	query.Limit, _ = strconv.Atoi(q.Get("limit"))
+	return query
+}
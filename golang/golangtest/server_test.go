@@ -0,0 +1,75 @@
+package golangtest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/melvinodsa/go-iam-sdk/golang"
+)
+
+func TestServers(t *testing.T) {
+	fake := NewFakeService()
+	fake.AddUser(golang.User{Id: "user-id", Name: "Test User"})
+	fake.AddToken("valid-token", "user-id")
+	fake.AddResource(golang.Resource{ID: "resource-id", Key: "billing:read", Enabled: true})
+
+	servers := NewServers(t, fake,
+		Behavior{Status: http.StatusServiceUnavailable},
+		Behavior{Latency: 20 * time.Millisecond, Version: "v2"},
+	)
+	down := golang.NewService(servers[0].URL, "client-id", "secret")
+	slow := golang.NewService(servers[1].URL, "client-id", "secret")
+	ctx := context.Background()
+
+	t.Run("Failing Server", func(t *testing.T) {
+		if _, err := down.Me(ctx, "valid-token"); !errors.Is(err, golang.ErrServer) {
+			t.Fatalf("expected ErrServer, got %v", err)
+		}
+		if len(fake.CallsTo("Me")) != 0 {
+			t.Fatal("expected the failing server not to reach the fake")
+		}
+	})
+
+	t.Run("Slow Server", func(t *testing.T) {
+		user, err := slow.Me(ctx, "valid-token")
+		if err != nil || user.Name != "Test User" {
+			t.Fatalf("unexpected user %+v, %v", user, err)
+		}
+		resource, err := slow.GetResource(ctx, "resource-id", "valid-token")
+		if err != nil || resource.Key != "billing:read" {
+			t.Fatalf("unexpected resource %+v, %v", resource, err)
+		}
+		list, err := slow.ListResources(ctx, golang.ListResourcesQuery{Key: "billing"}, "valid-token")
+		if err != nil || list.Total != 1 {
+			t.Fatalf("unexpected resource list %+v, %v", list, err)
+		}
+
+		timeout, cancel := context.WithTimeout(ctx, 5*time.Millisecond)
+		defer cancel()
+		if _, err := slow.Me(timeout, "valid-token"); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected a timeout, got %v", err)
+		}
+	})
+
+	t.Run("Fake Errors", func(t *testing.T) {
+		if _, err := slow.Me(ctx, "invalid-token"); !errors.Is(err, golang.ErrUnauthorized) {
+			t.Fatalf("expected ErrUnauthorized, got %v", err)
+		}
+		if _, err := slow.GetResource(ctx, "missing", "valid-token"); !errors.Is(err, golang.ErrNotFound) {
+			t.Fatalf("expected ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("Recovery", func(t *testing.T) {
+		servers[0].SetBehavior(Behavior{})
+		if _, err := down.Me(ctx, "valid-token"); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if servers[0].Requests() != 2 {
+			t.Fatalf("expected 2 requests, got %d", servers[0].Requests())
+		}
+	})
+}
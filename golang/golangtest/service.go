@@ -0,0 +1,1905 @@
+package golangtest
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/melvinodsa/go-iam-sdk/golang"
+)
+
+// Doctor reports the fake as a healthy server supporting every required
+// capability, or as unreachable if FailWith set an error for Doctor. The
+// callback URL passes if it is a redirect URI of a client added with
+// AddClientConfig.
+func (f *FakeService) Doctor(ctx context.Context, opts golang.DoctorOptions) *golang.DoctorReport {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	report := &golang.DoctorReport{}
+	add := func(name string, status golang.CheckStatus, detail string, err error) {
+		report.Checks = append(report.Checks, golang.DoctorCheck{Name: name, Status: status, Detail: detail, Err: err})
+	}
+	if err := f.record("Doctor", opts); err != nil {
+		add(golang.CheckConnectivity, golang.CheckFailed, "cannot reach the server", err)
+		for _, name := range []string{golang.CheckCredentials, golang.CheckClockSkew, golang.CheckCapabilities, golang.CheckCallbackURL} {
+			add(name, golang.CheckSkipped, "the server is unreachable", nil)
+		}
+		return report
+	}
+	report.Server = &golang.ServerInfo{Version: "fake", Capabilities: slices.Clone(opts.RequiredCapabilities)}
+	add(golang.CheckConnectivity, golang.CheckPassed, "", nil)
+	add(golang.CheckCredentials, golang.CheckPassed, "", nil)
+	add(golang.CheckClockSkew, golang.CheckPassed, "", nil)
+	if len(opts.RequiredCapabilities) == 0 {
+		add(golang.CheckCapabilities, golang.CheckSkipped, "no capabilities are required", nil)
+	} else {
+		add(golang.CheckCapabilities, golang.CheckPassed, "", nil)
+	}
+	switch {
+	case opts.CallbackURL == "":
+		add(golang.CheckCallbackURL, golang.CheckSkipped, "no callback URL given", nil)
+	case slices.ContainsFunc(sortedKeys(f.clientConfigs), func(id string) bool {
+		return slices.Contains(f.clientConfigs[id].RedirectURIs, opts.CallbackURL)
+	}):
+		add(golang.CheckCallbackURL, golang.CheckPassed, "", nil)
+	default:
+		add(golang.CheckCallbackURL, golang.CheckFailed, fmt.Sprintf("%s is not a redirect URI of any client", opts.CallbackURL), nil)
+	}
+	return report
+}
+
+// Verify exchanges a code added with AddCode for its token.
+func (f *FakeService) Verify(ctx context.Context, code string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.record("Verify", code); err != nil {
+		return "", err
+	}
+	token, ok := f.codes[code]
+	if !ok {
+		return "", ErrInvalidCode
+	}
+	return token, nil
+}
+
+// Me returns the user the token belongs to.
+func (f *FakeService) Me(ctx context.Context, token string) (*golang.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user, err := f.begin("Me", token)
+	if err != nil {
+		return nil, err
+	}
+	u := cloneUser(user)
+	u.ImpersonatedBy = f.tokens[token].impersonatedBy
+	return u, nil
+}
+
+// GetUsers returns the users with the given IDs, skipping unknown IDs.
+func (f *FakeService) GetUsers(ctx context.Context, ids []string, token string) ([]golang.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("GetUsers", token, ids); err != nil {
+		return nil, err
+	}
+	users := []golang.User{}
+	for _, id := range ids {
+		if u, ok := f.users[id]; ok {
+			users = append(users, *cloneUser(u))
+		}
+	}
+	return users, nil
+}
+
+// ResolveTokens resolves each token to its user, in order.
+func (f *FakeService) ResolveTokens(ctx context.Context, tokens []string) ([]golang.TokenResolution, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.record("ResolveTokens", tokens); err != nil {
+		return nil, err
+	}
+	resolutions := make([]golang.TokenResolution, len(tokens))
+	for i, token := range tokens {
+		resolutions[i].Token = token
+		user, err := f.authenticate(token)
+		if err != nil {
+			resolutions[i].Message = err.Error()
+			continue
+		}
+		resolutions[i].User = cloneUser(user)
+	}
+	return resolutions, nil
+}
+
+// GetRiskSignals returns the assessment set with SetRiskAssessment, or no
+// risk if none was set.
+func (f *FakeService) GetRiskSignals(ctx context.Context, userID string, token string) (*golang.RiskAssessment, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("GetRiskSignals", token, userID); err != nil {
+		return nil, err
+	}
+	if _, ok := f.users[userID]; !ok {
+		return nil, fmt.Errorf("user %q: %w", userID, ErrNotFound)
+	}
+	if a, ok := f.risks[userID]; ok {
+		risk := *a
+		return &risk, nil
+	}
+	return &golang.RiskAssessment{UserId: userID, Level: golang.RiskNone}, nil
+}
+
+// GetLockoutStatus returns the status set with SetLockoutStatus, or an
+// unlocked status if none was set.
+func (f *FakeService) GetLockoutStatus(ctx context.Context, userID string, token string) (*golang.LockoutStatus, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("GetLockoutStatus", token, userID); err != nil {
+		return nil, err
+	}
+	if _, ok := f.users[userID]; !ok {
+		return nil, fmt.Errorf("user %q: %w", userID, ErrNotFound)
+	}
+	if l, ok := f.lockouts[userID]; ok {
+		status := *l
+		return &status, nil
+	}
+	return &golang.LockoutStatus{UserId: userID}, nil
+}
+
+// UnlockUser clears the user's lockout status.
+func (f *FakeService) UnlockUser(ctx context.Context, userID string, token string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("UnlockUser", token, userID); err != nil {
+		return err
+	}
+	if _, ok := f.users[userID]; !ok {
+		return fmt.Errorf("user %q: %w", userID, ErrNotFound)
+	}
+	delete(f.lockouts, userID)
+	return nil
+}
+
+// RevokeTokens removes the tokens matching the filter. Tokens are issued when
+// added, and only tokens added with AddClientToken have a client.
+func (f *FakeService) RevokeTokens(ctx context.Context, filter golang.TokenFilter, token string) (*golang.TokenRevocation, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user, err := f.begin("RevokeTokens", token, filter)
+	if err != nil {
+		return nil, err
+	}
+	if filter.UserId == "" && filter.ClientId == "" && filter.IssuedBefore == nil {
+		return nil, golang.ErrEmptyTokenFilter
+	}
+	revocation := &golang.TokenRevocation{Filter: filter, RevokedAt: f.now(), RevokedBy: user.Id}
+	for t, entry := range f.tokens {
+		if (filter.UserId == "" || entry.userID == filter.UserId) &&
+			(filter.ClientId == "" || entry.clientID == filter.ClientId) &&
+			(filter.IssuedBefore == nil || entry.issuedAt.Before(*filter.IssuedBefore)) {
+			delete(f.tokens, t)
+			revocation.Revoked++
+		}
+	}
+	return revocation, nil
+}
+
+// GetUserMetadata returns the user's metadata.
+func (f *FakeService) GetUserMetadata(ctx context.Context, userID string, token string) (map[string]any, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("GetUserMetadata", token, userID); err != nil {
+		return nil, err
+	}
+	u, ok := f.users[userID]
+	if !ok {
+		return nil, fmt.Errorf("user %q: %w", userID, ErrNotFound)
+	}
+	return cloneMetadata(u.Metadata), nil
+}
+
+// UpdateUserMetadata merges metadata into the user's metadata, removing
+// keys with a nil value.
+func (f *FakeService) UpdateUserMetadata(ctx context.Context, userID string, metadata map[string]any, token string) (map[string]any, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user, err := f.begin("UpdateUserMetadata", token, userID, metadata)
+	if err != nil {
+		return nil, err
+	}
+	u, ok := f.users[userID]
+	if !ok {
+		return nil, fmt.Errorf("user %q: %w", userID, ErrNotFound)
+	}
+	if u.Metadata == nil {
+		u.Metadata = map[string]any{}
+	}
+	for k, v := range metadata {
+		if v == nil {
+			delete(u.Metadata, k)
+		} else {
+			u.Metadata[k] = v
+		}
+	}
+	u.UpdatedAt, u.UpdatedBy = f.now(), user.Id
+	return cloneMetadata(u.Metadata), nil
+}
+
+// SearchUsers returns the users matching the query ordered by ID. Metadata
+// values are compared in their fmt.Sprint form.
+func (f *FakeService) SearchUsers(ctx context.Context, query golang.SearchUsersQuery, token string) (*golang.UserList, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("SearchUsers", token, query); err != nil {
+		return nil, err
+	}
+	matches := []golang.User{}
+	for _, id := range sortedKeys(f.users) {
+		u := f.users[id]
+		if id <= query.After {
+			continue
+		}
+		if !strings.Contains(u.Name, query.Name) || !strings.Contains(u.Email, query.Email) || !matchesMetadata(u.Metadata, query.Metadata) {
+			continue
+		}
+		matches = append(matches, *cloneUser(u))
+	}
+	page, skip := paginate(matches, query.Page, query.Limit)
+	return &golang.UserList{Users: page, Pagination: pagination(len(matches), skip, query.Page, query.Limit)}, nil
+}
+
+// ScanUsers iterates over the users in ID order, a page at a time through
+// SearchUsers, like golang.Service.ScanUsers.
+func (f *FakeService) ScanUsers(ctx context.Context, opts golang.ScanOptions, token string) iter.Seq2[golang.User, error] {
+	return scan(ctx, opts, func(u golang.User) string { return u.Id }, func(after string, limit int) ([]golang.User, error) {
+		list, err := f.SearchUsers(ctx, golang.SearchUsersQuery{After: after, Limit: limit}, token)
+		if err != nil {
+			return nil, err
+		}
+		return list.Users, nil
+	})
+}
+
+// ExportUserData writes the user and its consents to w as a JSON document.
+func (f *FakeService) ExportUserData(ctx context.Context, userID string, w io.Writer, token string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("ExportUserData", token, userID); err != nil {
+		return err
+	}
+	u, ok := f.users[userID]
+	if !ok {
+		return fmt.Errorf("user %q: %w", userID, ErrNotFound)
+	}
+	return json.NewEncoder(w).Encode(struct {
+		User     *golang.User     `json:"user"`
+		Consents []golang.Consent `json:"consents"`
+	}{cloneUser(u), append([]golang.Consent{}, f.consents[userID]...)})
+}
+
+// EraseUser anonymizes and disables the user and removes its tokens and
+// consents.
+func (f *FakeService) EraseUser(ctx context.Context, userID string, reason string, token string) (*golang.ErasureReceipt, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user, err := f.begin("EraseUser", token, userID, reason)
+	if err != nil {
+		return nil, err
+	}
+	u, ok := f.users[userID]
+	if !ok {
+		return nil, fmt.Errorf("user %q: %w", userID, ErrNotFound)
+	}
+	receipt := &golang.ErasureReceipt{UserId: userID, Reason: reason, AuditId: f.newID(), ErasedAt: f.now(), ErasedBy: user.Id}
+	u.Name, u.Email, u.Phone, u.ProfilePic, u.Metadata, u.Enabled = "", "", "", "", nil, false
+	u.UpdatedAt, u.UpdatedBy = receipt.ErasedAt, user.Id
+	for t, entry := range f.tokens {
+		if entry.userID == userID {
+			delete(f.tokens, t)
+			receipt.TokensRevoked++
+		}
+	}
+	delete(f.consents, userID)
+	return receipt, nil
+}
+
+// FindPossibleDuplicates returns the other users with the same email,
+// ignoring case, phone or name, ordered by score then ID. The score is the
+// fraction of these attributes that match.
+func (f *FakeService) FindPossibleDuplicates(ctx context.Context, userID string, token string) ([]golang.DuplicateCandidate, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("FindPossibleDuplicates", token, userID); err != nil {
+		return nil, err
+	}
+	u, ok := f.users[userID]
+	if !ok {
+		return nil, fmt.Errorf("user %q: %w", userID, ErrNotFound)
+	}
+	candidates := []golang.DuplicateCandidate{}
+	for _, id := range sortedKeys(f.users) {
+		other := f.users[id]
+		if id == userID {
+			continue
+		}
+		var reasons []string
+		if u.Email != "" && strings.EqualFold(u.Email, other.Email) {
+			reasons = append(reasons, golang.DuplicateReasonEmail)
+		}
+		if u.Phone != "" && u.Phone == other.Phone {
+			reasons = append(reasons, golang.DuplicateReasonPhone)
+		}
+		if u.Name != "" && u.Name == other.Name {
+			reasons = append(reasons, golang.DuplicateReasonName)
+		}
+		if len(reasons) > 0 {
+			candidates = append(candidates, golang.DuplicateCandidate{User: *cloneUser(other), Reasons: reasons, Score: float64(len(reasons)) / 3})
+		}
+	}
+	slices.SortStableFunc(candidates, func(a, b golang.DuplicateCandidate) int { return cmp.Compare(b.Score, a.Score) })
+	return candidates, nil
+}
+
+// MergeUsers adds the roles, resources and policies of the duplicate user to
+// the primary one, moves its tokens and consents and deletes it. The primary
+// user's grants win over the duplicate's.
+func (f *FakeService) MergeUsers(ctx context.Context, primaryID string, duplicateID string, token string) (*golang.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user, err := f.begin("MergeUsers", token, primaryID, duplicateID)
+	if err != nil {
+		return nil, err
+	}
+	if primaryID == duplicateID {
+		return nil, fmt.Errorf("cannot merge user %q into itself", primaryID)
+	}
+	primary, ok := f.users[primaryID]
+	if !ok {
+		return nil, fmt.Errorf("user %q: %w", primaryID, ErrNotFound)
+	}
+	duplicate, ok := f.users[duplicateID]
+	if !ok {
+		return nil, fmt.Errorf("user %q: %w", duplicateID, ErrNotFound)
+	}
+	primary.Roles = mergeMissing(primary.Roles, duplicate.Roles)
+	primary.Resources = mergeMissing(primary.Resources, duplicate.Resources)
+	primary.Policies = mergeMissing(primary.Policies, duplicate.Policies)
+	primary.UpdatedAt, primary.UpdatedBy = f.now(), user.Id
+	for t, entry := range f.tokens {
+		if entry.userID == duplicateID {
+			entry.userID = primaryID
+			f.tokens[t] = entry
+		}
+	}
+	f.consents[primaryID] = append(f.consents[primaryID], f.consents[duplicateID]...)
+	delete(f.consents, duplicateID)
+	delete(f.users, duplicateID)
+	return cloneUser(primary), nil
+}
+
+// mergeMissing adds the entries of src whose key is missing from dst.
+func mergeMissing[V any](dst, src map[string]V) map[string]V {
+	for k, v := range src {
+		if _, ok := dst[k]; ok {
+			continue
+		}
+		if dst == nil {
+			dst = map[string]V{}
+		}
+		dst[k] = v
+	}
+	return dst
+}
+
+// GetResourceUsage returns how often the user accessed each resource since
+// the given time, from the accesses added with RecordAccess, sorted by key.
+func (f *FakeService) GetResourceUsage(ctx context.Context, userID string, since time.Time, token string) ([]golang.ResourceUsage, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("GetResourceUsage", token, userID, since); err != nil {
+		return nil, err
+	}
+	return f.usage(userID, since), nil
+}
+
+// FindUnusedGrants reports the user's grants without access added with
+// RecordAccess over the window.
+func (f *FakeService) FindUnusedGrants(ctx context.Context, userID string, window time.Duration, token string) (*golang.UnusedGrantReport, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("FindUnusedGrants", token, userID, window); err != nil {
+		return nil, err
+	}
+	if window <= 0 {
+		return nil, fmt.Errorf("window must be positive, got %v", window)
+	}
+	u, ok := f.users[userID]
+	if !ok {
+		return nil, fmt.Errorf("user %q: %w", userID, ErrNotFound)
+	}
+	until := f.Now()
+	since := until.Add(-window)
+	return &golang.UnusedGrantReport{UserId: userID, Since: since, Until: until, Unused: u.UnusedGrants(f.usage(userID, since))}, nil
+}
+
+// usage aggregates the user's accesses since the given time. It must be
+// called with f.mu held.
+func (f *FakeService) usage(userID string, since time.Time) []golang.ResourceUsage {
+	byKey := map[string]*golang.ResourceUsage{}
+	for _, a := range f.accesses {
+		if a.userID != userID || a.at.Before(since) {
+			continue
+		}
+		use, ok := byKey[a.resourceKey]
+		if !ok {
+			use = &golang.ResourceUsage{ResourceKey: a.resourceKey}
+			byKey[a.resourceKey] = use
+		}
+		use.Count++
+		if use.LastAccessAt == nil || a.at.After(*use.LastAccessAt) {
+			at := a.at
+			use.LastAccessAt = &at
+		}
+	}
+	usage := []golang.ResourceUsage{}
+	for _, k := range sortedKeys(byKey) {
+		usage = append(usage, *byKey[k])
+	}
+	return usage
+}
+
+// ListConsents returns the consents added for the user with AddConsent.
+func (f *FakeService) ListConsents(ctx context.Context, userID string, token string) ([]golang.Consent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("ListConsents", token, userID); err != nil {
+		return nil, err
+	}
+	return append([]golang.Consent{}, f.consents[userID]...), nil
+}
+
+// RevokeConsent removes the user's consent to the client.
+func (f *FakeService) RevokeConsent(ctx context.Context, userID string, clientID string, token string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("RevokeConsent", token, userID, clientID); err != nil {
+		return err
+	}
+	consents := f.consents[userID]
+	for i, c := range consents {
+		if c.ClientId == clientID {
+			f.consents[userID] = append(consents[:i:i], consents[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("consent of user %q to client %q: %w", userID, clientID, ErrNotFound)
+}
+
+// EvaluateWithContext evaluates the token's user locally, see User.EvaluateWithContext.
+func (f *FakeService) EvaluateWithContext(ctx context.Context, resourceKey string, attrs golang.AccessAttributes, token string) (*golang.Evaluation, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user, err := f.begin("EvaluateWithContext", token, resourceKey, attrs)
+	if err != nil {
+		return nil, err
+	}
+	return user.EvaluateWithContext(ctx, resourceKey, attrs)
+}
+
+// ListProjects returns every project ordered by ID.
+func (f *FakeService) ListProjects(ctx context.Context, token string) ([]golang.Project, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("ListProjects", token); err != nil {
+		return nil, err
+	}
+	projects := []golang.Project{}
+	for _, id := range sortedKeys(f.projects) {
+		projects = append(projects, *f.projects[id])
+	}
+	return projects, nil
+}
+
+// CreateProject stores the project under a new ID.
+func (f *FakeService) CreateProject(ctx context.Context, project *golang.Project, token string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user, err := f.begin("CreateProject", token, project)
+	if err != nil {
+		return err
+	}
+	if project == nil {
+		return fmt.Errorf("project cannot be nil")
+	}
+	p := *project
+	p.Id, p.CreatedAt, p.CreatedBy = f.newID(), f.now(), user.Id
+	f.projects[p.Id] = &p
+	*project = p
+	return nil
+}
+
+// UpdateProject replaces the project with the given ID, keeping its region.
+func (f *FakeService) UpdateProject(ctx context.Context, id string, project *golang.Project, token string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user, err := f.begin("UpdateProject", token, id, project)
+	if err != nil {
+		return err
+	}
+	if project == nil {
+		return fmt.Errorf("project cannot be nil")
+	}
+	existing, ok := f.projects[id]
+	if !ok {
+		return fmt.Errorf("project %q: %w", id, ErrNotFound)
+	}
+	p := *project
+	p.Id, p.Region, p.CreatedAt, p.CreatedBy = id, existing.Region, existing.CreatedAt, existing.CreatedBy
+	p.UpdatedAt, p.UpdatedBy = f.now(), user.Id
+	f.projects[id] = &p
+	*project = p
+	return nil
+}
+
+// GetBranding returns the branding of a project added with AddProject, empty
+// if it was never updated.
+func (f *FakeService) GetBranding(ctx context.Context, projectID string, token string) (*golang.Branding, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("GetBranding", token, projectID); err != nil {
+		return nil, err
+	}
+	if _, ok := f.projects[projectID]; !ok {
+		return nil, fmt.Errorf("project %q: %w", projectID, ErrNotFound)
+	}
+	branding := golang.Branding{ProjectId: projectID}
+	if b, ok := f.brandings[projectID]; ok {
+		branding = *b
+		branding.Texts = maps.Clone(b.Texts)
+	}
+	return &branding, nil
+}
+
+// UpdateBranding validates and replaces the branding of a project added with
+// AddProject.
+func (f *FakeService) UpdateBranding(ctx context.Context, branding *golang.Branding, token string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user, err := f.begin("UpdateBranding", token, branding)
+	if err != nil {
+		return err
+	}
+	if branding == nil {
+		return fmt.Errorf("branding cannot be nil")
+	}
+	if err := branding.Validate(); err != nil {
+		return err
+	}
+	if _, ok := f.projects[branding.ProjectId]; !ok {
+		return fmt.Errorf("project %q: %w", branding.ProjectId, ErrNotFound)
+	}
+	b := *branding
+	b.Texts = maps.Clone(branding.Texts)
+	b.UpdatedAt, b.UpdatedBy = f.now(), user.Id
+	f.brandings[b.ProjectId] = &b
+	*branding = b
+	return nil
+}
+
+// CreateTemplate validates the template and stores it under a new ID.
+func (f *FakeService) CreateTemplate(ctx context.Context, template *golang.Template, token string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user, err := f.begin("CreateTemplate", token, template)
+	if err != nil {
+		return err
+	}
+	if template == nil {
+		return fmt.Errorf("template cannot be nil")
+	}
+	if err := template.Validate(); err != nil {
+		return err
+	}
+	if err := f.templateConflict(template); err != nil {
+		return err
+	}
+	t := *template
+	t.Id, t.CreatedAt, t.CreatedBy = f.newID(), f.now(), user.Id
+	f.templates[t.Id] = &t
+	*template = t
+	return nil
+}
+
+// templateConflict fails with ErrConflict if another template of the project
+// has the same kind, channel and locale.
+func (f *FakeService) templateConflict(template *golang.Template) error {
+	for _, t := range f.templates {
+		if t.Id != template.Id && t.ProjectId == template.ProjectId && t.Kind == template.Kind &&
+			t.Channel == template.Channel && t.Locale == template.Locale {
+			return fmt.Errorf("%s %s template in %q: %w", template.Kind, template.Channel, template.Locale, ErrConflict)
+		}
+	}
+	return nil
+}
+
+// GetTemplate returns the template with the given ID.
+func (f *FakeService) GetTemplate(ctx context.Context, id string, token string) (*golang.Template, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("GetTemplate", token, id); err != nil {
+		return nil, err
+	}
+	t, ok := f.templates[id]
+	if !ok {
+		return nil, fmt.Errorf("template %q: %w", id, ErrNotFound)
+	}
+	template := *t
+	return &template, nil
+}
+
+// ListTemplates returns the templates matching the query ordered by ID.
+func (f *FakeService) ListTemplates(ctx context.Context, query golang.ListTemplatesQuery, token string) (*golang.TemplateList, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("ListTemplates", token, query); err != nil {
+		return nil, err
+	}
+	matches := []golang.Template{}
+	for _, id := range sortedKeys(f.templates) {
+		t := f.templates[id]
+		if (query.Kind == "" || t.Kind == query.Kind) && (query.Channel == "" || t.Channel == query.Channel) &&
+			(query.Locale == "" || t.Locale == query.Locale) {
+			matches = append(matches, *t)
+		}
+	}
+	page, skip := paginate(matches, query.Page, query.Limit)
+	return &golang.TemplateList{Templates: page, Pagination: pagination(len(matches), skip, query.Page, query.Limit)}, nil
+}
+
+// UpdateTemplate validates and replaces the template with the same ID.
+func (f *FakeService) UpdateTemplate(ctx context.Context, template *golang.Template, token string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user, err := f.begin("UpdateTemplate", token, template)
+	if err != nil {
+		return err
+	}
+	if template == nil {
+		return fmt.Errorf("template cannot be nil")
+	}
+	if err := template.Validate(); err != nil {
+		return err
+	}
+	existing, ok := f.templates[template.Id]
+	if !ok {
+		return fmt.Errorf("template %q: %w", template.Id, ErrNotFound)
+	}
+	if err := f.templateConflict(template); err != nil {
+		return err
+	}
+	t := *template
+	t.CreatedAt, t.CreatedBy = existing.CreatedAt, existing.CreatedBy
+	t.UpdatedAt, t.UpdatedBy = f.now(), user.Id
+	f.templates[t.Id] = &t
+	*template = t
+	return nil
+}
+
+// DeleteTemplate removes the template with the given ID.
+func (f *FakeService) DeleteTemplate(ctx context.Context, id string, token string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("DeleteTemplate", token, id); err != nil {
+		return err
+	}
+	if _, ok := f.templates[id]; !ok {
+		return fmt.Errorf("template %q: %w", id, ErrNotFound)
+	}
+	delete(f.templates, id)
+	return nil
+}
+
+// CreateOrganization stores the organization under a new ID.
+func (f *FakeService) CreateOrganization(ctx context.Context, org *golang.Organization, token string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user, err := f.begin("CreateOrganization", token, org)
+	if err != nil {
+		return err
+	}
+	if org == nil {
+		return fmt.Errorf("organization cannot be nil")
+	}
+	o := *org
+	o.Id, o.CreatedAt, o.CreatedBy = f.newID(), f.now(), user.Id
+	f.organizations[o.Id] = &o
+	*org = o
+	return nil
+}
+
+// GetOrganization returns the organization with the given ID.
+func (f *FakeService) GetOrganization(ctx context.Context, id string, token string) (*golang.Organization, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("GetOrganization", token, id); err != nil {
+		return nil, err
+	}
+	o, ok := f.organizations[id]
+	if !ok {
+		return nil, fmt.Errorf("organization %q: %w", id, ErrNotFound)
+	}
+	org := *o
+	return &org, nil
+}
+
+// ListOrganizations returns every organization ordered by ID.
+func (f *FakeService) ListOrganizations(ctx context.Context, token string) ([]golang.Organization, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("ListOrganizations", token); err != nil {
+		return nil, err
+	}
+	orgs := []golang.Organization{}
+	for _, id := range sortedKeys(f.organizations) {
+		orgs = append(orgs, *f.organizations[id])
+	}
+	return orgs, nil
+}
+
+// UpdateOrganization replaces the organization with the same ID.
+func (f *FakeService) UpdateOrganization(ctx context.Context, org *golang.Organization, token string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user, err := f.begin("UpdateOrganization", token, org)
+	if err != nil {
+		return err
+	}
+	if org == nil {
+		return fmt.Errorf("organization cannot be nil")
+	}
+	existing, ok := f.organizations[org.Id]
+	if !ok {
+		return fmt.Errorf("organization %q: %w", org.Id, ErrNotFound)
+	}
+	o := *org
+	o.CreatedAt, o.CreatedBy = existing.CreatedAt, existing.CreatedBy
+	o.UpdatedAt, o.UpdatedBy = f.now(), user.Id
+	f.organizations[o.Id] = &o
+	*org = o
+	return nil
+}
+
+// DeleteOrganization removes the organization with the given ID.
+func (f *FakeService) DeleteOrganization(ctx context.Context, id string, token string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("DeleteOrganization", token, id); err != nil {
+		return err
+	}
+	if _, ok := f.organizations[id]; !ok {
+		return fmt.Errorf("organization %q: %w", id, ErrNotFound)
+	}
+	delete(f.organizations, id)
+	return nil
+}
+
+// ListOrganizationProjects returns the projects whose OrgId is the
+// organization's, ordered by ID.
+func (f *FakeService) ListOrganizationProjects(ctx context.Context, orgID string, token string) ([]golang.Project, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("ListOrganizationProjects", token, orgID); err != nil {
+		return nil, err
+	}
+	if _, ok := f.organizations[orgID]; !ok {
+		return nil, fmt.Errorf("organization %q: %w", orgID, ErrNotFound)
+	}
+	projects := []golang.Project{}
+	for _, id := range sortedKeys(f.projects) {
+		if p := f.projects[id]; p.OrgId == orgID {
+			projects = append(projects, *p)
+		}
+	}
+	return projects, nil
+}
+
+// CreateOrganizationRole stores the role under a new ID as a role of the organization.
+func (f *FakeService) CreateOrganizationRole(ctx context.Context, orgID string, role *golang.Role, token string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user, err := f.begin("CreateOrganizationRole", token, orgID, role)
+	if err != nil {
+		return err
+	}
+	if role == nil {
+		return fmt.Errorf("role cannot be nil")
+	}
+	if _, ok := f.organizations[orgID]; !ok {
+		return fmt.Errorf("organization %q: %w", orgID, ErrNotFound)
+	}
+	r := *role
+	r.Id, r.OrgId, r.CreatedAt, r.CreatedBy = f.newID(), orgID, f.now(), user.Id
+	r.Resources = maps.Clone(r.Resources)
+	f.roles[r.Id] = &r
+	*role = r
+	return nil
+}
+
+// ListOrganizationRoles returns the roles of the organization ordered by ID.
+func (f *FakeService) ListOrganizationRoles(ctx context.Context, orgID string, token string) ([]golang.Role, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("ListOrganizationRoles", token, orgID); err != nil {
+		return nil, err
+	}
+	roles := []golang.Role{}
+	for _, id := range sortedKeys(f.roles) {
+		if r := *f.roles[id]; r.OrgId == orgID {
+			r.Resources = maps.Clone(r.Resources)
+			roles = append(roles, r)
+		}
+	}
+	return roles, nil
+}
+
+// GetClientConfig returns the configuration of the client.
+func (f *FakeService) GetClientConfig(ctx context.Context, clientID string, token string) (*golang.ClientConfig, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("GetClientConfig", token, clientID); err != nil {
+		return nil, err
+	}
+	c, ok := f.clientConfigs[clientID]
+	if !ok {
+		return nil, fmt.Errorf("client %q: %w", clientID, ErrNotFound)
+	}
+	config := *c
+	return &config, nil
+}
+
+// UpdateClientConfig replaces the configuration of an existing client.
+func (f *FakeService) UpdateClientConfig(ctx context.Context, config *golang.ClientConfig, token string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user, err := f.begin("UpdateClientConfig", token, config)
+	if err != nil {
+		return err
+	}
+	if config == nil {
+		return fmt.Errorf("client config cannot be nil")
+	}
+	if _, ok := f.clientConfigs[config.ClientId]; !ok {
+		return fmt.Errorf("client %q: %w", config.ClientId, ErrNotFound)
+	}
+	c := *config
+	c.UpdatedAt, c.UpdatedBy = f.now(), user.Id
+	f.clientConfigs[c.ClientId] = &c
+	*config = c
+	return nil
+}
+
+// ListClientSecrets returns the secrets of a client added with
+// AddClientConfig, without the secrets themselves.
+func (f *FakeService) ListClientSecrets(ctx context.Context, clientID string, token string) ([]golang.ClientSecret, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("ListClientSecrets", token, clientID); err != nil {
+		return nil, err
+	}
+	if _, ok := f.clientConfigs[clientID]; !ok {
+		return nil, fmt.Errorf("client %q: %w", clientID, ErrNotFound)
+	}
+	secrets := []golang.ClientSecret{}
+	for _, id := range sortedKeys(f.clientSecrets) {
+		if c := *f.clientSecrets[id]; c.ClientId == clientID && c.Active(f.Now()) {
+			c.ProjectIds = slices.Clone(c.ProjectIds)
+			secrets = append(secrets, c)
+		}
+	}
+	return secrets, nil
+}
+
+// AddClientSecret adds a generated secret to a client added with
+// AddClientConfig.
+func (f *FakeService) AddClientSecret(ctx context.Context, clientID string, projectIDs []string, token string) (*golang.ClientSecret, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user, err := f.begin("AddClientSecret", token, clientID, projectIDs)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := f.clientConfigs[clientID]; !ok {
+		return nil, fmt.Errorf("client %q: %w", clientID, ErrNotFound)
+	}
+	c := golang.ClientSecret{
+		Id:         f.newID(),
+		ClientId:   clientID,
+		ProjectIds: slices.Clone(projectIDs),
+		CreatedAt:  f.now(),
+		CreatedBy:  user.Id,
+	}
+	c.Secret = "secret-" + c.Id
+	c.Hint = c.Secret[len(c.Secret)-4:]
+	stored := c
+	stored.Secret = ""
+	f.clientSecrets[c.Id] = &stored
+	return &c, nil
+}
+
+// RetireClientSecret expires an active secret of the client after the
+// overlap. Retiring a secret again can only bring its expiry forward.
+func (f *FakeService) RetireClientSecret(ctx context.Context, clientID string, secretID string, overlap time.Duration, token string) (*golang.ClientSecret, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user, err := f.begin("RetireClientSecret", token, clientID, secretID, overlap)
+	if err != nil {
+		return nil, err
+	}
+	if overlap < 0 {
+		return nil, fmt.Errorf("overlap cannot be negative, got %v", overlap)
+	}
+	c, ok := f.clientSecrets[secretID]
+	if !ok || c.ClientId != clientID || !c.Active(f.Now()) {
+		return nil, fmt.Errorf("client secret %q: %w", secretID, ErrNotFound)
+	}
+	expiry := f.Now().Add(overlap)
+	if c.ExpiresAt == nil || expiry.Before(*c.ExpiresAt) {
+		c.ExpiresAt = &expiry
+	}
+	if c.RetiredAt == nil {
+		c.RetiredAt, c.RetiredBy = f.now(), user.Id
+	}
+	secret := *c
+	secret.ProjectIds = slices.Clone(c.ProjectIds)
+	return &secret, nil
+}
+
+// GetClaimsConfig returns the claims configuration of a client added with
+// AddClientConfig, empty if it was never updated.
+func (f *FakeService) GetClaimsConfig(ctx context.Context, clientID string, token string) (*golang.ClaimsConfig, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("GetClaimsConfig", token, clientID); err != nil {
+		return nil, err
+	}
+	if _, ok := f.clientConfigs[clientID]; !ok {
+		return nil, fmt.Errorf("client %q: %w", clientID, ErrNotFound)
+	}
+	config := golang.ClaimsConfig{ClientId: clientID}
+	if c, ok := f.claimsConfigs[clientID]; ok {
+		config = *c
+		config.Claims = slices.Clone(c.Claims)
+	}
+	return &config, nil
+}
+
+// UpdateClaimsConfig validates and replaces the claims configuration of a
+// client added with AddClientConfig.
+func (f *FakeService) UpdateClaimsConfig(ctx context.Context, config *golang.ClaimsConfig, token string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user, err := f.begin("UpdateClaimsConfig", token, config)
+	if err != nil {
+		return err
+	}
+	if config == nil {
+		return fmt.Errorf("claims config cannot be nil")
+	}
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	if _, ok := f.clientConfigs[config.ClientId]; !ok {
+		return fmt.Errorf("client %q: %w", config.ClientId, ErrNotFound)
+	}
+	c := *config
+	c.Claims = slices.Clone(config.Claims)
+	c.UpdatedAt, c.UpdatedBy = f.now(), user.Id
+	f.claimsConfigs[c.ClientId] = &c
+	*config = c
+	return nil
+}
+
+// CreateResource stores the resource under a new ID.
+func (f *FakeService) CreateResource(ctx context.Context, resource *golang.Resource, token string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user, err := f.begin("CreateResource", token, resource)
+	if err != nil {
+		return err
+	}
+	if resource == nil {
+		return fmt.Errorf("resource cannot be nil")
+	}
+	return f.createResource(resource, user)
+}
+
+func (f *FakeService) createResource(resource *golang.Resource, user *golang.User) error {
+	if resource.ExternalId != "" && f.resourceByExternalID(resource.ExternalId) != nil {
+		return fmt.Errorf("resource with external ID %q: %w", resource.ExternalId, ErrConflict)
+	}
+	r := *resource
+	r.ID, r.CreatedAt, r.CreatedBy = f.newID(), f.now(), user.Id
+	f.resources[r.ID] = &r
+	*resource = r
+	return nil
+}
+
+func (f *FakeService) resourceByExternalID(externalID string) *golang.Resource {
+	for _, r := range f.resources {
+		if r.ExternalId == externalID {
+			return r
+		}
+	}
+	return nil
+}
+
+// GetResourceByExternalID returns the resource with the given external ID.
+func (f *FakeService) GetResourceByExternalID(ctx context.Context, externalID string, token string) (*golang.Resource, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("GetResourceByExternalID", token, externalID); err != nil {
+		return nil, err
+	}
+	r := f.resourceByExternalID(externalID)
+	if r == nil {
+		return nil, fmt.Errorf("resource with external ID %q: %w", externalID, ErrNotFound)
+	}
+	resource := *r
+	return &resource, nil
+}
+
+// EnsureResource stores the resource under a new ID unless one with the same
+// external ID exists, which is returned instead.
+func (f *FakeService) EnsureResource(ctx context.Context, resource *golang.Resource, token string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user, err := f.begin("EnsureResource", token, resource)
+	if err != nil {
+		return false, err
+	}
+	if resource == nil {
+		return false, fmt.Errorf("resource cannot be nil")
+	}
+	if resource.ExternalId == "" {
+		return false, fmt.Errorf("resource external ID cannot be empty")
+	}
+	if r := f.resourceByExternalID(resource.ExternalId); r != nil {
+		*resource = *r
+		return false, nil
+	}
+	return true, f.createResource(resource, user)
+}
+
+// GetResource returns the resource with the given ID.
+func (f *FakeService) GetResource(ctx context.Context, id string, token string) (*golang.Resource, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("GetResource", token, id); err != nil {
+		return nil, err
+	}
+	r, ok := f.resources[id]
+	if !ok {
+		return nil, fmt.Errorf("resource %q: %w", id, ErrNotFound)
+	}
+	resource := *r
+	return &resource, nil
+}
+
+// UpdateResource replaces the resource with the same ID, keeping its external ID.
+func (f *FakeService) UpdateResource(ctx context.Context, resource *golang.Resource, token string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user, err := f.begin("UpdateResource", token, resource)
+	if err != nil {
+		return err
+	}
+	if resource == nil {
+		return fmt.Errorf("resource cannot be nil")
+	}
+	existing, ok := f.resources[resource.ID]
+	if !ok {
+		return fmt.Errorf("resource %q: %w", resource.ID, ErrNotFound)
+	}
+	r := *resource
+	r.ExternalId, r.CreatedAt, r.CreatedBy = existing.ExternalId, existing.CreatedAt, existing.CreatedBy
+	r.UpdatedAt, r.UpdatedBy = f.now(), user.Id
+	f.resources[r.ID] = &r
+	*resource = r
+	return nil
+}
+
+// ListResources returns the resources matching the query ordered by ID.
+func (f *FakeService) ListResources(ctx context.Context, query golang.ListResourcesQuery, token string) (*golang.ResourceList, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("ListResources", token, query); err != nil {
+		return nil, err
+	}
+	matches := []golang.Resource{}
+	for _, id := range sortedKeys(f.resources) {
+		r := f.resources[id]
+		if id <= query.After {
+			continue
+		}
+		if !strings.Contains(r.Name, query.Name) || !strings.Contains(r.Key, query.Key) {
+			continue
+		}
+		if query.Enabled != nil && r.Enabled != *query.Enabled {
+			continue
+		}
+		matches = append(matches, *r)
+	}
+	page, skip := paginate(matches, query.Page, query.Limit)
+	return &golang.ResourceList{Resources: page, Pagination: pagination(len(matches), skip, query.Page, query.Limit)}, nil
+}
+
+// DeleteResource removes the resource with the given ID.
+func (f *FakeService) DeleteResource(ctx context.Context, resourceID string, token string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("DeleteResource", token, resourceID); err != nil {
+		return err
+	}
+	if _, ok := f.resources[resourceID]; !ok {
+		return fmt.Errorf("resource %q: %w", resourceID, ErrNotFound)
+	}
+	delete(f.resources, resourceID)
+	return nil
+}
+
+// ScanResources iterates over the resources in ID order, a page at a time
+// through ListResources, like golang.Service.ScanResources.
+func (f *FakeService) ScanResources(ctx context.Context, opts golang.ScanOptions, token string) iter.Seq2[golang.Resource, error] {
+	return scan(ctx, opts, func(r golang.Resource) string { return r.ID }, func(after string, limit int) ([]golang.Resource, error) {
+		list, err := f.ListResources(ctx, golang.ListResourcesQuery{After: after, Limit: limit}, token)
+		if err != nil {
+			return nil, err
+		}
+		return list.Resources, nil
+	})
+}
+
+// SyncResources makes the fake's resources match the desired ones by Key,
+// like golang.Service.SyncResources. Calls are not concurrent and
+// SyncOptions.Concurrency is ignored.
+func (f *FakeService) SyncResources(ctx context.Context, resources []golang.Resource, opts golang.SyncOptions, token string) (*golang.SyncReport, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user, err := f.begin("SyncResources", token, resources, opts)
+	if err != nil {
+		return nil, err
+	}
+	existing := map[string]*golang.Resource{}
+	for _, r := range f.resources {
+		existing[r.Key] = r
+	}
+
+	report := &golang.SyncReport{}
+	desired := map[string]bool{}
+	for _, r := range resources {
+		item := golang.SyncItem{Key: r.Key}
+		current, ok := existing[r.Key]
+		switch {
+		case r.Key == "":
+			item.Action, item.Err = golang.SyncFailed, fmt.Errorf("resource key cannot be empty")
+		case desired[r.Key]:
+			item.Action, item.Err = golang.SyncFailed, fmt.Errorf("duplicate resource key")
+		case !ok:
+			r.ID, r.CreatedAt, r.CreatedBy = f.newID(), f.now(), user.Id
+			f.resources[r.ID] = &r
+			item.Action, item.Resource = golang.SyncCreated, &r
+		case current.Name == r.Name && current.Description == r.Description && current.Enabled == r.Enabled &&
+			current.ExternalId == r.ExternalId:
+			resource := *current
+			item.Action, item.Resource = golang.SyncSkipped, &resource
+		default:
+			r.ID, r.CreatedAt, r.CreatedBy = current.ID, current.CreatedAt, current.CreatedBy
+			r.UpdatedAt, r.UpdatedBy = f.now(), user.Id
+			f.resources[r.ID] = &r
+			item.Action, item.Resource = golang.SyncUpdated, &r
+		}
+		if item.Action != golang.SyncFailed {
+			desired[r.Key] = true
+		}
+		if item.Resource != nil {
+			resource := *item.Resource
+			item.Resource = &resource
+		}
+		report.Items = append(report.Items, item)
+	}
+
+	if opts.Delete {
+		for _, key := range sortedKeys(existing) {
+			if !desired[key] {
+				delete(f.resources, existing[key].ID)
+				report.Items = append(report.Items, golang.SyncItem{Key: key, Action: golang.SyncDeleted})
+			}
+		}
+	}
+	return report, nil
+}
+
+// CreateRole stores the role under a new ID.
+func (f *FakeService) CreateRole(ctx context.Context, role *golang.Role, token string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user, err := f.begin("CreateRole", token, role)
+	if err != nil {
+		return err
+	}
+	if role == nil {
+		return fmt.Errorf("role cannot be nil")
+	}
+	return f.createRole(role, user)
+}
+
+func (f *FakeService) createRole(role *golang.Role, user *golang.User) error {
+	if role.ExternalId != "" && f.roleByExternalID(role.ExternalId) != nil {
+		return fmt.Errorf("role with external ID %q: %w", role.ExternalId, ErrConflict)
+	}
+	r := *role
+	r.Id, r.CreatedAt, r.CreatedBy = f.newID(), f.now(), user.Id
+	r.Resources = maps.Clone(r.Resources)
+	f.roles[r.Id] = &r
+	*role = r
+	return nil
+}
+
+func (f *FakeService) roleByExternalID(externalID string) *golang.Role {
+	for _, r := range f.roles {
+		if r.ExternalId == externalID {
+			return r
+		}
+	}
+	return nil
+}
+
+// GetRoleByExternalID returns the role with the given external ID.
+func (f *FakeService) GetRoleByExternalID(ctx context.Context, externalID string, token string) (*golang.Role, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("GetRoleByExternalID", token, externalID); err != nil {
+		return nil, err
+	}
+	r := f.roleByExternalID(externalID)
+	if r == nil {
+		return nil, fmt.Errorf("role with external ID %q: %w", externalID, ErrNotFound)
+	}
+	role := *r
+	role.Resources = maps.Clone(r.Resources)
+	return &role, nil
+}
+
+// EnsureRole stores the role under a new ID unless one with the same
+// external ID exists, which is returned instead.
+func (f *FakeService) EnsureRole(ctx context.Context, role *golang.Role, token string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user, err := f.begin("EnsureRole", token, role)
+	if err != nil {
+		return false, err
+	}
+	if role == nil {
+		return false, fmt.Errorf("role cannot be nil")
+	}
+	if role.ExternalId == "" {
+		return false, fmt.Errorf("role external ID cannot be empty")
+	}
+	if r := f.roleByExternalID(role.ExternalId); r != nil {
+		*role = *r
+		role.Resources = maps.Clone(r.Resources)
+		return false, nil
+	}
+	return true, f.createRole(role, user)
+}
+
+// UpdateRole replaces the role with the same ID, keeping its external ID.
+func (f *FakeService) UpdateRole(ctx context.Context, role *golang.Role, token string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user, err := f.begin("UpdateRole", token, role)
+	if err != nil {
+		return err
+	}
+	if role == nil {
+		return fmt.Errorf("role cannot be nil")
+	}
+	existing, ok := f.roles[role.Id]
+	if !ok {
+		return fmt.Errorf("role %q: %w", role.Id, ErrNotFound)
+	}
+	r := *role
+	r.ExternalId, r.CreatedAt, r.CreatedBy = existing.ExternalId, existing.CreatedAt, existing.CreatedBy
+	r.UpdatedAt, r.UpdatedBy = f.now(), user.Id
+	r.Resources = maps.Clone(r.Resources)
+	f.roles[r.Id] = &r
+	*role = r
+	return nil
+}
+
+// GetRole returns the role with the given ID.
+func (f *FakeService) GetRole(ctx context.Context, id string, token string) (*golang.Role, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("GetRole", token, id); err != nil {
+		return nil, err
+	}
+	r, ok := f.roles[id]
+	if !ok {
+		return nil, fmt.Errorf("role %q: %w", id, ErrNotFound)
+	}
+	role := *r
+	role.Resources = maps.Clone(r.Resources)
+	return &role, nil
+}
+
+// ListRoles returns the roles matching the query ordered by ID.
+func (f *FakeService) ListRoles(ctx context.Context, query golang.ListRolesQuery, token string) (*golang.RoleList, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("ListRoles", token, query); err != nil {
+		return nil, err
+	}
+	matches := []golang.Role{}
+	for _, id := range sortedKeys(f.roles) {
+		r := *f.roles[id]
+		if strings.Contains(r.Name, query.Name) {
+			r.Resources = maps.Clone(r.Resources)
+			matches = append(matches, r)
+		}
+	}
+	page, skip := paginate(matches, query.Page, query.Limit)
+	return &golang.RoleList{Roles: page, Pagination: pagination(len(matches), skip, query.Page, query.Limit)}, nil
+}
+
+// AddResourceToRole adds the resource to the role and grants it to the users
+// holding the role.
+func (f *FakeService) AddResourceToRole(ctx context.Context, roleID string, resource golang.RoleResource, token string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("AddResourceToRole", token, roleID, resource); err != nil {
+		return err
+	}
+	role, ok := f.roles[roleID]
+	if !ok {
+		return fmt.Errorf("role %q: %w", roleID, ErrNotFound)
+	}
+	if role.Resources == nil {
+		role.Resources = map[string]golang.RoleResource{}
+	}
+	role.Resources[resource.Id] = resource
+	for _, u := range f.users {
+		if u.HasRole(roleID) {
+			r := grantResource(u, resource.Key, resource.Name)
+			r.RoleIds = maps.Clone(r.RoleIds)
+			if r.RoleIds == nil {
+				r.RoleIds = map[string]bool{}
+			}
+			r.RoleIds[roleID] = true
+			u.Resources[resource.Key] = r
+		}
+	}
+	return nil
+}
+
+// RemoveResourceFromRole removes the resource from the role and revokes it
+// from the users holding it only through the role.
+func (f *FakeService) RemoveResourceFromRole(ctx context.Context, roleID string, resourceID string, token string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("RemoveResourceFromRole", token, roleID, resourceID); err != nil {
+		return err
+	}
+	role, ok := f.roles[roleID]
+	if !ok {
+		return fmt.Errorf("role %q: %w", roleID, ErrNotFound)
+	}
+	resource, ok := role.Resources[resourceID]
+	if !ok {
+		return fmt.Errorf("resource %q of role %q: %w", resourceID, roleID, ErrNotFound)
+	}
+	delete(role.Resources, resourceID)
+	for _, u := range f.users {
+		r, ok := u.Resources[resource.Key]
+		if !ok || !r.RoleIds[roleID] {
+			continue
+		}
+		r.RoleIds = maps.Clone(r.RoleIds)
+		delete(r.RoleIds, roleID)
+		u.Resources[resource.Key] = r
+		if len(r.RoleIds) == 0 && len(r.PolicyIds) == 0 {
+			delete(u.Resources, resource.Key)
+		}
+	}
+	return nil
+}
+
+// ListPolicies returns the policies matching the query ordered by ID.
+func (f *FakeService) ListPolicies(ctx context.Context, query golang.ListPoliciesQuery, token string) (*golang.PolicyList, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("ListPolicies", token, query); err != nil {
+		return nil, err
+	}
+	matches := []golang.Policy{}
+	for _, id := range sortedKeys(f.policies) {
+		if p := f.policies[id]; strings.Contains(p.Name, query.Name) {
+			matches = append(matches, *p)
+		}
+	}
+	page, skip := paginate(matches, query.Page, query.Limit)
+	return &golang.PolicyList{Policies: page, Pagination: pagination(len(matches), skip, query.Page, query.Limit)}, nil
+}
+
+// AttachPolicyToUser attaches the policy to the user with the mapping.
+func (f *FakeService) AttachPolicyToUser(ctx context.Context, userID string, policyID string, mapping golang.UserPolicyMapping, token string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("AttachPolicyToUser", token, userID, policyID, mapping); err != nil {
+		return err
+	}
+	u, ok := f.users[userID]
+	if !ok {
+		return fmt.Errorf("user %q: %w", userID, ErrNotFound)
+	}
+	p, ok := f.policies[policyID]
+	if !ok {
+		return fmt.Errorf("policy %q: %w", policyID, ErrNotFound)
+	}
+	if u.Policies == nil {
+		u.Policies = map[string]golang.UserPolicy{}
+	}
+	u.Policies[policyID] = golang.UserPolicy{Name: p.Name, Mapping: mapping}
+	return nil
+}
+
+// CreateDelegation stores the delegation scope under a new ID.
+func (f *FakeService) CreateDelegation(ctx context.Context, scope *golang.DelegationScope, token string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user, err := f.begin("CreateDelegation", token, scope)
+	if err != nil {
+		return err
+	}
+	if scope == nil {
+		return fmt.Errorf("delegation scope cannot be nil")
+	}
+	d := *scope
+	d.Id, d.CreatedAt, d.CreatedBy = f.newID(), f.now(), user.Id
+	f.delegations[d.Id] = &d
+	*scope = d
+	return nil
+}
+
+// ListDelegations returns the delegation scopes of the user ordered by ID.
+func (f *FakeService) ListDelegations(ctx context.Context, userID string, token string) ([]golang.DelegationScope, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("ListDelegations", token, userID); err != nil {
+		return nil, err
+	}
+	scopes := []golang.DelegationScope{}
+	for _, id := range sortedKeys(f.delegations) {
+		if d := f.delegations[id]; d.UserId == userID {
+			scopes = append(scopes, *d)
+		}
+	}
+	return scopes, nil
+}
+
+// RevokeDelegation removes the delegation scope with the given ID.
+func (f *FakeService) RevokeDelegation(ctx context.Context, id string, token string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("RevokeDelegation", token, id); err != nil {
+		return err
+	}
+	if _, ok := f.delegations[id]; !ok {
+		return fmt.Errorf("delegation %q: %w", id, ErrNotFound)
+	}
+	delete(f.delegations, id)
+	return nil
+}
+
+// GrantTemporaryAccess records the grant and grants the resource to the user.
+// The resource is not revoked automatically at expiry, check
+// TemporaryGrant.Active against the fake's clock instead.
+func (f *FakeService) GrantTemporaryAccess(ctx context.Context, userID string, resourceKey string, duration time.Duration, reason string, token string) (*golang.TemporaryGrant, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user, err := f.begin("GrantTemporaryAccess", token, userID, resourceKey, duration, reason)
+	if err != nil {
+		return nil, err
+	}
+	if duration < time.Second {
+		return nil, fmt.Errorf("grant duration must be at least one second, got %v", duration)
+	}
+	if reason == "" {
+		return nil, fmt.Errorf("grant reason cannot be empty")
+	}
+	u, ok := f.users[userID]
+	if !ok {
+		return nil, fmt.Errorf("user %q: %w", userID, ErrNotFound)
+	}
+	u.Resources[resourceKey] = grantResource(u, resourceKey, resourceKey)
+
+	now := f.now()
+	expiry := now.Add(duration)
+	g := golang.TemporaryGrant{
+		Id:          f.newID(),
+		UserId:      userID,
+		ResourceKey: resourceKey,
+		Reason:      reason,
+		ExpiresAt:   &expiry,
+		CreatedAt:   now,
+		CreatedBy:   user.Id,
+	}
+	f.grants[g.Id] = &g
+	return &g, nil
+}
+
+// ListTemporaryGrants returns the temporary grants of the user ordered by ID.
+func (f *FakeService) ListTemporaryGrants(ctx context.Context, userID string, token string) ([]golang.TemporaryGrant, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("ListTemporaryGrants", token, userID); err != nil {
+		return nil, err
+	}
+	grants := []golang.TemporaryGrant{}
+	for _, id := range sortedKeys(f.grants) {
+		if g := f.grants[id]; g.UserId == userID {
+			grants = append(grants, *g)
+		}
+	}
+	return grants, nil
+}
+
+// RevokeTemporaryGrant marks the grant revoked and revokes the resource from
+// the user unless a role or policy also grants it.
+func (f *FakeService) RevokeTemporaryGrant(ctx context.Context, id string, token string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("RevokeTemporaryGrant", token, id); err != nil {
+		return err
+	}
+	g, ok := f.grants[id]
+	if !ok {
+		return fmt.Errorf("temporary grant %q: %w", id, ErrNotFound)
+	}
+	g.RevokedAt = f.now()
+	if u, ok := f.users[g.UserId]; ok {
+		revokeResource(u, g.ResourceKey)
+	}
+	return nil
+}
+
+// RequestAccess files a pending access request for the token's user.
+func (f *FakeService) RequestAccess(ctx context.Context, resourceKey string, reason string, token string) (*golang.AccessRequest, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user, err := f.begin("RequestAccess", token, resourceKey, reason)
+	if err != nil {
+		return nil, err
+	}
+	if resourceKey == "" {
+		return nil, fmt.Errorf("resource key cannot be empty")
+	}
+	r := golang.AccessRequest{
+		Id:          f.newID(),
+		ProjectId:   user.ProjectId,
+		UserId:      user.Id,
+		ResourceKey: resourceKey,
+		Reason:      reason,
+		Status:      golang.AccessRequestPending,
+		CreatedAt:   f.now(),
+	}
+	f.accessRequests[r.Id] = &r
+	request := r
+	return &request, nil
+}
+
+// ListAccessRequests returns the access requests matching the query ordered by ID.
+func (f *FakeService) ListAccessRequests(ctx context.Context, query golang.ListAccessRequestsQuery, token string) (*golang.AccessRequestList, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("ListAccessRequests", token, query); err != nil {
+		return nil, err
+	}
+	matches := []golang.AccessRequest{}
+	for _, id := range sortedKeys(f.accessRequests) {
+		r := f.accessRequests[id]
+		if (query.Status == "" || r.Status == query.Status) && (query.UserId == "" || r.UserId == query.UserId) {
+			matches = append(matches, *r)
+		}
+	}
+	page, skip := paginate(matches, query.Page, query.Limit)
+	return &golang.AccessRequestList{Requests: page, Pagination: pagination(len(matches), skip, query.Page, query.Limit)}, nil
+}
+
+// ApproveAccessRequest approves the pending request and grants the resource
+// to the requester.
+func (f *FakeService) ApproveAccessRequest(ctx context.Context, id string, comment string, token string) (*golang.AccessRequest, error) {
+	return f.reviewAccessRequest("ApproveAccessRequest", id, golang.AccessRequestApproved, comment, token)
+}
+
+// DenyAccessRequest denies the pending request.
+func (f *FakeService) DenyAccessRequest(ctx context.Context, id string, comment string, token string) (*golang.AccessRequest, error) {
+	return f.reviewAccessRequest("DenyAccessRequest", id, golang.AccessRequestDenied, comment, token)
+}
+
+func (f *FakeService) reviewAccessRequest(method string, id string, status golang.AccessRequestStatus, comment string, token string) (*golang.AccessRequest, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user, err := f.begin(method, token, id, comment)
+	if err != nil {
+		return nil, err
+	}
+	r, ok := f.accessRequests[id]
+	if !ok {
+		return nil, fmt.Errorf("access request %q: %w", id, ErrNotFound)
+	}
+	if r.Status != golang.AccessRequestPending {
+		return nil, fmt.Errorf("access request %q is already %s", id, r.Status)
+	}
+	r.Status, r.ReviewedBy, r.ReviewComment, r.ReviewedAt = status, user.Id, comment, f.now()
+	if u, ok := f.users[r.UserId]; ok && status == golang.AccessRequestApproved {
+		u.Resources[r.ResourceKey] = grantResource(u, r.ResourceKey, r.ResourceKey)
+	}
+	request := *r
+	return &request, nil
+}
+
+// CreateReviewCampaign stores the campaign under a new ID. Items to review
+// are not derived from the campaign, add them with AddReviewItem.
+func (f *FakeService) CreateReviewCampaign(ctx context.Context, campaign *golang.ReviewCampaign, token string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user, err := f.begin("CreateReviewCampaign", token, campaign)
+	if err != nil {
+		return err
+	}
+	if campaign == nil {
+		return fmt.Errorf("review campaign cannot be nil")
+	}
+	c := *campaign
+	c.Id, c.CreatedAt, c.CreatedBy = f.newID(), f.now(), user.Id
+	f.reviewCampaigns[c.Id] = &c
+	*campaign = c
+	return nil
+}
+
+// ListPendingReviewItems returns the undecided items of the campaign ordered by ID.
+func (f *FakeService) ListPendingReviewItems(ctx context.Context, campaignID string, token string) ([]golang.ReviewItem, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("ListPendingReviewItems", token, campaignID); err != nil {
+		return nil, err
+	}
+	items := []golang.ReviewItem{}
+	for _, id := range sortedKeys(f.reviewItems) {
+		if item := f.reviewItems[id]; item.CampaignId == campaignID && item.Decision == golang.ReviewPending {
+			items = append(items, *item)
+		}
+	}
+	return items, nil
+}
+
+// RecordReviewDecision records the decision on the item. Revoking removes the
+// resource or role under review from the user.
+func (f *FakeService) RecordReviewDecision(ctx context.Context, itemID string, decision golang.ReviewDecision, comment string, token string) (*golang.ReviewItem, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("RecordReviewDecision", token, itemID, decision, comment); err != nil {
+		return nil, err
+	}
+	if decision != golang.ReviewCertify && decision != golang.ReviewRevoke {
+		return nil, fmt.Errorf("invalid review decision %q", decision)
+	}
+	item, ok := f.reviewItems[itemID]
+	if !ok {
+		return nil, fmt.Errorf("review item %q: %w", itemID, ErrNotFound)
+	}
+	item.Decision, item.Comment, item.DecidedAt = decision, comment, f.now()
+	if u, ok := f.users[item.UserId]; ok && decision == golang.ReviewRevoke {
+		if item.ResourceKey != "" {
+			delete(u.Resources, item.ResourceKey)
+		}
+		if item.RoleId != "" {
+			delete(u.Roles, item.RoleId)
+		}
+	}
+	result := *item
+	return &result, nil
+}
+
+// RequestSupportAccess creates a pending support session for the token's
+// user as the agent.
+func (f *FakeService) RequestSupportAccess(ctx context.Context, userID string, reason string, duration time.Duration, token string) (*golang.SupportSession, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	agent, err := f.begin("RequestSupportAccess", token, userID, reason, duration)
+	if err != nil {
+		return nil, err
+	}
+	if duration < time.Second {
+		return nil, fmt.Errorf("support session duration must be at least one second, got %v", duration)
+	}
+	if reason == "" {
+		return nil, fmt.Errorf("support session reason cannot be empty")
+	}
+	u, ok := f.users[userID]
+	if !ok {
+		return nil, fmt.Errorf("user %q: %w", userID, ErrNotFound)
+	}
+	session := golang.SupportSession{
+		Id:              f.newID(),
+		ProjectId:       u.ProjectId,
+		UserId:          userID,
+		AgentId:         agent.Id,
+		Reason:          reason,
+		DurationSeconds: int64(duration / time.Second),
+		Status:          golang.SupportSessionPending,
+		CreatedAt:       f.now(),
+	}
+	f.supportSessions[session.Id] = &session
+	s := session
+	return &s, nil
+}
+
+// ListSupportSessions returns the user's support sessions ordered by ID.
+func (f *FakeService) ListSupportSessions(ctx context.Context, userID string, token string) ([]golang.SupportSession, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.begin("ListSupportSessions", token, userID); err != nil {
+		return nil, err
+	}
+	sessions := []golang.SupportSession{}
+	for _, id := range sortedKeys(f.supportSessions) {
+		if s := f.supportSessions[id]; s.UserId == userID {
+			sessions = append(sessions, *s)
+		}
+	}
+	return sessions, nil
+}
+
+// ApproveSupportAccess approves the pending session if the token belongs to
+// its user. Access expires after the requested duration.
+func (f *FakeService) ApproveSupportAccess(ctx context.Context, id string, token string) (*golang.SupportSession, error) {
+	return f.decideSupportAccess("ApproveSupportAccess", id, golang.SupportSessionApproved, token)
+}
+
+// DenySupportAccess denies the pending session if the token belongs to its
+// user.
+func (f *FakeService) DenySupportAccess(ctx context.Context, id string, token string) (*golang.SupportSession, error) {
+	return f.decideSupportAccess("DenySupportAccess", id, golang.SupportSessionDenied, token)
+}
+
+func (f *FakeService) decideSupportAccess(method string, id string, status golang.SupportSessionStatus, token string) (*golang.SupportSession, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user, err := f.begin(method, token, id)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := f.supportSessions[id]
+	if !ok {
+		return nil, fmt.Errorf("support session %q: %w", id, ErrNotFound)
+	}
+	if s.UserId != user.Id || f.tokens[token].impersonatedBy != "" {
+		return nil, fmt.Errorf("support session %q of another user: %w", id, ErrForbidden)
+	}
+	if s.Status != golang.SupportSessionPending {
+		return nil, fmt.Errorf("support session %q is already %s", id, s.Status)
+	}
+	s.Status, s.DecidedAt = status, f.now()
+	if status == golang.SupportSessionApproved {
+		expires := s.DecidedAt.Add(time.Duration(s.DurationSeconds) * time.Second)
+		s.ExpiresAt = &expires
+	}
+	session := *s
+	return &session, nil
+}
+
+// StartSupportSession adds a token authenticating as the session's user,
+// attributed to the agent, until the session expires.
+func (f *FakeService) StartSupportSession(ctx context.Context, id string, token string) (*golang.SupportToken, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	agent, err := f.begin("StartSupportSession", token, id)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := f.supportSessions[id]
+	if !ok {
+		return nil, fmt.Errorf("support session %q: %w", id, ErrNotFound)
+	}
+	if s.AgentId != agent.Id || !s.Active(f.Now()) {
+		return nil, fmt.Errorf("support session %q is not active for the agent: %w", id, ErrForbidden)
+	}
+	supportToken := "support-" + f.newID()
+	f.tokens[supportToken] = tokenEntry{userID: s.UserId, impersonatedBy: agent.Id, issuedAt: f.Now(), expiry: *s.ExpiresAt}
+	f.supportTokens[id] = supportToken
+	return &golang.SupportToken{AccessToken: supportToken, SessionId: id, ExpiresAt: s.ExpiresAt}, nil
+}
+
+// EndSupportSession ends the session if the token belongs to its user or
+// agent, and removes its token.
+func (f *FakeService) EndSupportSession(ctx context.Context, id string, token string) (*golang.SupportSession, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user, err := f.begin("EndSupportSession", token, id)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := f.supportSessions[id]
+	if !ok {
+		return nil, fmt.Errorf("support session %q: %w", id, ErrNotFound)
+	}
+	if user.Id != s.UserId && user.Id != s.AgentId {
+		return nil, fmt.Errorf("support session %q of another user: %w", id, ErrForbidden)
+	}
+	s.Status, s.EndedAt, s.EndedBy = golang.SupportSessionEnded, f.now(), user.Id
+	delete(f.tokens, f.supportTokens[id])
+	delete(f.supportTokens, id)
+	session := *s
+	return &session, nil
+}
+
+// cloneUser copies u deeply enough that later changes to the fake's state do
+// not show through.
+func cloneUser(u *golang.User) *golang.User {
+	c := *u
+	c.Roles = maps.Clone(u.Roles)
+	c.Resources = maps.Clone(u.Resources)
+	c.Policies = maps.Clone(u.Policies)
+	c.Metadata = maps.Clone(u.Metadata)
+	return &c
+}
+
+// cloneMetadata copies metadata, never returning nil.
+func cloneMetadata(metadata map[string]any) map[string]any {
+	c := maps.Clone(metadata)
+	if c == nil {
+		c = map[string]any{}
+	}
+	return c
+}
+
+// matchesMetadata reports whether metadata holds every wanted value.
+func matchesMetadata(metadata map[string]any, want map[string]string) bool {
+	for k, v := range want {
+		got, ok := metadata[k]
+		if !ok || fmt.Sprint(got) != v {
+			return false
+		}
+	}
+	return true
+}
+
+// grantResource returns the user's grant of the resource key, a new one if
+// the user did not hold it yet, making sure u.Resources can be written to.
+func grantResource(u *golang.User, key, name string) golang.UserResource {
+	if u.Resources == nil {
+		u.Resources = map[string]golang.UserResource{}
+	}
+	if r, ok := u.Resources[key]; ok {
+		return r
+	}
+	return golang.UserResource{Key: key, Name: name}
+}
+
+// revokeResource removes the resource key from the user unless a role or a
+// policy still grants it.
+func revokeResource(u *golang.User, key string) {
+	if r, ok := u.Resources[key]; ok && len(r.RoleIds) == 0 && len(r.PolicyIds) == 0 {
+		delete(u.Resources, key)
+	}
+}
@@ -0,0 +1,62 @@
+package golang
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGrantTemporaryAccess(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/grant/v1/" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var payload grantRequest
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("expected valid payload, got %v", err)
+		}
+		if payload.UserId != "user-id" || payload.ResourceKey != "db:write" || payload.DurationSeconds != 1800 || payload.Reason != "INC-42" {
+			t.Fatalf("unexpected payload: %+v", payload)
+		}
+		if r.Header.Get("Authorization") == "Bearer valid-token" {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"success":true,"data":{"id":"grant-id","user_id":"user-id","resource_key":"db:write","reason":"INC-42","expires_at":"2030-01-01T00:00:00Z"}}`))
+		} else {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"success":false,"message":"Forbidden"}`))
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret")
+
+	t.Run("Valid Token", func(t *testing.T) {
+		grant, err := service.GrantTemporaryAccess(context.Background(), "user-id", "db:write", 30*time.Minute, "INC-42", "valid-token")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if grant.Id != "grant-id" || !grant.Active(time.Date(2029, 1, 1, 0, 0, 0, 0, time.UTC)) {
+			t.Fatalf("unexpected grant: %+v", grant)
+		}
+		if grant.Active(*grant.ExpiresAt) {
+			t.Fatal("expected grant to be inactive at expiry")
+		}
+	})
+
+	t.Run("Invalid Token", func(t *testing.T) {
+		if _, err := service.GrantTemporaryAccess(context.Background(), "user-id", "db:write", 30*time.Minute, "INC-42", "invalid-token"); err == nil {
+			t.Fatal("expected an error, got none")
+		}
+	})
+
+	t.Run("Missing Reason", func(t *testing.T) {
+		if _, err := service.GrantTemporaryAccess(context.Background(), "user-id", "db:write", 30*time.Minute, "", "valid-token"); err == nil {
+			t.Fatal("expected an error, got none")
+		}
+	})
+}
@@ -0,0 +1,108 @@
+package golang
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"sync/atomic"
+)
+
+// HTTP headers carrying the IDs the service attaches to calls.
+const (
+	RequestIDHeader      = "X-Request-Id"
+	IdempotencyKeyHeader = "Idempotency-Key"
+)
+
+// IDKind is what a generated ID is used for.
+type IDKind string
+
+const (
+	IDRequest        IDKind = "request"         // Identifies a call across its attempts, sent in RequestIDHeader
+	IDIdempotencyKey IDKind = "idempotency-key" // Lets the server deduplicate retried writes, sent in IdempotencyKeyHeader
+	IDNonce          IDKind = "nonce"           // Makes a signed proof or token unique
+)
+
+// IDGenerator generates the IDs the service attaches to calls. Replace the
+// default random UUIDs with WithIDGenerator, e.g. for deterministic IDs in
+// record/replay tests. Implementations must be safe for concurrent use.
+type IDGenerator interface {
+	NewID(kind IDKind) string
+}
+
+// IDGeneratorFunc adapts a function to the IDGenerator interface.
+type IDGeneratorFunc func(kind IDKind) string
+
+// NewID calls f(kind).
+func (f IDGeneratorFunc) NewID(kind IDKind) string {
+	return f(kind)
+}
+
+// RandomIDs generates random version 4 UUIDs. It is the default IDGenerator.
+var RandomIDs IDGenerator = IDGeneratorFunc(func(IDKind) string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+})
+
+// SequentialIDs returns an IDGenerator counting up from 1 for each kind, with
+// IDs of the form "<prefix>-<kind>-<n>".
+func SequentialIDs(prefix string) IDGenerator {
+	var counters [3]atomic.Uint64
+	return IDGeneratorFunc(func(kind IDKind) string {
+		i := 0
+		switch kind {
+		case IDIdempotencyKey:
+			i = 1
+		case IDNonce:
+			i = 2
+		}
+		return fmt.Sprintf("%s-%s-%d", prefix, kind, counters[i].Add(1))
+	})
+}
+
+type idempotencyKeyKey struct{}
+
+// WithIdempotencyKey returns a copy of ctx sending key as the idempotency key
+// of calls made with it, instead of a generated one, so the server applies a
+// write at most once however often it is sent. Writes with a key set this
+// way are retried automatically by WithRetry. Use one key per logical write.
+// Calls making several writes, such as SyncResources, send each of them with
+// a key derived from key, and their reads and token exchanges without it.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyKey{}, key)
+}
+
+// scopeIdempotencyKey returns ctx for a call made on behalf of a call with
+// several sub-calls: an idempotency key set on ctx is replaced by one derived
+// from it and name, so every sub-call is deduplicated on its own, also when
+// the outer call is repeated with the same key. An empty name removes the key.
+func scopeIdempotencyKey(ctx context.Context, name string) context.Context {
+	key := idempotencyKey(ctx)
+	if key == "" {
+		return ctx
+	}
+	if name != "" {
+		key += "/" + name
+	} else {
+		key = ""
+	}
+	return context.WithValue(ctx, idempotencyKeyKey{}, key)
+}
+
+// idempotencyKey returns the key set on ctx with WithIdempotencyKey.
+func idempotencyKey(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyKey{}).(string)
+	return key
+}
+
+// WithIDGenerator makes the service generate request IDs, idempotency keys
+// and nonces with g instead of RandomIDs.
+func WithIDGenerator(g IDGenerator) Option {
+	return func(s *serviceImpl) {
+		if g != nil {
+			s.ids = g
+		}
+	}
+}
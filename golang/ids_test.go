@@ -0,0 +1,111 @@
+package golang
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestIDGenerator(t *testing.T) {
+	type seen struct{ requestID, idempotencyKey string }
+	var requests []seen
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, seen{r.Header.Get(RequestIDHeader), r.Header.Get(IdempotencyKeyHeader)})
+		if r.Method == http.MethodPost && len(requests) == 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"success":false,"message":"Unavailable"}`))
+			return
+		}
+		w.Write([]byte(`{"success":true,"data":{"id":"resource-id"}}`))
+	}))
+	defer ts.Close()
+
+	service := newService(ts.URL, "client-id", "secret",
+		WithIDGenerator(SequentialIDs("test")),
+		WithRetry(1, time.Millisecond),
+		WithRetryNonIdempotent(),
+	)
+	if _, err := service.GetResource(context.Background(), "resource-id", "valid-token"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := service.CreateResource(context.Background(), &Resource{Name: "Test"}, "valid-token"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := []seen{
+		{"test-request-1", ""},
+		{"test-request-2", "test-idempotency-key-1"},
+		{"test-request-2", "test-idempotency-key-1"}, // the retry keeps the IDs
+	}
+	if len(requests) != len(want) {
+		t.Fatalf("expected %d requests, got %+v", len(want), requests)
+	}
+	for i := range want {
+		if requests[i] != want[i] {
+			t.Fatalf("request %d: expected %+v, got %+v", i, want[i], requests[i])
+		}
+	}
+}
+
+func TestAPIErrorRequestID(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"success":false,"message":"Not found"}`))
+	}))
+	defer ts.Close()
+
+	service := newService(ts.URL, "client-id", "secret")
+	_, err := service.GetRole(context.Background(), "role-id", "valid-token")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %v", err)
+	}
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	if !uuid.MatchString(apiErr.RequestID) {
+		t.Fatalf("expected a random UUID request ID, got %q", apiErr.RequestID)
+	}
+}
+
+func TestIdempotencyKeyScope(t *testing.T) {
+	var mu sync.Mutex
+	keys := map[string]string{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload Resource
+		json.NewDecoder(r.Body).Decode(&payload)
+		mu.Lock()
+		keys[r.Method+" "+payload.Key+strings.TrimPrefix(r.URL.Path, "/resource/v1/")] = r.Header.Get(IdempotencyKeyHeader)
+		mu.Unlock()
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"success":true,"data":{"resources":[{"id":"old-id","key":"old"}],"total":1}}`))
+		default:
+			w.Write([]byte(`{"success":true,"data":{"id":"new-id"}}`))
+		}
+	}))
+	defer ts.Close()
+
+	ctx := WithIdempotencyKey(context.Background(), "nightly-1")
+	desired := []Resource{{Key: "a"}, {Key: "b"}}
+	report, err := NewService(ts.URL, "client-id", "secret").SyncResources(ctx, desired, SyncOptions{Delete: true}, "token")
+	if err != nil || report.Err() != nil {
+		t.Fatalf("expected no error, got %v, %v", err, report.Err())
+	}
+
+	want := map[string]string{
+		"GET search":    "",
+		"POST a":        "nightly-1/create:a",
+		"POST b":        "nightly-1/create:b",
+		"DELETE old-id": "nightly-1/delete:old",
+	}
+	if !maps.Equal(keys, want) {
+		t.Fatalf("expected a key per write derived from the caller's, got %v", keys)
+	}
+}
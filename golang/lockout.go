@@ -0,0 +1,63 @@
+package golang
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// LockoutStatus explains whether a user is locked out after too many failed
+// login attempts.
+type LockoutStatus struct {
+	UserId         string     `json:"user_id"`                // User the status is about
+	Locked         bool       `json:"locked"`                 // Whether logins are currently refused
+	Reason         string     `json:"reason,omitempty"`       // Why the user was locked out
+	FailedAttempts int        `json:"failed_attempts"`        // Consecutive failed logins
+	LastFailureAt  *time.Time `json:"last_failure_at"`        // When the last login failed
+	LastFailureIP  string     `json:"last_failure_ip"`        // IP address of the last failed login
+	LockedAt       *time.Time `json:"locked_at,omitempty"`    // When the lockout started
+	LockedUntil    *time.Time `json:"locked_until,omitempty"` // When the lockout ends, never if nil while locked
+}
+
+type LockoutStatusResponse struct {
+	Success bool           `json:"success"`
+	Message string         `json:"message"`
+	Data    *LockoutStatus `json:"data,omitempty"`
+}
+
+// GetLockoutStatus fetches the brute-force lockout status of the user with the provided ID.
+func (s *serviceImpl) GetLockoutStatus(ctx context.Context, userID string, token string) (*LockoutStatus, error) {
+	result := LockoutStatusResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodGet,
+		path:   "/user/v1/" + url.PathEscape(userID) + "/lockout",
+		token:  token,
+		action: "fetch lockout status",
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Data == nil {
+		return nil, fmt.Errorf("failed to fetch lockout status: empty response. Status: %s", resp.Status)
+	}
+
+	return result.Data, nil
+}
+
+// UnlockUser clears the lockout of the user with the provided ID and resets
+// the failed login count.
+func (s *serviceImpl) UnlockUser(ctx context.Context, userID string, token string) error {
+	result := LockoutStatusResponse{}
+	if _, err := s.call(ctx, apiRequest{
+		method: http.MethodDelete,
+		path:   "/user/v1/" + url.PathEscape(userID) + "/lockout",
+		token:  token,
+		action: "unlock user",
+	}, &result); err != nil {
+		return err
+	}
+
+	return nil
+}
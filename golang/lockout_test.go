@@ -0,0 +1,55 @@
+package golang
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLockout(t *testing.T) {
+	locked := true
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user/v1/user-id/lockout" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer valid-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"success":false,"message":"Invalid token"}`))
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			if locked {
+				w.Write([]byte(`{"success":true,"data":{"user_id":"user-id","locked":true,"reason":"too many failed logins","failed_attempts":5}}`))
+			} else {
+				w.Write([]byte(`{"success":true,"data":{"user_id":"user-id","locked":false}}`))
+			}
+		case http.MethodDelete:
+			locked = false
+			w.Write([]byte(`{"success":true,"data":{"user_id":"user-id","locked":false}}`))
+		default:
+			t.Fatalf("unexpected method %s", r.Method)
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret")
+	ctx := context.Background()
+
+	status, err := service.GetLockoutStatus(ctx, "user-id", "valid-token")
+	if err != nil || !status.Locked || status.FailedAttempts != 5 {
+		t.Fatalf("expected locked status, got %+v, %v", status, err)
+	}
+	if err := service.UnlockUser(ctx, "user-id", "valid-token"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if status, err := service.GetLockoutStatus(ctx, "user-id", "valid-token"); err != nil || status.Locked {
+		t.Fatalf("expected unlocked status, got %+v, %v", status, err)
+	}
+	if err := service.UnlockUser(ctx, "user-id", "invalid-token"); err == nil {
+		t.Fatal("expected an error, got none")
+	}
+}
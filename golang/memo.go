@@ -0,0 +1,79 @@
+package golang
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+type requestCacheKey struct{}
+
+// requestCache memoizes results for the lifetime of one inbound request.
+type requestCache struct {
+	mu      sync.Mutex
+	entries map[string]*memoEntry
+}
+
+// memoEntry is a result being computed or computed already. done is closed
+// once value and err are set.
+type memoEntry struct {
+	done  chan struct{}
+	value any
+	err   error
+}
+
+// WithRequestCache returns a copy of ctx carrying a cache that memoizes Me
+// and EvaluateWithContext results for calls made with it, so that repeated
+// checks while handling one inbound request cost one upstream call each.
+// Concurrent identical calls share a single upstream call and failed calls
+// are not cached. The cache lives as long as ctx, so it must only be
+// installed on request-scoped contexts; authmiddleware installs it on every
+// request. If ctx already carries a cache, ctx is returned unchanged. The
+// cache is only used by services with FeatureRequestCache enabled.
+func WithRequestCache(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(requestCacheKey{}).(*requestCache); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, requestCacheKey{}, &requestCache{entries: map[string]*memoEntry{}})
+}
+
+// memoize returns the result of fn cached under key in the request cache of
+// ctx, calling fn only if no result is cached. Without a request cache fn is
+// always called.
+func memoize[T any](ctx context.Context, key string, fn func() (T, error)) (T, error) {
+	cache, ok := ctx.Value(requestCacheKey{}).(*requestCache)
+	if !ok {
+		return fn()
+	}
+
+	cache.mu.Lock()
+	if e, ok := cache.entries[key]; ok {
+		cache.mu.Unlock()
+		select {
+		case <-e.done:
+			return e.value.(T), e.err
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+	e := &memoEntry{done: make(chan struct{})}
+	cache.entries[key] = e
+	cache.mu.Unlock()
+
+	value, err := fn()
+	e.value, e.err = value, err
+	if err != nil {
+		cache.mu.Lock()
+		delete(cache.entries, key)
+		cache.mu.Unlock()
+	}
+	close(e.done)
+	return value, err
+}
+
+// memoKey builds a cache key from the call name and its arguments.
+func memoKey(call string, args ...any) string {
+	data, _ := json.Marshal(args)
+	return call + "\x00" + string(data)
+}
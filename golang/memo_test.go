@@ -0,0 +1,88 @@
+package golang
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRequestCache(t *testing.T) {
+	var me, evaluations int32
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/me/v1/":
+			atomic.AddInt32(&me, 1)
+			if r.Header.Get("Authorization") != "Bearer valid-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"success":false,"message":"Invalid token"}`))
+				return
+			}
+			w.Write([]byte(`{"success":true,"data":{"id":"user-id"}}`))
+		case "/policy/v1/evaluate":
+			atomic.AddInt32(&evaluations, 1)
+			w.Write([]byte(`{"success":true,"data":{"allowed":true}}`))
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret", WithFeature(FeatureRequestCache, true))
+
+	t.Run("Without Cache", func(t *testing.T) {
+		atomic.StoreInt32(&me, 0)
+		for i := 0; i < 3; i++ {
+			service.Me(context.Background(), "valid-token")
+		}
+		if me != 3 {
+			t.Fatalf("expected 3 calls, got %d", me)
+		}
+	})
+
+	t.Run("With Cache", func(t *testing.T) {
+		atomic.StoreInt32(&me, 0)
+		ctx := WithRequestCache(context.Background())
+		if WithRequestCache(ctx) != ctx {
+			t.Fatal("expected an existing cache to be reused")
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if user, err := service.Me(ctx, "valid-token"); err != nil || user.Id != "user-id" {
+					t.Errorf("unexpected result %+v, %v", user, err)
+				}
+			}()
+		}
+		wg.Wait()
+		if me != 1 {
+			t.Fatalf("expected 1 call, got %d", me)
+		}
+
+		attrs := AccessAttributes{IP: "10.0.0.1"}
+		service.EvaluateWithContext(ctx, "billing:read", attrs, "valid-token")
+		service.EvaluateWithContext(ctx, "billing:read", attrs, "valid-token")
+		service.EvaluateWithContext(ctx, "billing:write", attrs, "valid-token")
+		if evaluations != 2 {
+			t.Fatalf("expected 2 evaluations, got %d", evaluations)
+		}
+	})
+
+	t.Run("Errors Are Not Cached", func(t *testing.T) {
+		atomic.StoreInt32(&me, 0)
+		ctx := WithRequestCache(context.Background())
+		for i := 0; i < 2; i++ {
+			if _, err := service.Me(ctx, "invalid-token"); err == nil {
+				t.Fatal("expected an error, got none")
+			}
+		}
+		if me != 2 {
+			t.Fatalf("expected 2 calls, got %d", me)
+		}
+	})
+}
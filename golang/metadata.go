@@ -0,0 +1,123 @@
+package golang
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// SearchUsersQuery filters and paginates SearchUsers.
+type SearchUsersQuery struct {
+	Name     string            // Only users whose name contains Name
+	Email    string            // Only users whose email contains Email
+	Metadata map[string]string // Only users whose metadata has all these values, compared as strings
+	After    string            // Only users whose ID sorts after After, for paginating by ID instead of Page
+	Page     int               // 1-based page number, the first page if zero
+	Limit    int               // Maximum number of users per page, the server default if zero
+}
+
+// UserList is a page of users returned by SearchUsers.
+type UserList struct {
+	Users []User `json:"users"` // Users on this page
+	Pagination
+}
+
+type MetadataResponse struct {
+	Success bool           `json:"success"`
+	Message string         `json:"message"`
+	Data    map[string]any `json:"data,omitempty"`
+}
+
+type UserListResponse struct {
+	Success bool      `json:"success"`
+	Message string    `json:"message"`
+	Data    *UserList `json:"data,omitempty"`
+}
+
+// GetUserMetadata fetches the custom attributes stored with the user.
+func (s *serviceImpl) GetUserMetadata(ctx context.Context, userID string, token string) (map[string]any, error) {
+	result := MetadataResponse{}
+	if _, err := s.call(ctx, apiRequest{
+		method: http.MethodGet,
+		path:   "/user/v1/" + url.PathEscape(userID) + "/metadata",
+		token:  token,
+		action: "fetch user metadata",
+	}, &result); err != nil {
+		return nil, err
+	}
+	if result.Data == nil {
+		return map[string]any{}, nil
+	}
+
+	return result.Data, nil
+}
+
+// UpdateUserMetadata merges metadata into the custom attributes of the user:
+// keys with a nil value are removed, others are set. It returns the stored
+// attributes after the update.
+func (s *serviceImpl) UpdateUserMetadata(ctx context.Context, userID string, metadata map[string]any, token string) (map[string]any, error) {
+	result := MetadataResponse{}
+	if _, err := s.call(ctx, apiRequest{
+		method: http.MethodPatch,
+		path:   "/user/v1/" + url.PathEscape(userID) + "/metadata",
+		body:   metadata,
+		token:  token,
+		action: "update user metadata",
+		retry:  retrySafe,
+	}, &result); err != nil {
+		return nil, err
+	}
+	if result.Data == nil {
+		return map[string]any{}, nil
+	}
+
+	return result.Data, nil
+}
+
+// SearchUsers searches the users matching the query, including their custom
+// attributes, one page at a time.
+func (s *serviceImpl) SearchUsers(ctx context.Context, query SearchUsersQuery, token string) (*UserList, error) {
+	result := UserListResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodGet,
+		path:   "/user/v1/search",
+		query:  query.values(),
+		token:  token,
+		action: "search users",
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Data == nil {
+		return nil, fmt.Errorf("failed to search users: empty response. Status: %s", resp.Status)
+	}
+	result.Data.complete(resp, query.Page, query.Limit, len(result.Data.Users))
+	capturePagination(ctx, result.Data.Pagination)
+
+	return result.Data, nil
+}
+
+func (q SearchUsersQuery) values() url.Values {
+	v := url.Values{}
+	if q.Name != "" {
+		v.Set("name", q.Name)
+	}
+	if q.Email != "" {
+		v.Set("email", q.Email)
+	}
+	for k, value := range q.Metadata {
+		v.Set("metadata."+k, value)
+	}
+	if q.After != "" {
+		v.Set("after", q.After)
+	}
+	if q.Page > 0 {
+		v.Set("page", strconv.Itoa(q.Page))
+	}
+	if q.Limit > 0 {
+		v.Set("limit", strconv.Itoa(q.Limit))
+	}
+	return v
+}
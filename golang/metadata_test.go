@@ -0,0 +1,71 @@
+package golang
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUserMetadata(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer valid-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"success":false,"message":"Invalid token"}`))
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/user/v1/user-id/metadata":
+			w.Write([]byte(`{"success":true,"data":{"locale":"en","plan":"pro"}}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/user/v1/user-id/metadata":
+			var payload map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil || payload["plan"] != "team" || payload["locale"] != nil {
+				t.Fatalf("unexpected metadata payload: %+v, %v", payload, err)
+			}
+			w.Write([]byte(`{"success":true,"data":{"plan":"team"}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/user/v1/search":
+			if r.URL.Query().Get("metadata.plan") != "team" || r.URL.Query().Get("limit") != "10" {
+				t.Fatalf("unexpected query %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"success":true,"data":{"users":[{"id":"user-id","metadata":{"plan":"team"}}],"total":1,"limit":10}}`))
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret")
+	ctx := context.Background()
+
+	t.Run("Valid Token", func(t *testing.T) {
+		metadata, err := service.GetUserMetadata(ctx, "user-id", "valid-token")
+		if err != nil || metadata["locale"] != "en" {
+			t.Fatalf("unexpected metadata %v, %v", metadata, err)
+		}
+
+		metadata, err = service.UpdateUserMetadata(ctx, "user-id", map[string]any{"plan": "team", "locale": nil}, "valid-token")
+		if err != nil || len(metadata) != 1 || metadata["plan"] != "team" {
+			t.Fatalf("unexpected metadata %v, %v", metadata, err)
+		}
+
+		list, err := service.SearchUsers(ctx, SearchUsersQuery{Metadata: map[string]string{"plan": "team"}, Limit: 10}, "valid-token")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(list.Users) != 1 || list.Users[0].Metadata["plan"] != "team" || list.Total != 1 || list.Page != 1 {
+			t.Fatalf("unexpected user list: %+v", list)
+		}
+	})
+
+	t.Run("Invalid Token", func(t *testing.T) {
+		if _, err := service.GetUserMetadata(ctx, "user-id", "invalid-token"); err == nil {
+			t.Fatal("expected an error, got none")
+		}
+		if _, err := service.UpdateUserMetadata(ctx, "user-id", map[string]any{"plan": "team"}, "invalid-token"); err == nil {
+			t.Fatal("expected an error, got none")
+		}
+	})
+}
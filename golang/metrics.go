@@ -0,0 +1,81 @@
+package golang
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// Metrics counts the calls made by the services it is passed to with
+// WithMetrics. The counters are safe for concurrent use and can be exported
+// to any metrics system, e.g. from a Prometheus collector.
+type Metrics struct {
+	inFlight  atomic.Int64
+	completed atomic.Int64
+	failed    atomic.Int64
+	canceled  atomic.Int64
+}
+
+// WithMetrics makes the service count its calls in m, which may be shared by
+// several services.
+func WithMetrics(m *Metrics) Option {
+	return func(s *serviceImpl) {
+		s.metrics = m
+	}
+}
+
+// InFlight returns the number of calls currently in progress, including
+// calls waiting for admission or a retry.
+func (m *Metrics) InFlight() int64 {
+	return m.inFlight.Load()
+}
+
+// Completed returns the number of calls that finished successfully.
+func (m *Metrics) Completed() int64 {
+	return m.completed.Load()
+}
+
+// Failed returns the number of calls that failed for other reasons than the
+// cancellation of their context.
+func (m *Metrics) Failed() int64 {
+	return m.failed.Load()
+}
+
+// Canceled returns the number of calls aborted in flight because their
+// context was canceled or its deadline passed.
+func (m *Metrics) Canceled() int64 {
+	return m.canceled.Load()
+}
+
+func (m *Metrics) begin() {
+	if m != nil {
+		m.inFlight.Add(1)
+	}
+}
+
+func (m *Metrics) end(err error) {
+	if m == nil {
+		return
+	}
+	m.inFlight.Add(-1)
+	switch {
+	case err == nil:
+		m.completed.Add(1)
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		m.canceled.Add(1)
+	default:
+		m.failed.Add(1)
+	}
+}
+
+// contextError makes err, returned by a call made with ctx, match the
+// context's error with errors.Is if the call failed because ctx is done, as
+// the HTTP transport reports cancellation with errors of its own.
+func contextError(ctx context.Context, err error) error {
+	ctxErr := ctx.Err()
+	if err == nil || ctxErr == nil || errors.Is(err, ctxErr) {
+		return err
+	}
+	return fmt.Errorf("%w (%w)", err, ctxErr)
+}
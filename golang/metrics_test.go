@@ -0,0 +1,64 @@
+package golang
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCancellation(t *testing.T) {
+	release := make(chan struct{})
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/me/v1/":
+			w.Write([]byte(`{"success":true,"data":{"id":"user-id"}}`))
+		case "/slow-headers":
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+		case "/slow-body":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"success":true,"data":{"id":`))
+			w.(http.Flusher).Flush()
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+	defer close(release)
+
+	metrics := &Metrics{}
+	service := newService(ts.URL, "client-id", "secret", WithMetrics(metrics), WithRetry(3, time.Millisecond))
+
+	for name, path := range map[string]string{"Waiting For Headers": "/slow-headers", "Reading Body": "/slow-body"} {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+
+			start := time.Now()
+			_, err := service.call(ctx, apiRequest{method: http.MethodGet, path: path, action: "fetch user"}, nil)
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Fatalf("expected the call to be aborted promptly, took %v", elapsed)
+			}
+		})
+	}
+
+	if _, err := service.Me(context.Background(), "valid-token"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if metrics.Canceled() != 2 || metrics.Completed() != 1 || metrics.Failed() != 0 || metrics.InFlight() != 0 {
+		t.Fatalf("unexpected metrics: canceled %d, completed %d, failed %d, in flight %d",
+			metrics.Canceled(), metrics.Completed(), metrics.Failed(), metrics.InFlight())
+	}
+}
@@ -0,0 +1,85 @@
+package golang
+
+import (
+	"net/http"
+	"time"
+)
+
+// Option configures the service returned by NewService.
+type Option func(*serviceImpl)
+
+// RequestHook is called before every request is sent, including retries.
+// It may add headers, for example for tracing.
+type RequestHook func(req *http.Request)
+
+// ResponseHook is called after every attempt with the response or the error
+// it failed with, for example for logging or metrics.
+type ResponseHook func(req *http.Request, resp *http.Response, err error)
+
+// WithHTTPClient makes the service send requests with client instead of
+// http.DefaultClient, e.g. to configure proxies or TLS.
+func WithHTTPClient(client *http.Client) Option {
+	return func(s *serviceImpl) {
+		if client != nil {
+			s.httpClient = client
+		}
+	}
+}
+
+// DefaultMaxResponseBytes is the response size limit of services created
+// without WithMaxResponseBytes.
+const DefaultMaxResponseBytes = 10 << 20
+
+// WithMaxResponseBytes limits the size of the response bodies the service
+// reads into memory, failing larger responses with a *ResponseTooLargeError.
+// A limit of zero or less disables the check.
+func WithMaxResponseBytes(limit int64) Option {
+	return func(s *serviceImpl) {
+		s.maxResponseBytes = limit
+	}
+}
+
+// WithTimeout limits the time each request may take, including reading the
+// response body. The HTTP client in use is copied, never modified.
+func WithTimeout(timeout time.Duration) Option {
+	return func(s *serviceImpl) {
+		s.timeout = timeout
+	}
+}
+
+// WithRetry retries requests failing with a network error, a 5xx or a 429
+// response up to max times, waiting backoff before the first retry and
+// doubling the wait before each further one. Only calls that cannot be
+// applied twice are retried: reads, PUT and DELETE requests, writes that
+// have no further effect when repeated, such as adding a resource to a role,
+// and writes made with WithIdempotencyKey. Others, such as creates, are only
+// retried if WithRetryNonIdempotent is also given. Retries stop as soon as
+// the request's context is done.
+func WithRetry(max int, backoff time.Duration) Option {
+	return func(s *serviceImpl) {
+		s.retry.max = max
+		s.retry.backoff = backoff
+	}
+}
+
+// WithRetryNonIdempotent allows WithRetry to also retry non-idempotent
+// requests such as creates, which may then be applied more than once.
+func WithRetryNonIdempotent() Option {
+	return func(s *serviceImpl) {
+		s.retry.nonIdempotent = true
+	}
+}
+
+// WithRequestHook adds a hook called before every request is sent.
+func WithRequestHook(hook RequestHook) Option {
+	return func(s *serviceImpl) {
+		s.requestHooks = append(s.requestHooks, hook)
+	}
+}
+
+// WithResponseHook adds a hook called after every attempt.
+func WithResponseHook(hook ResponseHook) Option {
+	return func(s *serviceImpl) {
+		s.responseHooks = append(s.responseHooks, hook)
+	}
+}
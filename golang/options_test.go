@@ -0,0 +1,230 @@
+package golang
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithRetry(t *testing.T) {
+	var calls int32
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"success":false,"message":"Unavailable"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"success":true,"data":{"id":"user-id"}}`))
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret", WithRetry(3, time.Millisecond))
+
+	t.Run("Idempotent Call", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		user, err := service.Me(context.Background(), "token")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if user.Id != "user-id" || calls != 3 {
+			t.Fatalf("expected success after 3 calls, got %d calls", calls)
+		}
+	})
+
+	t.Run("Non-idempotent Call", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		err := service.CreateResource(context.Background(), &Resource{Name: "resource"}, "token")
+		if err == nil {
+			t.Fatal("expected an error, got none")
+		}
+		if calls != 1 {
+			t.Fatalf("expected a single call, got %d", calls)
+		}
+	})
+
+	t.Run("Non-idempotent Opt-in", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		service := NewService(ts.URL, "client-id", "secret", WithRetry(3, time.Millisecond), WithRetryNonIdempotent())
+		if err := service.CreateResource(context.Background(), &Resource{Name: "resource"}, "token"); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if calls != 3 {
+			t.Fatalf("expected 3 calls, got %d", calls)
+		}
+	})
+
+	t.Run("Context Cancelled", func(t *testing.T) {
+		atomic.StoreInt32(&calls, -100)
+		service := NewService(ts.URL, "client-id", "secret", WithRetry(10, time.Hour))
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		_, err := service.Me(ctx, "token")
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected deadline exceeded, got %v", err)
+		}
+	})
+}
+
+func TestRetrySafety(t *testing.T) {
+	var calls int32
+	var keys []string
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"success":false,"message":"Unavailable"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"success":true,"data":{"allowed":true}}`))
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret", WithRetry(3, time.Millisecond))
+
+	tests := []struct {
+		name  string
+		call  func(ctx context.Context) error
+		ctx   context.Context
+		calls int32
+	}{
+		{
+			name: "Create Is Not Retried",
+			call: func(ctx context.Context) error {
+				return service.CreateRole(ctx, &Role{Name: "role"}, "token")
+			},
+			ctx:   context.Background(),
+			calls: 1,
+		},
+		{
+			name: "Read By POST Is Retried",
+			call: func(ctx context.Context) error {
+				_, err := service.EvaluateWithContext(ctx, "billing:read", AccessAttributes{}, "token")
+				return err
+			},
+			ctx:   context.Background(),
+			calls: 3,
+		},
+		{
+			name: "Create With Idempotency Key Is Retried",
+			call: func(ctx context.Context) error {
+				return service.CreateRole(ctx, &Role{Name: "role"}, "token")
+			},
+			ctx:   WithIdempotencyKey(context.Background(), "key-1"),
+			calls: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			atomic.StoreInt32(&calls, 0)
+			keys = nil
+			err := tt.call(tt.ctx)
+			if calls != tt.calls {
+				t.Fatalf("expected %d calls, got %d", tt.calls, calls)
+			}
+			if (err == nil) != (tt.calls == 3) {
+				t.Fatalf("unexpected error %v", err)
+			}
+		})
+	}
+
+	t.Run("Idempotency Key Is Reused", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		keys = nil
+		if err := service.CreateRole(WithIdempotencyKey(context.Background(), "key-1"), &Role{Name: "role"}, "token"); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		for _, key := range keys {
+			if key != "key-1" {
+				t.Fatalf("expected every attempt to send key-1, got %v", keys)
+			}
+		}
+	})
+
+	t.Run("Generated Idempotency Key", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		keys = nil
+		service.CreateRole(context.Background(), &Role{Name: "role"}, "token")
+		if len(keys) != 1 || keys[0] == "" {
+			t.Fatalf("expected a generated key on the create, got %v", keys)
+		}
+	})
+}
+
+func TestWithHooksAndClient(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Trace-Id") != "trace" {
+			t.Errorf("expected request hook header, got %q", r.Header.Get("X-Trace-Id"))
+		}
+		time.Sleep(50 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"success":true,"data":{"id":"user-id"}}`))
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	client := &http.Client{}
+	trace := WithRequestHook(func(req *http.Request) { req.Header.Set("X-Trace-Id", "trace") })
+	var responses int32
+	service := NewService(ts.URL, "client-id", "secret",
+		WithHTTPClient(client),
+		trace,
+		WithResponseHook(func(req *http.Request, resp *http.Response, err error) { atomic.AddInt32(&responses, 1) }),
+	)
+	if _, err := service.Me(context.Background(), "token"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if responses != 1 {
+		t.Fatalf("expected response hook to be called once, got %d", responses)
+	}
+
+	timed := NewService(ts.URL, "client-id", "secret", WithHTTPClient(client), trace, WithTimeout(10*time.Millisecond))
+	if _, err := timed.Me(context.Background(), "token"); err == nil {
+		t.Fatal("expected a timeout error, got none")
+	}
+	if client.Timeout != 0 {
+		t.Fatal("expected the provided client not to be modified")
+	}
+}
+
+func TestMaxResponseBytes(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		body := `{"success":true,"data":{"id":"user-id","name":"` + strings.Repeat("a", 1<<20) + `"}}`
+		if r.URL.Query().Get("chunked") == "" {
+			w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		} else {
+			w.Header().Set("Transfer-Encoding", "chunked")
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(body))
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	for _, path := range []string{"/me", "/me?chunked=1"} {
+		service := newService(ts.URL, "client-id", "secret", WithMaxResponseBytes(1024))
+		_, err := service.call(context.Background(), apiRequest{method: http.MethodGet, path: path, action: "fetch user"}, nil)
+		var tooLarge *ResponseTooLargeError
+		if !errors.As(err, &tooLarge) || tooLarge.Limit != 1024 || tooLarge.Action != "fetch user" {
+			t.Fatalf("expected a ResponseTooLargeError for %s, got %v", path, err)
+		}
+	}
+
+	service := newService(ts.URL, "client-id", "secret", WithMaxResponseBytes(0))
+	if _, err := service.call(context.Background(), apiRequest{method: http.MethodGet, path: "/me?chunked=1"}, nil); err != nil {
+		t.Fatalf("expected no error without a limit, got %v", err)
+	}
+}
@@ -0,0 +1,200 @@
+package golang
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Organization groups projects, so that org-level roles can grant
+// permissions spanning all of its projects.
+type Organization struct {
+	Id          string     `json:"id"`          // Unique identifier for the organization
+	Name        string     `json:"name"`        // Display name of the organization
+	Description string     `json:"description"` // Description of the organization
+	CreatedAt   *time.Time `json:"created_at"`  // Timestamp when organization was created
+	CreatedBy   string     `json:"created_by"`  // ID of the user who created this organization
+	UpdatedAt   *time.Time `json:"updated_at"`  // Timestamp when organization was last updated
+	UpdatedBy   string     `json:"updated_by"`  // ID of the user who last updated this organization
+}
+
+type OrganizationResponse struct {
+	Success bool          `json:"success"`
+	Message string        `json:"message"`
+	Data    *Organization `json:"data,omitempty"`
+}
+
+type OrganizationsResponse struct {
+	Success bool           `json:"success"`
+	Message string         `json:"message"`
+	Data    []Organization `json:"data,omitempty"`
+}
+
+type RolesResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	Data    []Role `json:"data,omitempty"`
+}
+
+// CreateOrganization creates a new organization with the provided details and token.
+// Organization argument will be updated with the created organization details.
+func (s *serviceImpl) CreateOrganization(ctx context.Context, org *Organization, token string) error {
+	if org == nil {
+		return fmt.Errorf("organization cannot be nil")
+	}
+
+	result := OrganizationResponse{}
+	if _, err := s.call(ctx, apiRequest{
+		method: http.MethodPost,
+		path:   "/org/v1/",
+		body:   org,
+		token:  token,
+		action: "create organization",
+	}, &result); err != nil {
+		return err
+	}
+
+	if result.Data != nil {
+		*org = *result.Data
+	}
+
+	return nil
+}
+
+// GetOrganization fetches the organization with the provided ID.
+func (s *serviceImpl) GetOrganization(ctx context.Context, id string, token string) (*Organization, error) {
+	result := OrganizationResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodGet,
+		path:   "/org/v1/" + url.PathEscape(id),
+		token:  token,
+		action: "fetch organization",
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Data == nil {
+		return nil, fmt.Errorf("failed to fetch organization: empty response. Status: %s", resp.Status)
+	}
+
+	return result.Data, nil
+}
+
+// ListOrganizations fetches the organizations visible to the token.
+func (s *serviceImpl) ListOrganizations(ctx context.Context, token string) ([]Organization, error) {
+	result := OrganizationsResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodGet,
+		path:   "/org/v1/",
+		token:  token,
+		action: "list organizations",
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	capturePagination(ctx, listPagination(resp, len(result.Data)))
+
+	return result.Data, nil
+}
+
+// UpdateOrganization updates the organization with the same ID.
+// Organization argument will be updated with the stored organization details.
+func (s *serviceImpl) UpdateOrganization(ctx context.Context, org *Organization, token string) error {
+	if org == nil {
+		return fmt.Errorf("organization cannot be nil")
+	}
+
+	result := OrganizationResponse{}
+	if _, err := s.call(ctx, apiRequest{
+		method: http.MethodPut,
+		path:   "/org/v1/" + url.PathEscape(org.Id),
+		body:   org,
+		token:  token,
+		action: "update organization",
+	}, &result); err != nil {
+		return err
+	}
+
+	if result.Data != nil {
+		*org = *result.Data
+	}
+
+	return nil
+}
+
+// DeleteOrganization deletes the organization with the provided ID.
+func (s *serviceImpl) DeleteOrganization(ctx context.Context, id string, token string) error {
+	result := OrganizationResponse{}
+	if _, err := s.call(ctx, apiRequest{
+		method: http.MethodDelete,
+		path:   "/org/v1/" + url.PathEscape(id),
+		token:  token,
+		action: "delete organization",
+	}, &result); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListOrganizationProjects fetches the projects belonging to the organization.
+func (s *serviceImpl) ListOrganizationProjects(ctx context.Context, orgID string, token string) ([]Project, error) {
+	result := ProjectsResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodGet,
+		path:   "/org/v1/" + url.PathEscape(orgID) + "/project",
+		token:  token,
+		action: "list organization projects",
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	capturePagination(ctx, listPagination(resp, len(result.Data)))
+
+	return result.Data, nil
+}
+
+// CreateOrganizationRole creates a role whose resources are granted across
+// every project of the organization.
+// Role argument will be updated with the created role details.
+func (s *serviceImpl) CreateOrganizationRole(ctx context.Context, orgID string, role *Role, token string) error {
+	if role == nil {
+		return fmt.Errorf("role cannot be nil")
+	}
+
+	result := RoleResponse{}
+	if _, err := s.call(ctx, apiRequest{
+		method: http.MethodPost,
+		path:   "/org/v1/" + url.PathEscape(orgID) + "/role",
+		body:   role,
+		token:  token,
+		action: "create organization role",
+	}, &result); err != nil {
+		return err
+	}
+
+	if result.Data != nil {
+		*role = *result.Data
+	}
+
+	return nil
+}
+
+// ListOrganizationRoles fetches the org-level roles of the organization.
+func (s *serviceImpl) ListOrganizationRoles(ctx context.Context, orgID string, token string) ([]Role, error) {
+	result := RolesResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodGet,
+		path:   "/org/v1/" + url.PathEscape(orgID) + "/role",
+		token:  token,
+		action: "list organization roles",
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	capturePagination(ctx, listPagination(resp, len(result.Data)))
+
+	return result.Data, nil
+}
@@ -0,0 +1,215 @@
+package golang
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Pagination describes a page of a list response. Total, Skip and Limit are
+// sent by the server; Page and PerPage are derived from them, and Links holds
+// the RFC 5988 links of the response's Link header, keyed by relation such as
+// "next" and "prev".
+type Pagination struct {
+	Total   int64             `json:"total"` // Total number of items matching the query
+	Skip    int64             `json:"skip"`  // Number of items before this page
+	Limit   int64             `json:"limit"` // Maximum number of items per page
+	Page    int               `json:"-"`     // 1-based number of this page
+	PerPage int               `json:"-"`     // Maximum number of items per page, 0 if unlimited
+	Links   map[string]string `json:"-"`     // Link header URLs by relation, nil if none were sent
+
+	full bool // Whether the page holds PerPage items, for servers omitting Total
+}
+
+// HasNext reports whether there are items after this page: the server sent a
+// next link, or the items before the end of this page are fewer than Total.
+// If the server omitted Total, a full page is assumed to have a next one.
+func (p Pagination) HasNext() bool {
+	if _, ok := p.Links["next"]; ok {
+		return true
+	}
+	if p.Total <= 0 {
+		return p.full
+	}
+	return p.PerPage > 0 && int64(p.Page*p.PerPage) < p.Total
+}
+
+// HasPrev reports whether there are items before this page.
+func (p Pagination) HasPrev() bool {
+	return p.Page > 1
+}
+
+// LastPage returns the number of the last page, at least 1. It is only
+// known if the server sent Total.
+func (p Pagination) LastPage() int {
+	if p.PerPage <= 0 || p.Total <= 0 {
+		return 1
+	}
+	return int((p.Total + int64(p.PerPage) - 1) / int64(p.PerPage))
+}
+
+// LinkHeader returns an RFC 5988 Link header value with first, prev, next
+// and last links for the same listing served at base, e.g. by an API layer
+// re-emitting go-iam data. The pages are selected with the page and limit
+// query parameters, other parameters of base are kept.
+func (p Pagination) LinkHeader(base *url.URL) string {
+	link := func(page int, rel string) string {
+		u := *base
+		q := u.Query()
+		q.Set("page", strconv.Itoa(page))
+		if p.PerPage > 0 {
+			q.Set("limit", strconv.Itoa(p.PerPage))
+		}
+		u.RawQuery = q.Encode()
+		return "<" + u.String() + `>; rel="` + rel + `"`
+	}
+
+	links := []string{link(1, "first")}
+	if p.HasPrev() {
+		links = append(links, link(p.Page-1, "prev"))
+	}
+	if p.HasNext() {
+		links = append(links, link(p.Page+1, "next"))
+	}
+	if p.Total > 0 || !p.HasNext() {
+		links = append(links, link(max(p.LastPage(), p.Page), "last"))
+	}
+	return strings.Join(links, ", ")
+}
+
+// ParseLinkHeader parses an RFC 5988 Link header value into URLs keyed by
+// relation. Links with several relations are stored under each of them.
+// Commas and semicolons inside the URLs or quoted parameter values do not
+// separate links.
+func ParseLinkHeader(header string) map[string]string {
+	links := map[string]string{}
+	for {
+		start := strings.IndexByte(header, '<')
+		if start < 0 {
+			return links
+		}
+		end := strings.IndexByte(header[start:], '>')
+		if end < 0 {
+			return links
+		}
+		target := header[start+1 : start+end]
+		header = header[start+end+1:]
+
+		// The parameters of the link run until the next comma outside quotes.
+		params := header
+		if i := indexUnquoted(header, ','); i >= 0 {
+			params, header = header[:i], header[i+1:]
+		} else {
+			header = ""
+		}
+		for params != "" {
+			param := params
+			if i := indexUnquoted(params, ';'); i >= 0 {
+				param, params = params[:i], params[i+1:]
+			} else {
+				params = ""
+			}
+			name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || !strings.EqualFold(strings.TrimSpace(name), "rel") {
+				continue
+			}
+			for _, rel := range strings.Fields(strings.Trim(strings.TrimSpace(value), `"`)) {
+				links[strings.ToLower(rel)] = target
+			}
+		}
+	}
+}
+
+// indexUnquoted returns the index of the first sep in s outside a quoted
+// string, or -1 if there is none.
+func indexUnquoted(s string, sep byte) int {
+	quoted := false
+	for i := 0; i < len(s); i++ {
+		switch {
+		case quoted && s[i] == '\\':
+			i++
+		case s[i] == '"':
+			quoted = !quoted
+		case !quoted && s[i] == sep:
+			return i
+		}
+	}
+	return -1
+}
+
+// PaginationCapture records the pagination of the responses to list calls
+// made with the context returned by CapturePagination.
+type PaginationCapture struct {
+	mu         sync.Mutex
+	pagination Pagination
+	ok         bool
+}
+
+// CapturePagination returns a copy of ctx recording the pagination of list
+// responses in the returned capture. List methods returning a slice rather
+// than a page report every item as a single page, with the links of the
+// response's Link header if the server sent one:
+//
+//	lctx, capture := golang.CapturePagination(ctx)
+//	projects, err := service.ListProjects(lctx, token)
+//	if p, ok := capture.Pagination(); ok {
+//		w.Header().Set("Link", p.LinkHeader(r.URL))
+//	}
+func CapturePagination(ctx context.Context) (context.Context, *PaginationCapture) {
+	c := &PaginationCapture{}
+	return context.WithValue(ctx, paginationCaptureKey{}, c), c
+}
+
+// Pagination returns the pagination of the latest list response, false if
+// no list call was made with the context.
+func (c *PaginationCapture) Pagination() (Pagination, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.pagination, c.ok
+}
+
+type paginationCaptureKey struct{}
+
+// capturePagination records p in the capture of ctx, if any.
+func capturePagination(ctx context.Context, p Pagination) {
+	if c, ok := ctx.Value(paginationCaptureKey{}).(*PaginationCapture); ok {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.pagination, c.ok = p, true
+	}
+}
+
+// listPagination describes the response of a list call returning all n
+// items at once.
+func listPagination(resp *http.Response, n int) Pagination {
+	p := Pagination{Total: int64(n)}
+	p.complete(resp, 0, 0, n)
+	return p
+}
+
+// complete derives Page and PerPage, falling back to the requested page and
+// limit when the server did not echo them, records whether the n items of
+// the page fill it and parses the Link header of resp.
+func (p *Pagination) complete(resp *http.Response, page, limit, n int) {
+	if p.Limit <= 0 {
+		p.Limit = int64(max(limit, 0))
+	}
+	p.PerPage = int(p.Limit)
+	switch {
+	case p.PerPage > 0 && (p.Skip > 0 || page <= 0):
+		p.Page = int(p.Skip)/p.PerPage + 1
+	case page > 0:
+		p.Page = page
+	default:
+		p.Page = 1
+	}
+	p.full = p.PerPage > 0 && n >= p.PerPage
+	if resp != nil {
+		if header := resp.Header.Get("Link"); header != "" {
+			p.Links = ParseLinkHeader(header)
+		}
+	}
+}
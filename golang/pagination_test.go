@@ -0,0 +1,153 @@
+package golang
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestParseLinkHeader(t *testing.T) {
+	header := `<https://iam.example.com/role/v1/search?page=3>; rel="next", ` +
+		`<https://iam.example.com/role/v1/search?page=1>; rel="prev first", ` +
+		`<https://iam.example.com/role/v1/search?ids=a,b;c&page=9>; title="Last, 9; final"; rel=last, ` +
+		`malformed; rel="up"`
+	want := map[string]string{
+		"next":  "https://iam.example.com/role/v1/search?page=3",
+		"prev":  "https://iam.example.com/role/v1/search?page=1",
+		"first": "https://iam.example.com/role/v1/search?page=1",
+		"last":  "https://iam.example.com/role/v1/search?ids=a,b;c&page=9",
+	}
+	if got := ParseLinkHeader(header); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestListPagination(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", `<http://iam/role/v1/search?page=3&limit=10>; rel="next"`)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"success":true,"data":{"roles":[{"id":"role-id"}],"total":25,"skip":10,"limit":10}}`))
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret")
+	list, err := service.ListRoles(context.Background(), ListRolesQuery{Page: 2, Limit: 10}, "valid-token")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if list.Total != 25 || list.Page != 2 || list.PerPage != 10 || !list.HasNext() || !list.HasPrev() {
+		t.Fatalf("unexpected pagination: %+v", list.Pagination)
+	}
+	if list.Links["next"] != "http://iam/role/v1/search?page=3&limit=10" {
+		t.Fatalf("unexpected links: %v", list.Links)
+	}
+}
+
+func TestPaginationWithoutTotal(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "1" {
+			w.Write([]byte(`{"success":true,"data":{"roles":[{"id":"a"},{"id":"b"}]}}`))
+			return
+		}
+		w.Write([]byte(`{"success":true,"data":{"roles":[{"id":"c"}]}}`))
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret")
+	full, err := service.ListRoles(context.Background(), ListRolesQuery{Page: 1, Limit: 2}, "valid-token")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !full.HasNext() {
+		t.Fatalf("expected a full page to have a next one: %+v", full.Pagination)
+	}
+	last, err := service.ListRoles(context.Background(), ListRolesQuery{Page: 2, Limit: 2}, "valid-token")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if last.HasNext() {
+		t.Fatalf("expected a short page to be the last one: %+v", last.Pagination)
+	}
+}
+
+func TestCapturePagination(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/project/v1/":
+			w.Header().Set("Link", `<http://iam/project/v1/?after=b,c>; rel="next"`)
+			w.Write([]byte(`{"success":true,"data":[{"id":"a"},{"id":"b"}]}`))
+		case "/role/v1/search":
+			w.Write([]byte(`{"success":true,"data":{"roles":[{"id":"role-id"}],"total":25,"skip":10,"limit":10}}`))
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret")
+	ctx, capture := CapturePagination(context.Background())
+	if _, ok := capture.Pagination(); ok {
+		t.Fatal("expected no pagination before a list call")
+	}
+
+	if _, err := service.ListProjects(ctx, "valid-token"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	p, ok := capture.Pagination()
+	if !ok || p.Total != 2 || p.Page != 1 || p.PerPage != 0 || !p.HasNext() || p.Links["next"] != "http://iam/project/v1/?after=b,c" {
+		t.Fatalf("unexpected pagination: %+v", p)
+	}
+
+	if _, err := service.ListRoles(ctx, ListRolesQuery{Page: 2, Limit: 10}, "valid-token"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if p, _ := capture.Pagination(); p.Total != 25 || p.Page != 2 || p.PerPage != 10 {
+		t.Fatalf("unexpected pagination: %+v", p)
+	}
+}
+
+func TestPaginationLinkHeader(t *testing.T) {
+	base, _ := url.Parse("https://api.example.com/roles?name=admin")
+
+	tests := []struct {
+		name string
+		page Pagination
+		want string
+	}{
+		{
+			name: "Middle Page",
+			page: Pagination{Total: 25, Page: 2, PerPage: 10},
+			want: `<https://api.example.com/roles?limit=10&name=admin&page=1>; rel="first", ` +
+				`<https://api.example.com/roles?limit=10&name=admin&page=1>; rel="prev", ` +
+				`<https://api.example.com/roles?limit=10&name=admin&page=3>; rel="next", ` +
+				`<https://api.example.com/roles?limit=10&name=admin&page=3>; rel="last"`,
+		},
+		{
+			name: "Unlimited",
+			page: Pagination{Total: 25, Page: 1},
+			want: `<https://api.example.com/roles?name=admin&page=1>; rel="first", ` +
+				`<https://api.example.com/roles?name=admin&page=1>; rel="last"`,
+		},
+		{
+			name: "Unknown Total",
+			page: Pagination{Page: 2, PerPage: 10, full: true},
+			want: `<https://api.example.com/roles?limit=10&name=admin&page=1>; rel="first", ` +
+				`<https://api.example.com/roles?limit=10&name=admin&page=1>; rel="prev", ` +
+				`<https://api.example.com/roles?limit=10&name=admin&page=3>; rel="next"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.page.LinkHeader(base); got != tt.want {
+				t.Fatalf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package golang
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is returned instead of crashing the process when a callback
+// supplied by the application, such as a hook, a token source or a policy
+// function, panics while the SDK invokes it.
+type PanicError struct {
+	Callback string // The callback that panicked, e.g. "request hook"
+	Value    any    // Value passed to panic
+	Stack    []byte // Stack trace of the panicking goroutine
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("%s panicked: %v", e.Callback, e.Value)
+}
+
+// Unwrap returns the panic value if it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// PanicHandler is notified of recovered callback panics, e.g. to log the
+// stack trace or count them in metrics. Panics in the handler itself are
+// ignored.
+type PanicHandler func(err *PanicError)
+
+// WithPanicHandler sets the handler notified when a callback invoked by the
+// service panics. The call the callback belongs to fails with the
+// *PanicError either way.
+func WithPanicHandler(h PanicHandler) Option {
+	return func(s *serviceImpl) {
+		s.panicHandler = h
+	}
+}
+
+// Protect runs fn, recovering a panic as a *PanicError naming callback, which
+// is reported to onPanic, if not nil, and returned. It lets code invoking
+// application callbacks, such as middleware, share the SDK's panic handling.
+func Protect(callback string, onPanic PanicHandler, fn func()) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			perr := &PanicError{Callback: callback, Value: v, Stack: debug.Stack()}
+			if onPanic != nil {
+				Protect("panic handler", nil, func() { onPanic(perr) })
+			}
+			err = perr
+		}
+	}()
+	fn()
+	return nil
+}
@@ -0,0 +1,94 @@
+package golang
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type panickingTokenSource struct{}
+
+func (panickingTokenSource) Token(ctx context.Context) (*Token, error) {
+	panic("token source bug")
+}
+
+func TestCallbackPanics(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(`{"success":true,"data":{"id":"user-id"}}`))
+	}))
+	defer ts.Close()
+
+	tests := []struct {
+		name      string
+		option    Option
+		callback  string
+		wantCalls int32
+	}{
+		{"Request Hook", WithRequestHook(func(req *http.Request) { panic("request hook bug") }), "request hook", 0},
+		{"Response Hook", WithResponseHook(func(req *http.Request, resp *http.Response, err error) { panic(errors.New("response hook bug")) }), "response hook", 1},
+		{"Token Source", WithTokenSource(panickingTokenSource{}), "token source", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			atomic.StoreInt32(&calls, 0)
+			var handled *PanicError
+			service := NewService(ts.URL, "client-id", "secret", tt.option,
+				WithRetry(2, time.Millisecond),
+				WithPanicHandler(func(err *PanicError) { handled = err }),
+			)
+
+			token := "valid-token"
+			if tt.callback == "token source" {
+				token = ""
+			}
+			_, err := service.Me(context.Background(), token)
+			var perr *PanicError
+			if !errors.As(err, &perr) || perr.Callback != tt.callback || len(perr.Stack) == 0 {
+				t.Fatalf("expected a PanicError from the %s, got %v", tt.callback, err)
+			}
+			if handled != perr {
+				t.Fatalf("expected the panic handler to receive the error, got %v", handled)
+			}
+			if calls != tt.wantCalls {
+				t.Fatalf("expected %d calls without retries, got %d", tt.wantCalls, calls)
+			}
+		})
+	}
+}
+
+func TestProtect(t *testing.T) {
+	if err := Protect("callback", nil, func() {}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	cause := errors.New("boom")
+	err := Protect("callback", func(*PanicError) { panic("handler bug") }, func() { panic(cause) })
+	if !errors.Is(err, cause) || err.Error() != "callback panicked: boom" {
+		t.Fatalf("expected a PanicError wrapping the cause, got %v", err)
+	}
+}
+
+func TestPolicyAndFanOutPanics(t *testing.T) {
+	evaluator := NewPolicyEvaluator()
+	evaluator.Register("policy-id", func(args map[string]string) bool { panic("policy bug") })
+	user := &User{
+		Resources: map[string]UserResource{"billing:read": {Key: "billing:read", PolicyIds: map[string]bool{"policy-id": true}}},
+		Policies:  map[string]UserPolicy{"policy-id": {}},
+	}
+	var perr *PanicError
+	if _, err := evaluator.Evaluate(user, "billing:read", nil); !errors.As(err, &perr) {
+		t.Fatalf("expected a PanicError, got %v", err)
+	}
+
+	g, _ := NewFanOut(context.Background(), 0)
+	g.Go(func(ctx context.Context) error { panic("fan-out bug") })
+	if err := g.Wait(); !errors.As(err, &perr) {
+		t.Fatalf("expected a PanicError, got %v", err)
+	}
+}
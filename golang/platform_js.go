@@ -0,0 +1,18 @@
+//go:build js && wasm
+
+package golang
+
+import "net/http"
+
+// fetchRedirectHeader is read by the fetch-based transport of net/http under
+// js/wasm to set the redirect mode of the fetch call.
+const fetchRedirectHeader = "js.fetch:redirect"
+
+// prepareRequest adapts req to the fetch API browsers send it with. Fetch
+// follows redirects itself without calling CheckRedirect, so a disabled
+// RedirectPolicy makes redirects fail the call instead.
+func (s *serviceImpl) prepareRequest(req *http.Request) {
+	if s.redirect != nil && s.redirect.Disabled {
+		req.Header.Set(fetchRedirectHeader, "error")
+	}
+}
@@ -0,0 +1,22 @@
+//go:build js && wasm
+
+package golang
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestPrepareRequest(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "https://iam.example.com/me/v1/", nil)
+	newService("https://iam.example.com", "client-id", "", WithRedirectPolicy(RedirectPolicy{Disabled: true})).prepareRequest(req)
+	if req.Header.Get(fetchRedirectHeader) != "error" {
+		t.Fatalf("expected redirects to fail the fetch, got %q", req.Header.Get(fetchRedirectHeader))
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, "https://iam.example.com/me/v1/", nil)
+	newService("https://iam.example.com", "client-id", "").prepareRequest(req)
+	if req.Header.Get(fetchRedirectHeader) != "" {
+		t.Fatalf("expected fetch to follow redirects, got %q", req.Header.Get(fetchRedirectHeader))
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !(js && wasm)
+
+package golang
+
+import "net/http"
+
+// prepareRequest adapts req to the platform's HTTP stack, which needs no
+// adaptation outside of js/wasm.
+func (s *serviceImpl) prepareRequest(req *http.Request) {}
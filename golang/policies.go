@@ -0,0 +1,112 @@
+package golang
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Policy is a reusable rule attached to users, parameterised by arguments
+// that are resolved through each user's UserPolicyMapping.
+type Policy struct {
+	Id          string           `json:"id"`          // Unique identifier for the policy
+	Name        string           `json:"name"`        // Display name of the policy
+	Description string           `json:"description"` // Description of what the policy enforces
+	Definition  PolicyDefinition `json:"definition"`  // Arguments the policy expects
+	CreatedAt   *time.Time       `json:"created_at"`  // Timestamp when policy was created
+	CreatedBy   string           `json:"created_by"`  // ID of the user who created this policy
+	UpdatedAt   *time.Time       `json:"updated_at"`  // Timestamp when policy was last updated
+	UpdatedBy   string           `json:"updated_by"`  // ID of the user who last updated this policy
+}
+
+// PolicyDefinition describes the arguments of a policy.
+type PolicyDefinition struct {
+	Arguments map[string]PolicyArgument `json:"arguments"`
+}
+
+// PolicyArgument is a single argument of a policy definition.
+type PolicyArgument struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// ListPoliciesQuery filters and paginates ListPolicies.
+type ListPoliciesQuery struct {
+	Name  string // Only policies whose name contains Name
+	Page  int    // 1-based page number, the first page if zero
+	Limit int    // Maximum number of policies per page, the server default if zero
+}
+
+// PolicyList is a page of policies returned by ListPolicies.
+type PolicyList struct {
+	Policies []Policy `json:"policies"` // Policies on this page
+	Pagination
+}
+
+type PolicyListResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    *PolicyList `json:"data,omitempty"`
+}
+
+type AttachPolicyRequest struct {
+	PolicyId string            `json:"policy_id"`
+	Mapping  UserPolicyMapping `json:"mapping"`
+}
+
+// ListPolicies searches the policies matching the query, one page at a time.
+func (s *serviceImpl) ListPolicies(ctx context.Context, query ListPoliciesQuery, token string) (*PolicyList, error) {
+	result := PolicyListResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodGet,
+		path:   "/policy/v1/search",
+		query:  query.values(),
+		token:  token,
+		action: "list policies",
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Data == nil {
+		return nil, fmt.Errorf("failed to list policies: empty response. Status: %s", resp.Status)
+	}
+	result.Data.complete(resp, query.Page, query.Limit, len(result.Data.Policies))
+	capturePagination(ctx, result.Data.Pagination)
+
+	return result.Data, nil
+}
+
+// AttachPolicyToUser attaches the policy to the user, resolving its
+// arguments through the provided mapping.
+func (s *serviceImpl) AttachPolicyToUser(ctx context.Context, userID string, policyID string, mapping UserPolicyMapping, token string) error {
+	result := UserResponse{}
+	if _, err := s.call(ctx, apiRequest{
+		method: http.MethodPost,
+		path:   "/user/v1/" + url.PathEscape(userID) + "/policy",
+		body:   AttachPolicyRequest{PolicyId: policyID, Mapping: mapping},
+		token:  token,
+		action: "attach policy to user",
+		retry:  retrySafe,
+	}, &result); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (q ListPoliciesQuery) values() url.Values {
+	v := url.Values{}
+	if q.Name != "" {
+		v.Set("name", q.Name)
+	}
+	if q.Page > 0 {
+		v.Set("page", strconv.Itoa(q.Page))
+	}
+	if q.Limit > 0 {
+		v.Set("limit", strconv.Itoa(q.Limit))
+	}
+	return v
+}
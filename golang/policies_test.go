@@ -0,0 +1,85 @@
+package golang
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListPolicies(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/policy/v1/search" {
+			t.Fatalf("expected path /policy/v1/search, got %s", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") == "Bearer valid-token" {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"success":true,"data":{"policies":[{"id":"policy-id","name":"Own records","definition":{"arguments":{"@userId":{"name":"@userId"}}}}],"total":1}}`))
+		} else {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"success":false,"message":"Invalid token"}`))
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret")
+
+	t.Run("Valid Token", func(t *testing.T) {
+		list, err := service.ListPolicies(context.Background(), ListPoliciesQuery{}, "valid-token")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(list.Policies) != 1 || len(list.Policies[0].Definition.Arguments) != 1 {
+			t.Fatalf("unexpected policy list: %+v", list)
+		}
+	})
+
+	t.Run("Invalid Token", func(t *testing.T) {
+		if _, err := service.ListPolicies(context.Background(), ListPoliciesQuery{}, "invalid-token"); err == nil {
+			t.Fatal("expected an error, got none")
+		}
+	})
+}
+
+func TestAttachPolicyToUser(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/user/v1/user-id/policy" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var payload AttachPolicyRequest
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("expected valid payload, got %v", err)
+		}
+		if payload.PolicyId != "policy-id" || payload.Mapping.Arguments["@userId"].Static != "user-id" {
+			t.Fatalf("unexpected payload: %+v", payload)
+		}
+		if r.Header.Get("Authorization") == "Bearer valid-token" {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"success":true,"data":{"id":"user-id"}}`))
+		} else {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"success":false,"message":"Invalid token"}`))
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret")
+	mapping := UserPolicyMapping{Arguments: map[string]UserPolicyMappingValue{"@userId": {Static: "user-id"}}}
+
+	t.Run("Valid Token", func(t *testing.T) {
+		if err := service.AttachPolicyToUser(context.Background(), "user-id", "policy-id", mapping, "valid-token"); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	})
+
+	t.Run("Invalid Token", func(t *testing.T) {
+		if err := service.AttachPolicyToUser(context.Background(), "user-id", "policy-id", mapping, "invalid-token"); err == nil {
+			t.Fatal("expected an error, got none")
+		}
+	})
+}
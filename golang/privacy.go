@@ -0,0 +1,75 @@
+package golang
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ErasureReceipt records the erasure of a user's personal data by EraseUser,
+// e.g. to answer the data subject.
+type ErasureReceipt struct {
+	UserId        string     `json:"user_id"`        // User whose data was erased
+	Reason        string     `json:"reason"`         // Why the data was erased, e.g. a request reference
+	TokensRevoked int        `json:"tokens_revoked"` // Number of the user's tokens revoked
+	AuditId       string     `json:"audit_id"`       // ID of the audit log entry recording the erasure
+	ErasedAt      *time.Time `json:"erased_at"`      // When the data was erased
+	ErasedBy      string     `json:"erased_by"`      // ID of the user who erased the data
+}
+
+type ErasureReceiptResponse struct {
+	Success bool            `json:"success"`
+	Message string          `json:"message"`
+	Data    *ErasureReceipt `json:"data,omitempty"`
+}
+
+// ExportUserData writes every piece of personal data go-iam holds about the
+// user to w as a JSON document, e.g. to answer a subject access request. The
+// export is streamed and not subject to the response size limit.
+func (s *serviceImpl) ExportUserData(ctx context.Context, userID string, w io.Writer, token string) error {
+	if w == nil {
+		return fmt.Errorf("writer cannot be nil")
+	}
+
+	_, err := s.call(ctx, apiRequest{
+		method:   http.MethodGet,
+		path:     "/user/v1/" + url.PathEscape(userID) + "/export",
+		token:    token,
+		action:   "export user data",
+		download: w,
+	}, nil)
+	return err
+}
+
+// EraseUser erases the personal data of the user: the profile is anonymized,
+// the user's tokens and consents are revoked and the erasure is recorded in
+// the audit log with the reason. The user's ID stays valid so references to
+// it keep working. The service's cache is cleared, since it may hold the
+// erased data.
+func (s *serviceImpl) EraseUser(ctx context.Context, userID string, reason string, token string) (*ErasureReceipt, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user ID cannot be empty")
+	}
+
+	defer s.purge(ctx)
+
+	result := ErasureReceiptResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodPost,
+		path:   "/user/v1/" + url.PathEscape(userID) + "/erase",
+		body:   map[string]string{"reason": reason},
+		token:  token,
+		action: "erase user",
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Data == nil {
+		return nil, fmt.Errorf("failed to erase user: empty response. Status: %s", resp.Status)
+	}
+
+	return result.Data, nil
+}
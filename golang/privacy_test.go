@@ -0,0 +1,89 @@
+package golang
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestExportUserData(t *testing.T) {
+	export := `{"user":{"id":"user-id","email":"user@example.com"},"consents":[]}` + strings.Repeat(" ", 64)
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/user/v1/user-id/export" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") == "Bearer valid-token" {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(export))
+		} else {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"success":false,"message":"Invalid token"}`))
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	// The export is streamed, so it is not subject to the response size limit.
+	service := NewService(ts.URL, "client-id", "secret", WithMaxResponseBytes(64))
+
+	t.Run("Valid Token", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := service.ExportUserData(context.Background(), "user-id", &buf, "valid-token"); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if buf.String() != export {
+			t.Fatalf("expected the export to be written as is, got %q", buf.String())
+		}
+	})
+
+	t.Run("Invalid Token", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := service.ExportUserData(context.Background(), "user-id", &buf, "invalid-token")
+		if !errors.Is(err, ErrUnauthorized) {
+			t.Fatalf("expected ErrUnauthorized, got %v", err)
+		}
+		if buf.Len() != 0 {
+			t.Fatalf("expected nothing to be written, got %q", buf.String())
+		}
+	})
+}
+
+func TestEraseUser(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/user/v1/user-id/erase" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") == "Bearer valid-token" {
+			w.Write([]byte(`{"success":true,"data":{"user_id":"user-id","reason":"DSR-42","tokens_revoked":3,"audit_id":"audit-id"}}`))
+		} else {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"success":false,"message":"Invalid token"}`))
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret")
+
+	t.Run("Valid Token", func(t *testing.T) {
+		receipt, err := service.EraseUser(context.Background(), "user-id", "DSR-42", "valid-token")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if receipt.TokensRevoked != 3 || receipt.AuditId != "audit-id" {
+			t.Fatalf("unexpected receipt %+v", receipt)
+		}
+	})
+
+	t.Run("Invalid Token", func(t *testing.T) {
+		if _, err := service.EraseUser(context.Background(), "user-id", "DSR-42", "invalid-token"); err == nil {
+			t.Fatal("expected an error, got none")
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package golang
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// defaultMaxRedirects matches the limit of http.Client's default policy.
+const defaultMaxRedirects = 10
+
+// RedirectPolicy controls how the service follows HTTP redirects, e.g. the
+// 307s issued by a gateway in front of go-iam.
+type RedirectPolicy struct {
+	Disabled     bool     // Do not follow redirects, calls fail with an APIError carrying the 3xx status
+	MaxRedirects int      // Maximum number of redirects followed per request, 10 if zero
+	TrustedHosts []string // Other hosts, as host or host:port, that receive the Authorization header
+}
+
+// WithRedirectPolicy sets how redirects are followed. Unlike http.Client's
+// default policy, which also forwards credentials to subdomains and drops
+// them for any other host, the Authorization header is kept on redirects to
+// the same scheme and host as the original request or to a trusted host,
+// and removed on any other redirect, including https to http downgrades.
+// The HTTP client in use is copied, never modified.
+func WithRedirectPolicy(policy RedirectPolicy) Option {
+	return func(s *serviceImpl) {
+		s.redirect = &policy
+	}
+}
+
+// checkRedirect implements http.Client.CheckRedirect.
+func (p *RedirectPolicy) checkRedirect(req *http.Request, via []*http.Request) error {
+	if p.Disabled {
+		return http.ErrUseLastResponse
+	}
+	limit := p.MaxRedirects
+	if limit <= 0 {
+		limit = defaultMaxRedirects
+	}
+	if len(via) > limit {
+		return fmt.Errorf("stopped after %d redirects", limit)
+	}
+
+	first := via[0]
+	auth := first.Header.Get("Authorization")
+	if auth == "" {
+		return nil
+	}
+	if p.forwardsAuth(first.URL, req.URL) {
+		req.Header.Set("Authorization", auth)
+	} else {
+		req.Header.Del("Authorization")
+	}
+	return nil
+}
+
+// forwardsAuth reports whether credentials sent to from may be sent to to.
+func (p *RedirectPolicy) forwardsAuth(from, to *url.URL) bool {
+	if from.Scheme == "https" && to.Scheme != "https" {
+		return false
+	}
+	if from.Scheme == to.Scheme && strings.EqualFold(from.Host, to.Host) {
+		return true
+	}
+	for _, host := range p.TrustedHosts {
+		if strings.EqualFold(host, to.Host) || strings.EqualFold(host, to.Hostname()) {
+			return true
+		}
+	}
+	return false
+}
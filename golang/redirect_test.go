@@ -0,0 +1,73 @@
+package golang
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRedirectPolicy(t *testing.T) {
+	var gotAuth string
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"success":true,"data":{"id":"user-id"}}`))
+	}))
+	defer other.Close()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/gateway/me":
+			http.Redirect(w, r, "/me", http.StatusTemporaryRedirect)
+		case "/gateway/other":
+			http.Redirect(w, r, other.URL+"/me", http.StatusTemporaryRedirect)
+		case "/gateway/loop":
+			http.Redirect(w, r, "/gateway/loop", http.StatusTemporaryRedirect)
+		default:
+			gotAuth = r.Header.Get("Authorization")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"success":true,"data":{"id":"user-id"}}`))
+		}
+	}))
+	defer ts.Close()
+
+	otherHost := strings.TrimPrefix(other.URL, "http://")
+	tests := []struct {
+		name     string
+		path     string
+		policy   RedirectPolicy
+		wantAuth string
+		wantErr  bool
+	}{
+		{"Same Host", "/gateway/me", RedirectPolicy{}, "Bearer valid-token", false},
+		{"Other Host", "/gateway/other", RedirectPolicy{}, "", false},
+		{"Trusted Host", "/gateway/other", RedirectPolicy{TrustedHosts: []string{otherHost}}, "Bearer valid-token", false},
+		{"Limit", "/gateway/loop", RedirectPolicy{MaxRedirects: 2}, "", true},
+		{"Disabled", "/gateway/me", RedirectPolicy{Disabled: true}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotAuth = ""
+			service := newService(ts.URL, "client-id", "secret", WithRedirectPolicy(tt.policy))
+			_, err := service.call(context.Background(), apiRequest{method: http.MethodGet, path: tt.path, token: "valid-token", action: "fetch user"}, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if gotAuth != tt.wantAuth {
+				t.Fatalf("expected Authorization %q, got %q", tt.wantAuth, gotAuth)
+			}
+		})
+	}
+
+	t.Run("Disabled Status", func(t *testing.T) {
+		service := newService(ts.URL, "client-id", "secret", WithRedirectPolicy(RedirectPolicy{Disabled: true}))
+		_, err := service.call(context.Background(), apiRequest{method: http.MethodGet, path: "/gateway/me", token: "valid-token"}, nil)
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTemporaryRedirect {
+			t.Fatalf("expected an APIError with status 307, got %v", err)
+		}
+	})
+}
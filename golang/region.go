@@ -0,0 +1,51 @@
+package golang
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+)
+
+// ErrUnknownRegion is returned for calls routed to a data region the service
+// has no endpoint for. Calls are never sent to another region instead.
+var ErrUnknownRegion = errors.New("no endpoint for data region")
+
+type regionKey struct{}
+
+// WithRegionEndpoints routes calls made with a context carrying a data
+// region, see WithRegion, to the base URL of that region's go-iam
+// deployment. Endpoints maps regions, e.g. "eu", to base URLs. Calls without
+// a region keep using the base URL given to NewService.
+func WithRegionEndpoints(endpoints map[string]string) Option {
+	return func(s *serviceImpl) {
+		s.regions = maps.Clone(endpoints)
+	}
+}
+
+// WithRegion returns a copy of ctx routing calls made with it to the
+// endpoint of the data region, typically Project.Region of the project the
+// call is about. Calls fail with ErrUnknownRegion if the service has no
+// endpoint for the region.
+func WithRegion(ctx context.Context, region string) context.Context {
+	return context.WithValue(ctx, regionKey{}, region)
+}
+
+// RegionFromContext returns the data region set on ctx with WithRegion.
+func RegionFromContext(ctx context.Context) string {
+	region, _ := ctx.Value(regionKey{}).(string)
+	return region
+}
+
+// endpoint returns the base URL serving the data region of ctx.
+func (s *serviceImpl) endpoint(ctx context.Context) (string, error) {
+	region := RegionFromContext(ctx)
+	if region == "" {
+		return s.baseURL, nil
+	}
+	u, ok := s.regions[region]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownRegion, region)
+	}
+	return u, nil
+}
@@ -0,0 +1,63 @@
+package golang
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRegionRouting(t *testing.T) {
+	newServer := func(region string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/project/v1/":
+				w.Write([]byte(`{"success":true,"data":{"id":"project-id","region":"` + region + `"}}`))
+			default:
+				w.Write([]byte(`{"success":true,"data":{"id":"user-id","name":"` + region + `"}}`))
+			}
+		}))
+	}
+	global, eu := newServer("global"), newServer("eu")
+	defer global.Close()
+	defer eu.Close()
+
+	service := NewService(global.URL, "client-id", "secret", WithRegionEndpoints(map[string]string{"eu": eu.URL}))
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		served string
+		err    error
+	}{
+		{"No Region", ctx, "global", nil},
+		{"Known Region", WithRegion(ctx, "eu"), "eu", nil},
+		{"Unknown Region", WithRegion(ctx, "ap"), "", ErrUnknownRegion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := service.Me(tt.ctx, "valid-token")
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+			if err == nil && user.Name != tt.served {
+				t.Fatalf("expected the call to be served by %s, got %s", tt.served, user.Name)
+			}
+		})
+	}
+
+	t.Run("Create Project", func(t *testing.T) {
+		project := &Project{Name: "EU tenant", Region: "eu"}
+		if err := service.CreateProject(ctx, project, "valid-token"); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if project.Region != "eu" {
+			t.Fatalf("expected the project to be created in eu, got %q", project.Region)
+		}
+		if err := service.CreateProject(ctx, &Project{Region: "ap"}, "valid-token"); !errors.Is(err, ErrUnknownRegion) {
+			t.Fatalf("expected ErrUnknownRegion, got %v", err)
+		}
+	})
+}
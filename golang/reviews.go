@@ -0,0 +1,153 @@
+package golang
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ReviewRecurrence is how often an access review campaign repeats.
+type ReviewRecurrence string
+
+const (
+	ReviewOnce      ReviewRecurrence = ""
+	ReviewMonthly   ReviewRecurrence = "monthly"
+	ReviewQuarterly ReviewRecurrence = "quarterly"
+	ReviewYearly    ReviewRecurrence = "yearly"
+)
+
+// ReviewDecision is a reviewer's decision on a review item.
+type ReviewDecision string
+
+const (
+	// ReviewPending marks an item that has not been decided yet.
+	ReviewPending ReviewDecision = ""
+	// ReviewCertify keeps the access in place.
+	ReviewCertify ReviewDecision = "certify"
+	// ReviewRevoke removes the access from the user.
+	ReviewRevoke ReviewDecision = "revoke"
+)
+
+// ReviewCampaign is a periodic access review, e.g. for SOC2. When a campaign
+// runs, every grant of the resources and roles in scope becomes a ReviewItem
+// that a reviewer must certify or revoke before the campaign is due.
+type ReviewCampaign struct {
+	Id           string           `json:"id"`                   // Unique identifier of the campaign
+	ProjectId    string           `json:"project_id"`           // Project the campaign belongs to
+	Name         string           `json:"name"`                 // Display name of the campaign
+	Description  string           `json:"description"`          // Description of the campaign's purpose
+	ResourceKeys []string         `json:"resource_keys"`        // Resources whose grants are reviewed
+	RoleIds      []string         `json:"role_ids"`             // Roles whose assignments are reviewed
+	ReviewerIds  []string         `json:"reviewer_ids"`         // Users who review the items
+	StartsAt     *time.Time       `json:"starts_at"`            // When the first run starts
+	DurationDays int              `json:"duration_days"`        // Days reviewers have to decide each run
+	Recurrence   ReviewRecurrence `json:"recurrence,omitempty"` // How often the campaign repeats
+	CreatedAt    *time.Time       `json:"created_at"`           // Timestamp when the campaign was created
+	CreatedBy    string           `json:"created_by"`           // ID of the user who created the campaign
+}
+
+// ReviewItem is a single grant under review in a campaign run.
+type ReviewItem struct {
+	Id          string         `json:"id"`                   // Unique identifier of the item
+	CampaignId  string         `json:"campaign_id"`          // Campaign the item belongs to
+	UserId      string         `json:"user_id"`              // User holding the access
+	ResourceKey string         `json:"resource_key"`         // Resource granted, if the item is a resource grant
+	RoleId      string         `json:"role_id"`              // Role assigned, if the item is a role assignment
+	ReviewerId  string         `json:"reviewer_id"`          // User expected to decide the item
+	Decision    ReviewDecision `json:"decision"`             // Decision recorded on the item
+	Comment     string         `json:"comment,omitempty"`    // Comment left with the decision
+	DueAt       *time.Time     `json:"due_at"`               // When the item must be decided
+	DecidedAt   *time.Time     `json:"decided_at,omitempty"` // When the decision was recorded
+}
+
+type reviewDecisionInput struct {
+	Decision ReviewDecision `json:"decision"`
+	Comment  string         `json:"comment,omitempty"`
+}
+
+type ReviewCampaignResponse struct {
+	Success bool            `json:"success"`
+	Message string          `json:"message"`
+	Data    *ReviewCampaign `json:"data,omitempty"`
+}
+
+type ReviewItemResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    *ReviewItem `json:"data,omitempty"`
+}
+
+type ReviewItemsResponse struct {
+	Success bool         `json:"success"`
+	Message string       `json:"message"`
+	Data    []ReviewItem `json:"data,omitempty"`
+}
+
+// CreateReviewCampaign schedules a new access review campaign.
+// Campaign argument will be updated with the created campaign details.
+func (s *serviceImpl) CreateReviewCampaign(ctx context.Context, campaign *ReviewCampaign, token string) error {
+	if campaign == nil {
+		return fmt.Errorf("review campaign cannot be nil")
+	}
+
+	result := ReviewCampaignResponse{}
+	if _, err := s.call(ctx, apiRequest{
+		method: http.MethodPost,
+		path:   "/review/v1/campaign",
+		body:   campaign,
+		token:  token,
+		action: "create review campaign",
+	}, &result); err != nil {
+		return err
+	}
+
+	if result.Data != nil {
+		*campaign = *result.Data
+	}
+
+	return nil
+}
+
+// ListPendingReviewItems fetches the undecided items of the campaign's current run.
+func (s *serviceImpl) ListPendingReviewItems(ctx context.Context, campaignID string, token string) ([]ReviewItem, error) {
+	result := ReviewItemsResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodGet,
+		path:   "/review/v1/campaign/" + url.PathEscape(campaignID) + "/items",
+		query:  url.Values{"status": {"pending"}},
+		token:  token,
+		action: "list pending review items",
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	capturePagination(ctx, listPagination(resp, len(result.Data)))
+
+	return result.Data, nil
+}
+
+// RecordReviewDecision certifies or revokes the access under review in the item.
+func (s *serviceImpl) RecordReviewDecision(ctx context.Context, itemID string, decision ReviewDecision, comment string, token string) (*ReviewItem, error) {
+	if decision != ReviewCertify && decision != ReviewRevoke {
+		return nil, fmt.Errorf("invalid review decision %q", decision)
+	}
+
+	result := ReviewItemResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodPost,
+		path:   "/review/v1/item/" + url.PathEscape(itemID) + "/decision",
+		body:   reviewDecisionInput{Decision: decision, Comment: comment},
+		token:  token,
+		action: "record review decision",
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Data == nil {
+		return nil, fmt.Errorf("failed to record review decision: empty response. Status: %s", resp.Status)
+	}
+
+	return result.Data, nil
+}
@@ -0,0 +1,71 @@
+package golang
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReviewCampaign(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer valid-token" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"success":false,"message":"Forbidden"}`))
+			return
+		}
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/review/v1/campaign":
+			var payload ReviewCampaign
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil || payload.Recurrence != ReviewQuarterly {
+				t.Fatalf("unexpected campaign payload: %+v, %v", payload, err)
+			}
+			w.Write([]byte(`{"success":true,"data":{"id":"campaign-id","name":"Q3 review","recurrence":"quarterly"}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/review/v1/campaign/campaign-id/items":
+			if r.URL.Query().Get("status") != "pending" {
+				t.Fatalf("expected pending filter, got %q", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"success":true,"data":[{"id":"item-id","campaign_id":"campaign-id","user_id":"user-id","resource_key":"billing:read"}]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/review/v1/item/item-id/decision":
+			var payload reviewDecisionInput
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil || payload.Decision != ReviewRevoke {
+				t.Fatalf("unexpected decision payload: %+v, %v", payload, err)
+			}
+			w.Write([]byte(`{"success":true,"data":{"id":"item-id","decision":"revoke","comment":"left the team"}}`))
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret")
+	ctx := context.Background()
+
+	campaign := &ReviewCampaign{Name: "Q3 review", ResourceKeys: []string{"billing:read"}, Recurrence: ReviewQuarterly}
+	if err := service.CreateReviewCampaign(ctx, campaign, "valid-token"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if campaign.Id != "campaign-id" {
+		t.Fatalf("expected campaign ID to be set, got %+v", campaign)
+	}
+
+	items, err := service.ListPendingReviewItems(ctx, campaign.Id, "valid-token")
+	if err != nil || len(items) != 1 || items[0].Decision != ReviewPending {
+		t.Fatalf("expected one pending item, got %+v, %v", items, err)
+	}
+
+	item, err := service.RecordReviewDecision(ctx, items[0].Id, ReviewRevoke, "left the team", "valid-token")
+	if err != nil || item.Decision != ReviewRevoke {
+		t.Fatalf("expected revoked item, got %+v, %v", item, err)
+	}
+
+	if _, err := service.RecordReviewDecision(ctx, "item-id", ReviewPending, "", "valid-token"); err == nil {
+		t.Fatal("expected an error for an invalid decision, got none")
+	}
+	if err := service.CreateReviewCampaign(ctx, &ReviewCampaign{}, "invalid-token"); err == nil {
+		t.Fatal("expected an error, got none")
+	}
+}
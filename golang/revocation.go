@@ -0,0 +1,65 @@
+package golang
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ErrEmptyTokenFilter is returned by RevokeTokens for a filter selecting no
+// tokens, which would otherwise revoke every token of the project.
+var ErrEmptyTokenFilter = errors.New("token filter must select by user, client or issue time")
+
+// TokenFilter selects the tokens revoked by RevokeTokens. Tokens must match
+// every field that is set, and at least one must be.
+type TokenFilter struct {
+	UserId       string     `json:"user_id,omitempty"`       // Only tokens of this user
+	ClientId     string     `json:"client_id,omitempty"`     // Only tokens minted for this client
+	IssuedBefore *time.Time `json:"issued_before,omitempty"` // Only tokens issued before this time
+}
+
+// TokenRevocation is the outcome of RevokeTokens.
+type TokenRevocation struct {
+	Filter    TokenFilter `json:"filter"`     // Filter the tokens were selected with
+	Revoked   int         `json:"revoked"`    // Number of tokens revoked
+	RevokedAt *time.Time  `json:"revoked_at"` // When the tokens were revoked
+	RevokedBy string      `json:"revoked_by"` // ID of the user who revoked the tokens
+}
+
+type TokenRevocationResponse struct {
+	Success bool             `json:"success"`
+	Message string           `json:"message"`
+	Data    *TokenRevocation `json:"data,omitempty"`
+}
+
+// RevokeTokens revokes every token matching the filter, e.g. all the tokens
+// minted for a client whose secret leaked. Calls made with a revoked token
+// fail with ErrUnauthorized. The service's cache is cleared, since it may hold
+// objects fetched with revoked tokens.
+func (s *serviceImpl) RevokeTokens(ctx context.Context, filter TokenFilter, token string) (*TokenRevocation, error) {
+	if filter.UserId == "" && filter.ClientId == "" && filter.IssuedBefore == nil {
+		return nil, ErrEmptyTokenFilter
+	}
+
+	defer s.purge(ctx)
+
+	result := TokenRevocationResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodPost,
+		path:   "/auth/v1/tokens/revoke",
+		body:   filter,
+		token:  token,
+		action: "revoke tokens",
+		retry:  retrySafe,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Data == nil {
+		return nil, fmt.Errorf("failed to revoke tokens: empty response. Status: %s", resp.Status)
+	}
+
+	return result.Data, nil
+}
@@ -0,0 +1,73 @@
+package golang
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRevokeTokens(t *testing.T) {
+	revoked := false
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer valid-token" || (revoked && r.Method == http.MethodGet) {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"success":false,"message":"Invalid token"}`))
+			return
+		}
+		switch r.URL.Path {
+		case "/auth/v1/tokens/revoke":
+			var filter TokenFilter
+			if err := json.NewDecoder(r.Body).Decode(&filter); err != nil || filter.ClientId != "leaked-client" || filter.IssuedBefore == nil {
+				t.Errorf("unexpected filter %+v, %v", filter, err)
+			}
+			revoked = true
+			w.Write([]byte(`{"success":true,"data":{"filter":{"client_id":"leaked-client"},"revoked":42,"revoked_by":"admin-id"}}`))
+		case "/resource/v1/resource-id":
+			w.Write([]byte(`{"success":true,"data":{"id":"resource-id"}}`))
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret", WithCache(CacheTTLs{}))
+	ctx := context.Background()
+
+	t.Run("Empty Filter", func(t *testing.T) {
+		if _, err := service.RevokeTokens(ctx, TokenFilter{}, "valid-token"); !errors.Is(err, ErrEmptyTokenFilter) {
+			t.Fatalf("expected ErrEmptyTokenFilter, got %v", err)
+		}
+	})
+
+	t.Run("Invalid Token", func(t *testing.T) {
+		if _, err := service.RevokeTokens(ctx, TokenFilter{UserId: "user-id"}, "invalid-token"); !errors.Is(err, ErrUnauthorized) {
+			t.Fatalf("expected ErrUnauthorized, got %v", err)
+		}
+	})
+
+	t.Run("Valid Token", func(t *testing.T) {
+		if _, err := service.GetResource(ctx, "resource-id", "valid-token"); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		now := time.Now()
+		revocation, err := service.RevokeTokens(ctx, TokenFilter{ClientId: "leaked-client", IssuedBefore: &now}, "valid-token")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if revocation.Revoked != 42 || revocation.RevokedBy != "admin-id" {
+			t.Fatalf("unexpected revocation %+v", revocation)
+		}
+
+		// The resource cached before the revocation must not be served anymore.
+		if _, err := service.GetResource(ctx, "resource-id", "valid-token"); !errors.Is(err, ErrUnauthorized) {
+			t.Fatalf("expected ErrUnauthorized once the cache is cleared, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,82 @@
+package golang
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// RiskLevel grades how likely a login is to be malicious.
+type RiskLevel string
+
+const (
+	RiskNone   RiskLevel = "none"
+	RiskLow    RiskLevel = "low"
+	RiskMedium RiskLevel = "medium"
+	RiskHigh   RiskLevel = "high"
+)
+
+var riskLevelOrder = map[RiskLevel]int{RiskNone: 0, RiskLow: 1, RiskMedium: 2, RiskHigh: 3}
+
+// AtLeast reports whether l is as severe as or more severe than min.
+// Unknown levels are treated as RiskNone.
+func (l RiskLevel) AtLeast(min RiskLevel) bool {
+	return riskLevelOrder[l] >= riskLevelOrder[min]
+}
+
+// RiskSignalType identifies the kind of anomaly go-iam detected.
+type RiskSignalType string
+
+const (
+	RiskNewDevice        RiskSignalType = "new_device"
+	RiskNewLocation      RiskSignalType = "new_location"
+	RiskImpossibleTravel RiskSignalType = "impossible_travel"
+	RiskFailedLogins     RiskSignalType = "failed_logins"
+)
+
+// RiskSignal is a single anomaly detected on a user's logins.
+type RiskSignal struct {
+	Id          string         `json:"id"`          // Unique identifier of the signal
+	Type        RiskSignalType `json:"type"`        // Kind of anomaly
+	Level       RiskLevel      `json:"level"`       // Severity of the anomaly
+	Description string         `json:"description"` // Human readable explanation
+	IP          string         `json:"ip"`          // IP address of the login that raised the signal
+	Location    string         `json:"location"`    // Approximate location of the IP address
+	Device      string         `json:"device"`      // Device or user agent of the login
+	DetectedAt  *time.Time     `json:"detected_at"` // When the anomaly was detected
+}
+
+// RiskAssessment is the current risk of a user and the signals behind it.
+type RiskAssessment struct {
+	UserId  string       `json:"user_id"` // User the assessment is about
+	Level   RiskLevel    `json:"level"`   // Overall risk, the highest level of the signals
+	Signals []RiskSignal `json:"signals"` // Recent anomalies, most recent first
+}
+
+type RiskAssessmentResponse struct {
+	Success bool            `json:"success"`
+	Message string          `json:"message"`
+	Data    *RiskAssessment `json:"data,omitempty"`
+}
+
+// GetRiskSignals fetches the login anomalies detected for the user with the
+// provided ID, e.g. to step up authentication when the risk is high.
+func (s *serviceImpl) GetRiskSignals(ctx context.Context, userID string, token string) (*RiskAssessment, error) {
+	result := RiskAssessmentResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodGet,
+		path:   "/user/v1/" + url.PathEscape(userID) + "/risk",
+		token:  token,
+		action: "fetch risk signals",
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Data == nil {
+		return nil, fmt.Errorf("failed to fetch risk signals: empty response. Status: %s", resp.Status)
+	}
+
+	return result.Data, nil
+}
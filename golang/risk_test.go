@@ -0,0 +1,62 @@
+package golang
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetRiskSignals(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/user/v1/user-id/risk" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") == "Bearer valid-token" {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"success":true,"data":{"user_id":"user-id","level":"high","signals":[{"id":"signal-id","type":"impossible_travel","level":"high","ip":"203.0.113.7"}]}}`))
+		} else {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"success":false,"message":"Invalid token"}`))
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret")
+
+	t.Run("Valid Token", func(t *testing.T) {
+		risk, err := service.GetRiskSignals(context.Background(), "user-id", "valid-token")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !risk.Level.AtLeast(RiskMedium) || len(risk.Signals) != 1 || risk.Signals[0].Type != RiskImpossibleTravel {
+			t.Fatalf("unexpected assessment: %+v", risk)
+		}
+	})
+
+	t.Run("Invalid Token", func(t *testing.T) {
+		if _, err := service.GetRiskSignals(context.Background(), "user-id", "invalid-token"); err == nil {
+			t.Fatal("expected an error, got none")
+		}
+	})
+}
+
+func TestRiskLevelAtLeast(t *testing.T) {
+	tests := []struct {
+		level, min RiskLevel
+		want       bool
+	}{
+		{RiskHigh, RiskMedium, true},
+		{RiskMedium, RiskMedium, true},
+		{RiskLow, RiskMedium, false},
+		{"", RiskLow, false},
+		{"", RiskNone, true},
+	}
+	for _, tt := range tests {
+		if got := tt.level.AtLeast(tt.min); got != tt.want {
+			t.Errorf("%q.AtLeast(%q) = %v, want %v", tt.level, tt.min, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package golang
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Role groups resources that are granted together to the users holding the role.
+type Role struct {
+	Id          string                  `json:"id"`                    // Unique identifier for the role
+	ProjectId   string                  `json:"project_id"`            // Project the role belongs to
+	OrgId       string                  `json:"org_id,omitempty"`      // Organization of an org-level role, spanning its projects
+	ExternalId  string                  `json:"external_id,omitempty"` // Caller-assigned immutable identifier, unique per project
+	Name        string                  `json:"name"`                  // Display name of the role
+	Description string                  `json:"description"`           // Description of the role's purpose
+	Enabled     bool                    `json:"enabled"`               // Whether the role is active
+	Resources   map[string]RoleResource `json:"resources"`             // Resources granted by the role, keyed by resource ID
+	CreatedAt   *time.Time              `json:"created_at"`            // Timestamp when role was created
+	CreatedBy   string                  `json:"created_by"`            // ID of the user who created this role
+	UpdatedAt   *time.Time              `json:"updated_at"`            // Timestamp when role was last updated
+	UpdatedBy   string                  `json:"updated_by"`            // ID of the user who last updated this role
+}
+
+// RoleResource is a resource granted by a role.
+type RoleResource struct {
+	Id   string `json:"id"`   // ID of the resource
+	Key  string `json:"key"`  // Key of the resource
+	Name string `json:"name"` // Name of the resource
+}
+
+// ListRolesQuery filters and paginates ListRoles.
+type ListRolesQuery struct {
+	Name  string // Only roles whose name contains Name
+	Page  int    // 1-based page number, the first page if zero
+	Limit int    // Maximum number of roles per page, the server default if zero
+}
+
+// RoleList is a page of roles returned by ListRoles.
+type RoleList struct {
+	Roles []Role `json:"roles"` // Roles on this page
+	Pagination
+}
+
+type RoleResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	Data    *Role  `json:"data,omitempty"`
+}
+
+type RoleListResponse struct {
+	Success bool      `json:"success"`
+	Message string    `json:"message"`
+	Data    *RoleList `json:"data,omitempty"`
+}
+
+// CreateRole creates a new role with the provided details and token.
+// Role argument will be updated with the created role details.
+func (s *serviceImpl) CreateRole(ctx context.Context, role *Role, token string) error {
+	if role == nil {
+		return fmt.Errorf("role cannot be nil")
+	}
+
+	result := RoleResponse{}
+	if _, err := s.call(ctx, apiRequest{
+		method: http.MethodPost,
+		path:   "/role/v1/",
+		body:   role,
+		token:  token,
+		action: "create role",
+	}, &result); err != nil {
+		return err
+	}
+
+	if result.Data != nil {
+		*role = *result.Data
+	}
+
+	return nil
+}
+
+// UpdateRole updates the role identified by role.Id with the provided details.
+// Role argument will be updated with the stored role details.
+func (s *serviceImpl) UpdateRole(ctx context.Context, role *Role, token string) error {
+	if role == nil {
+		return fmt.Errorf("role cannot be nil")
+	}
+	if role.Id == "" {
+		return fmt.Errorf("role ID cannot be empty")
+	}
+
+	defer s.invalidate(cacheRoles, role.Id)
+
+	result := RoleResponse{}
+	if _, err := s.call(ctx, apiRequest{
+		method: http.MethodPut,
+		path:   "/role/v1/" + url.PathEscape(role.Id),
+		body:   role,
+		token:  token,
+		action: "update role",
+	}, &result); err != nil {
+		return err
+	}
+
+	if result.Data != nil {
+		*role = *result.Data
+	}
+
+	return nil
+}
+
+// GetRole fetches the role with the provided ID.
+// The result is cached if the service has a cache, see WithCache.
+func (s *serviceImpl) GetRole(ctx context.Context, id string, token string) (*Role, error) {
+	return cached(ctx, s, cacheRoles, id, token, func() (*Role, error) {
+		return s.getRole(ctx, id, token)
+	})
+}
+
+func (s *serviceImpl) getRole(ctx context.Context, id string, token string) (*Role, error) {
+	result := RoleResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodGet,
+		path:   "/role/v1/" + url.PathEscape(id),
+		token:  token,
+		action: "fetch role",
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Data == nil {
+		return nil, fmt.Errorf("failed to fetch role: empty response. Status: %s", resp.Status)
+	}
+
+	return result.Data, nil
+}
+
+// ListRoles searches the roles matching the query, one page at a time.
+func (s *serviceImpl) ListRoles(ctx context.Context, query ListRolesQuery, token string) (*RoleList, error) {
+	result := RoleListResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodGet,
+		path:   "/role/v1/search",
+		query:  query.values(),
+		token:  token,
+		action: "list roles",
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Data == nil {
+		return nil, fmt.Errorf("failed to list roles: empty response. Status: %s", resp.Status)
+	}
+	result.Data.complete(resp, query.Page, query.Limit, len(result.Data.Roles))
+	capturePagination(ctx, result.Data.Pagination)
+
+	return result.Data, nil
+}
+
+// AddResourceToRole grants the resource to every user holding the role.
+func (s *serviceImpl) AddResourceToRole(ctx context.Context, roleID string, resource RoleResource, token string) error {
+	defer s.invalidate(cacheRoles, roleID)
+
+	result := RoleResponse{}
+	if _, err := s.call(ctx, apiRequest{
+		method: http.MethodPost,
+		path:   "/role/v1/" + url.PathEscape(roleID) + "/resource",
+		body:   resource,
+		token:  token,
+		action: "add resource to role",
+		retry:  retrySafe,
+	}, &result); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RemoveResourceFromRole revokes the resource from the role.
+func (s *serviceImpl) RemoveResourceFromRole(ctx context.Context, roleID string, resourceID string, token string) error {
+	defer s.invalidate(cacheRoles, roleID)
+
+	result := RoleResponse{}
+	if _, err := s.call(ctx, apiRequest{
+		method: http.MethodDelete,
+		path:   "/role/v1/" + url.PathEscape(roleID) + "/resource/" + url.PathEscape(resourceID),
+		token:  token,
+		action: "remove resource from role",
+	}, &result); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (q ListRolesQuery) values() url.Values {
+	v := url.Values{}
+	if q.Name != "" {
+		v.Set("name", q.Name)
+	}
+	if q.Page > 0 {
+		v.Set("page", strconv.Itoa(q.Page))
+	}
+	if q.Limit > 0 {
+		v.Set("limit", strconv.Itoa(q.Limit))
+	}
+	return v
+}
@@ -0,0 +1,118 @@
+package golang
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateRole(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/role/v1/" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var payload Role
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("expected valid role payload, got %v", err)
+		}
+		if payload.Name != "Billing Admin" {
+			t.Fatalf("unexpected role payload: %+v", payload)
+		}
+		if r.Header.Get("Authorization") == "Bearer valid-token" {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"success":true,"data":{"id":"role-id","name":"Billing Admin","enabled":true}}`))
+		} else {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"success":false,"message":"Invalid token"}`))
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret")
+
+	t.Run("Valid Token", func(t *testing.T) {
+		role := &Role{Name: "Billing Admin"}
+		if err := service.CreateRole(context.Background(), role, "valid-token"); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if role.Id != "role-id" {
+			t.Fatalf("expected role ID to be 'role-id', got %v", role.Id)
+		}
+	})
+
+	t.Run("Invalid Token", func(t *testing.T) {
+		if err := service.CreateRole(context.Background(), &Role{Name: "Billing Admin"}, "invalid-token"); err == nil {
+			t.Fatal("expected an error, got none")
+		}
+	})
+}
+
+func TestListRoles(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/role/v1/search" || r.URL.Query().Get("name") != "admin" {
+			t.Fatalf("unexpected request %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		if r.Header.Get("Authorization") == "Bearer valid-token" {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"success":true,"data":{"roles":[{"id":"role-id","name":"admin","resources":{"resource-id":{"id":"resource-id","key":"billing:read"}}}],"total":1}}`))
+		} else {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"success":false,"message":"Invalid token"}`))
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret")
+
+	t.Run("Valid Token", func(t *testing.T) {
+		list, err := service.ListRoles(context.Background(), ListRolesQuery{Name: "admin"}, "valid-token")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(list.Roles) != 1 || list.Roles[0].Resources["resource-id"].Key != "billing:read" {
+			t.Fatalf("unexpected role list: %+v", list)
+		}
+	})
+
+	t.Run("Invalid Token", func(t *testing.T) {
+		if _, err := service.ListRoles(context.Background(), ListRolesQuery{Name: "admin"}, "invalid-token"); err == nil {
+			t.Fatal("expected an error, got none")
+		}
+	})
+}
+
+func TestRoleResources(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/role/v1/role-id/resource":
+			var payload RoleResource
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil || payload.Key != "billing:read" {
+				t.Fatalf("unexpected resource payload: %+v, %v", payload, err)
+			}
+		case r.Method == http.MethodDelete && r.URL.Path == "/role/v1/role-id/resource/resource-id":
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"success":true,"data":{"id":"role-id"}}`))
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret")
+
+	resource := RoleResource{Id: "resource-id", Key: "billing:read", Name: "Read billing"}
+	if err := service.AddResourceToRole(context.Background(), "role-id", resource, "valid-token"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := service.RemoveResourceFromRole(context.Background(), "role-id", "resource-id", "valid-token"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+package golang
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// defaultScanPageSize is the page size ScanUsers and ScanResources fetch
+// with when ScanOptions.PageSize is not set.
+const defaultScanPageSize = 100
+
+// ScanOptions configures ScanUsers and ScanResources.
+type ScanOptions struct {
+	Cursor   string // Cursor saved by an interrupted scan to resume from, the first item if empty
+	PageSize int    // Number of items fetched per call, 100 if zero
+
+	// Checkpoint persists the cursor of the scan, e.g. in a database, for
+	// the next run to resume from with Cursor. It is called once every item
+	// delivered so far has been processed, and with an empty cursor once the
+	// scan is complete. An error stops the scan.
+	Checkpoint func(ctx context.Context, cursor string) error
+}
+
+// ScanUsers iterates over every user of the project in ID order, for sync
+// jobs that may be interrupted:
+//
+//	for user, err := range service.ScanUsers(ctx, golang.ScanOptions{Cursor: saved, Checkpoint: save}, token) {
+//		if err != nil {
+//			return err
+//		}
+//		// process user
+//	}
+//
+// Users are fetched a page at a time with SearchUsers, paginated by ID
+// rather than by page number, so that users deleted during the scan do not
+// shift later users out of it. The cursor is only advanced past a page once
+// the loop body returned for every user of it, so a run resumed from a saved
+// cursor delivers every user existing throughout both runs at least once;
+// users of the interrupted page are delivered again. Users created during a
+// scan may be missed. A failed call ends the iteration with the error.
+func (s *serviceImpl) ScanUsers(ctx context.Context, opts ScanOptions, token string) iter.Seq2[User, error] {
+	ctx = scopeIdempotencyKey(ctx, "")
+	return scan(ctx, opts, func(u User) string { return u.Id }, func(after string, limit int) ([]User, error) {
+		list, err := s.SearchUsers(ctx, SearchUsersQuery{After: after, Limit: limit}, token)
+		if err != nil {
+			return nil, err
+		}
+		return list.Users, nil
+	})
+}
+
+// ScanResources iterates over every resource of the project in ID order,
+// with the guarantees of ScanUsers.
+func (s *serviceImpl) ScanResources(ctx context.Context, opts ScanOptions, token string) iter.Seq2[Resource, error] {
+	ctx = scopeIdempotencyKey(ctx, "")
+	return scan(ctx, opts, func(r Resource) string { return r.ID }, func(after string, limit int) ([]Resource, error) {
+		list, err := s.ListResources(ctx, ListResourcesQuery{After: after, Limit: limit}, token)
+		if err != nil {
+			return nil, err
+		}
+		return list.Resources, nil
+	})
+}
+
+// scan iterates over the pages returned by list, each starting after the ID
+// of the last item of the previous one, until an empty page is returned. A
+// short page does not end the scan since servers may cap the page size.
+func scan[T any](ctx context.Context, opts ScanOptions, id func(T) string, list func(after string, limit int) ([]T, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		limit := opts.PageSize
+		if limit <= 0 {
+			limit = defaultScanPageSize
+		}
+		cursor := opts.Cursor
+		for {
+			items, err := list(cursor, limit)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			cursor = ""
+			if len(items) > 0 {
+				cursor = id(items[len(items)-1])
+			}
+			if opts.Checkpoint != nil {
+				if err := opts.Checkpoint(ctx, cursor); err != nil {
+					yield(zero, fmt.Errorf("failed to save scan checkpoint: %w", err))
+					return
+				}
+			}
+			if cursor == "" {
+				return
+			}
+		}
+	}
+}
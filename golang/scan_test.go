@@ -0,0 +1,126 @@
+package golang
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestScanUsers(t *testing.T) {
+	var mu sync.Mutex
+	var ids []string
+	for i := 1; i <= 7; i++ {
+		ids = append(ids, fmt.Sprintf("user-%d", i))
+	}
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.URL.Path != "/user/v1/search" || r.URL.Query().Get("page") != "" {
+			t.Fatalf("unexpected request %s", r.URL)
+		}
+		// The server caps pages at 2 users, whatever the requested limit.
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		users := []User{}
+		for _, id := range ids {
+			if id > r.URL.Query().Get("after") && len(users) < min(limit, 2) {
+				users = append(users, User{Id: id})
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"success": true, "data": UserList{Users: users}})
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret")
+	ctx := context.Background()
+	var saved []string
+	opts := ScanOptions{PageSize: 3, Checkpoint: func(ctx context.Context, cursor string) error {
+		saved = append(saved, cursor)
+		return nil
+	}}
+
+	// The first run is interrupted while processing user-3, and user-2 is
+	// deleted before the next run.
+	var seen []string
+	for user, err := range service.ScanUsers(ctx, opts, "token") {
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if user.Id == "user-3" {
+			break
+		}
+		seen = append(seen, user.Id)
+	}
+	if !slices.Equal(saved, []string{"user-2"}) {
+		t.Fatalf("expected the cursor to stay before the interrupted page, got %v", saved)
+	}
+	mu.Lock()
+	ids = slices.DeleteFunc(ids, func(id string) bool { return id == "user-2" })
+	mu.Unlock()
+
+	opts.Cursor = saved[len(saved)-1]
+	for user, err := range service.ScanUsers(ctx, opts, "token") {
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		seen = append(seen, user.Id)
+	}
+	want := []string{"user-1", "user-2", "user-3", "user-4", "user-5", "user-6", "user-7"}
+	if !slices.Equal(seen, want) {
+		t.Fatalf("expected every user once across both runs, got %v", seen)
+	}
+	if saved[len(saved)-1] != "" {
+		t.Fatalf("expected the cursor to be reset once complete, got %v", saved)
+	}
+
+	failing := errors.New("database unavailable")
+	opts = ScanOptions{Checkpoint: func(ctx context.Context, cursor string) error { return failing }}
+	var n int
+	for _, err := range service.ScanUsers(ctx, opts, "token") {
+		if err != nil {
+			if !errors.Is(err, failing) || n != 2 {
+				t.Fatalf("expected the checkpoint error after the first page, got %v after %d users", err, n)
+			}
+			break
+		}
+		n++
+	}
+}
+
+func TestScanResources(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("after") {
+		case "":
+			w.Write([]byte(`{"success":true,"data":{"resources":[{"id":"id-1"},{"id":"id-2"}]}}`))
+		case "id-2":
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"success":false,"message":"Internal error"}`))
+		default:
+			t.Errorf("unexpected cursor %q", r.URL.Query().Get("after"))
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	var ids []string
+	var errs []error
+	for resource, err := range NewService(ts.URL, "client-id", "secret").ScanResources(context.Background(), ScanOptions{PageSize: 2}, "token") {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		ids = append(ids, resource.ID)
+	}
+	if !slices.Equal(ids, []string{"id-1", "id-2"}) || len(errs) != 1 || !errors.Is(errs[0], ErrServer) {
+		t.Fatalf("expected both resources and then the error, got %v and %v", ids, errs)
+	}
+}
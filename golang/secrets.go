@@ -0,0 +1,120 @@
+package golang
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ClientSecret is one of the secrets a client authenticates with. A client
+// may hold several secrets at once, which allows rotating them without
+// downtime: add a new secret, roll it out, then retire the old one with an
+// overlap window long enough for the rollout to finish.
+type ClientSecret struct {
+	Id         string     `json:"id"`                    // Unique identifier of the secret
+	ClientId   string     `json:"client_id"`             // Client the secret authenticates
+	ProjectIds []string   `json:"project_ids,omitempty"` // Projects the secret is scoped to, every project of the client if empty
+	Secret     string     `json:"secret,omitempty"`      // The secret itself, only returned by AddClientSecret
+	Hint       string     `json:"hint"`                  // Last characters of the secret, to tell secrets apart
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`  // When a retired secret stops being accepted
+	CreatedAt  *time.Time `json:"created_at"`            // Timestamp when the secret was added
+	CreatedBy  string     `json:"created_by"`            // ID of the user who added the secret
+	RetiredAt  *time.Time `json:"retired_at,omitempty"`  // Timestamp when the secret was retired
+	RetiredBy  string     `json:"retired_by,omitempty"`  // ID of the user who retired the secret
+}
+
+type clientSecretInput struct {
+	ProjectIds []string `json:"project_ids,omitempty"`
+}
+
+type retireSecretInput struct {
+	OverlapSeconds int64 `json:"overlap_seconds"`
+}
+
+type ClientSecretResponse struct {
+	Success bool          `json:"success"`
+	Message string        `json:"message"`
+	Data    *ClientSecret `json:"data,omitempty"`
+}
+
+type ClientSecretsResponse struct {
+	Success bool           `json:"success"`
+	Message string         `json:"message"`
+	Data    []ClientSecret `json:"data,omitempty"`
+}
+
+// Active reports whether the secret is accepted at the given time.
+func (c ClientSecret) Active(at time.Time) bool {
+	return c.ExpiresAt == nil || at.Before(*c.ExpiresAt)
+}
+
+// ListClientSecrets fetches the secrets of the client with the provided ID,
+// including retired secrets still within their overlap window. The secrets
+// themselves are never returned, only their hints.
+func (s *serviceImpl) ListClientSecrets(ctx context.Context, clientID string, token string) ([]ClientSecret, error) {
+	result := ClientSecretsResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodGet,
+		path:   "/client/v1/" + url.PathEscape(clientID) + "/secrets",
+		token:  token,
+		action: "list client secrets",
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	capturePagination(ctx, listPagination(resp, len(result.Data)))
+
+	return result.Data, nil
+}
+
+// AddClientSecret adds a new secret to the client with the provided ID. The
+// secret is only accepted for the given projects, or for every project of
+// the client if projectIDs is empty. The returned ClientSecret is the only
+// one holding the secret itself, store it before discarding the result.
+func (s *serviceImpl) AddClientSecret(ctx context.Context, clientID string, projectIDs []string, token string) (*ClientSecret, error) {
+	result := ClientSecretResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodPost,
+		path:   "/client/v1/" + url.PathEscape(clientID) + "/secrets",
+		body:   clientSecretInput{ProjectIds: projectIDs},
+		token:  token,
+		action: "add client secret",
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Data == nil {
+		return nil, fmt.Errorf("failed to add client secret: empty response. Status: %s", resp.Status)
+	}
+
+	return result.Data, nil
+}
+
+// RetireClientSecret retires the secret with the provided ID. The secret
+// keeps being accepted for the overlap duration, so callers still using it
+// can switch to a newer secret, and is rejected immediately if overlap is
+// zero.
+func (s *serviceImpl) RetireClientSecret(ctx context.Context, clientID string, secretID string, overlap time.Duration, token string) (*ClientSecret, error) {
+	if overlap < 0 {
+		return nil, fmt.Errorf("overlap cannot be negative, got %v", overlap)
+	}
+
+	result := ClientSecretResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodPost,
+		path:   "/client/v1/" + url.PathEscape(clientID) + "/secrets/" + url.PathEscape(secretID) + "/retire",
+		body:   retireSecretInput{OverlapSeconds: int64(overlap / time.Second)},
+		token:  token,
+		action: "retire client secret",
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Data == nil {
+		return nil, fmt.Errorf("failed to retire client secret: empty response. Status: %s", resp.Status)
+	}
+
+	return result.Data, nil
+}
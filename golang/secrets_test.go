@@ -0,0 +1,70 @@
+package golang
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestClientSecrets(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer valid-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"success":false,"message":"Invalid token"}`))
+			return
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "GET /client/v1/client-1/secrets":
+			w.Write([]byte(`{"success":true,"data":[{"id":"secret-1","client_id":"client-1","hint":"abcd"},{"id":"secret-2","client_id":"client-1","hint":"wxyz"}]}`))
+		case "POST /client/v1/client-1/secrets":
+			var payload clientSecretInput
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil || !slices.Equal(payload.ProjectIds, []string{"project-1"}) {
+				t.Fatalf("unexpected payload %+v, %v", payload, err)
+			}
+			w.Write([]byte(`{"success":true,"data":{"id":"secret-3","client_id":"client-1","project_ids":["project-1"],"secret":"s3cr3t-9876","hint":"9876"}}`))
+		case "POST /client/v1/client-1/secrets/secret-1/retire":
+			var payload retireSecretInput
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil || payload.OverlapSeconds != 3600 {
+				t.Fatalf("unexpected payload %+v, %v", payload, err)
+			}
+			w.Write([]byte(`{"success":true,"data":{"id":"secret-1","client_id":"client-1","expires_at":"2030-01-01T00:00:00Z"}}`))
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret")
+	ctx := context.Background()
+
+	secrets, err := service.ListClientSecrets(ctx, "client-1", "valid-token")
+	if err != nil || len(secrets) != 2 || secrets[1].Hint != "wxyz" {
+		t.Fatalf("unexpected secrets %+v, %v", secrets, err)
+	}
+
+	added, err := service.AddClientSecret(ctx, "client-1", []string{"project-1"}, "valid-token")
+	if err != nil || added.Secret != "s3cr3t-9876" {
+		t.Fatalf("unexpected secret %+v, %v", added, err)
+	}
+
+	retired, err := service.RetireClientSecret(ctx, "client-1", "secret-1", time.Hour, "valid-token")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !retired.Active(time.Date(2029, 12, 31, 0, 0, 0, 0, time.UTC)) || retired.Active(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected the secret to be accepted until its expiry, got %+v", retired)
+	}
+
+	if _, err := service.RetireClientSecret(ctx, "client-1", "secret-1", -time.Second, "valid-token"); err == nil {
+		t.Fatal("expected an error for a negative overlap, got none")
+	}
+	if _, err := service.ListClientSecrets(ctx, "client-1", "invalid-token"); err == nil {
+		t.Fatal("expected an error, got none")
+	}
+}
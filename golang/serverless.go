@@ -0,0 +1,158 @@
+package golang
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// storeGenerationKey is the CacheStore key of the current generation of
+// entries, see purgeStored.
+const storeGenerationKey = "goiam:generation"
+
+// minStoreGenerationTTL is how long a generation is kept in a CacheStore at
+// least, longer if the user TTL of the cache is.
+const minStoreGenerationTTL = 24 * time.Hour
+
+// serverlessIdleConnTimeout closes idle connections before the 60 second
+// idle timeout of common load balancers, so a thawed function instance
+// rarely reuses a connection the server side already dropped.
+const serverlessIdleConnTimeout = 50 * time.Second
+
+// CacheStore persists cached results outside the process, e.g. in DynamoDB
+// or Redis, so they survive the cold starts of serverless functions and are
+// shared between instances. Keys never contain tokens in the clear.
+// Implementations must be safe for concurrent use.
+type CacheStore interface {
+	// Get returns the value stored under key, or ok false if there is none
+	// or it expired.
+	Get(ctx context.Context, key string) (value []byte, ok bool, err error)
+	// Set stores value under key for at least ttl. Values may be dropped
+	// earlier, but must not be returned after ttl.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// storedEntry is a cache entry as written to a CacheStore.
+type storedEntry struct {
+	Data    json.RawMessage `json:"data"`
+	Expires time.Time       `json:"expires"`
+}
+
+// WithCacheStore persists the Me results of the cache in store, so a new
+// process resolves tokens seen by earlier processes without a call until the
+// user TTL of the cache expires. It enables the cache with the default TTLs
+// if WithCache is not given. Only Me results are persisted. Purges of the
+// cache, by RevokeTokens, EraseUser, MergeUsers or EndSupportSession, also
+// invalidate every entry of store for all processes sharing it; the memory
+// caches of other processes keep their entries until they expire. Store
+// failures are ignored and fall back to calling the server.
+func WithCacheStore(store CacheStore) Option {
+	return func(s *serviceImpl) {
+		s.cacheStore = store
+	}
+}
+
+// WithServerless tunes the service for serverless functions such as AWS
+// Lambda, where every cold start would otherwise pay a TLS handshake plus a
+// Me call per token. It enables the cache, persisted in store if not nil,
+// see WithCacheStore, and, unless WithHTTPClient is given, sends requests
+// through a dedicated transport that keeps connections to the server alive
+// between invocations of a warm instance. Create the service once, outside
+// the function handler, so warm invocations reuse it.
+func WithServerless(store CacheStore) Option {
+	return func(s *serviceImpl) {
+		s.serverless = true
+		if store != nil {
+			s.cacheStore = store
+		}
+	}
+}
+
+// serverlessClient returns an HTTP client with its own keep-alive transport.
+func serverlessClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.IdleConnTimeout = serverlessIdleConnTimeout
+	transport.MaxIdleConnsPerHost = 8
+	return &http.Client{Transport: transport}
+}
+
+// storeKey returns the CacheStore key of a cache entry of the generation,
+// hashing the token.
+func storeKey(k cacheKey, generation string) string {
+	sum := sha256.Sum256([]byte(k.token))
+	key := "goiam:user:"
+	if generation != "" {
+		key += generation + ":"
+	}
+	return key + base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// storeGet reads key from the store, reporting failures as absent values.
+func (s *serviceImpl) storeGet(ctx context.Context, key string) ([]byte, bool, error) {
+	var value []byte
+	var ok bool
+	var err error
+	if perr := Protect("cache store", s.panicHandler, func() { value, ok, err = s.cacheStore.Get(ctx, key) }); perr != nil {
+		return nil, false, perr
+	}
+	return value, ok, err
+}
+
+// storeSet writes key to the store.
+func (s *serviceImpl) storeSet(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	var err error
+	if perr := Protect("cache store", s.panicHandler, func() { err = s.cacheStore.Set(ctx, key, value, ttl) }); perr != nil {
+		return perr
+	}
+	return err
+}
+
+// loadStored returns the entry persisted for k, also caching it in memory
+// until it expires, and the generation of the store to save a fetched entry
+// in. ok is false if the store holds no entry; generation is empty and
+// usable false if it cannot be read.
+func (s *serviceImpl) loadStored(ctx context.Context, k cacheKey) (data []byte, generation string, usable, ok bool) {
+	if s.cacheStore == nil || k.class != cacheUsers {
+		return nil, "", false, false
+	}
+	gen, _, err := s.storeGet(ctx, storeGenerationKey)
+	if err != nil {
+		return nil, "", false, false
+	}
+	value, found, err := s.storeGet(ctx, storeKey(k, string(gen)))
+	if err != nil || !found {
+		return nil, string(gen), err == nil, false
+	}
+	var entry storedEntry
+	if err := json.Unmarshal(value, &entry); err != nil || !s.cache.now().Before(entry.Expires) {
+		return nil, string(gen), true, false
+	}
+	s.cache.setUntil(k, entry.Data, entry.Expires)
+	return entry.Data, string(gen), true, true
+}
+
+// saveStored persists the entry cached in memory for k until expires in the
+// generation read before fetching it, so an entry fetched before a purge is
+// not visible after it.
+func (s *serviceImpl) saveStored(ctx context.Context, k cacheKey, generation string, data []byte, expires time.Time) {
+	value, err := json.Marshal(storedEntry{Data: data, Expires: expires})
+	if err != nil {
+		return
+	}
+	s.storeSet(ctx, storeKey(k, generation), value, expires.Sub(s.cache.now()))
+}
+
+// purgeStored invalidates every entry of the store by starting a new
+// generation: entries of earlier generations are no longer read and expire
+// with their TTL. The generation outlives the entries of the generation
+// before it, which would otherwise become visible again.
+func (s *serviceImpl) purgeStored(ctx context.Context) {
+	if s.cacheStore == nil {
+		return
+	}
+	ttl := max(minStoreGenerationTTL, s.cache.ttls[cacheUsers])
+	s.storeSet(context.WithoutCancel(ctx), storeGenerationKey, []byte(s.ids.NewID(IDNonce)), ttl)
+}
@@ -0,0 +1,122 @@
+package golang
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// mapStore is a CacheStore in memory, standing in for DynamoDB or Redis.
+type mapStore struct {
+	mu     sync.Mutex
+	values map[string][]byte
+}
+
+func (m *mapStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	v, ok := m.values[key]
+	return v, ok, nil
+}
+
+func (m *mapStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.values[key] = value
+	return nil
+}
+
+func TestCacheStore(t *testing.T) {
+	var calls int32
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if r.Header.Get("Authorization") != "Bearer valid-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"success":false,"message":"Invalid token"}`))
+			return
+		}
+		w.Write([]byte(`{"success":true,"data":{"id":"user-id"}}`))
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	store := &mapStore{values: map[string][]byte{}}
+	ctx := context.Background()
+
+	// Every service stands for a cold start of a function instance.
+	if user, err := NewService(ts.URL, "client-id", "secret", WithServerless(store)).Me(ctx, "valid-token"); err != nil || user.Id != "user-id" {
+		t.Fatalf("expected the user, got %+v, %v", user, err)
+	}
+	for key := range store.values {
+		if strings.Contains(key, "valid-token") {
+			t.Fatalf("expected the token to be hashed in the key, got %q", key)
+		}
+	}
+
+	cold := newService(ts.URL, "client-id", "secret", WithCacheStore(store))
+	if user, err := cold.Me(ctx, "valid-token"); err != nil || user.Id != "user-id" || calls != 1 {
+		t.Fatalf("expected the user from the store without a call, got %+v, %v after %d calls", user, err, calls)
+	}
+
+	if _, err := cold.Me(ctx, "invalid-token"); err == nil || len(store.values) != 1 {
+		t.Fatalf("expected failed calls not to be stored, got %v with %d values", err, len(store.values))
+	}
+
+	expired := newService(ts.URL, "client-id", "secret", WithCacheStore(store))
+	expired.cache.now = func() time.Time { return time.Now().Add(DefaultUserCacheTTL) }
+	calls = 0
+	if _, err := expired.Me(ctx, "valid-token"); err != nil || calls != 1 {
+		t.Fatalf("expected an expired stored entry to be fetched again, got %d calls, %v", calls, err)
+	}
+}
+
+func TestCacheStorePurge(t *testing.T) {
+	var calls int32
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/v1/tokens/revoke" {
+			w.Write([]byte(`{"success":true,"data":{"revoked":1}}`))
+			return
+		}
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(`{"success":true,"data":{"id":"user-id"}}`))
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	store := &mapStore{values: map[string][]byte{}}
+	ctx := context.Background()
+
+	if _, err := newService(ts.URL, "client-id", "secret", WithCacheStore(store)).Me(ctx, "valid-token"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := newService(ts.URL, "client-id", "secret", WithCacheStore(store)).RevokeTokens(ctx, TokenFilter{UserId: "user-id"}, "admin-token"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	cold := newService(ts.URL, "client-id", "secret", WithCacheStore(store))
+	if _, err := cold.Me(ctx, "valid-token"); err != nil || calls != 2 {
+		t.Fatalf("expected the revoked token to be resolved by the server, got %d calls, %v", calls, err)
+	}
+	if _, err := newService(ts.URL, "client-id", "secret", WithCacheStore(store)).Me(ctx, "valid-token"); err != nil || calls != 2 {
+		t.Fatalf("expected the entry stored after the purge to be used, got %d calls, %v", calls, err)
+	}
+}
+
+func TestServerlessClient(t *testing.T) {
+	service := newService("http://localhost", "client-id", "secret", WithServerless(nil))
+	if service.httpClient == http.DefaultClient || service.cache == nil {
+		t.Fatal("expected a dedicated HTTP client and the cache to be enabled")
+	}
+
+	client := &http.Client{}
+	if service := newService("http://localhost", "client-id", "secret", WithHTTPClient(client), WithServerless(nil)); service.httpClient != client {
+		t.Fatal("expected the given HTTP client to be kept")
+	}
+}
@@ -1,8 +1,103 @@
 package golang
 
-import "context"
+import (
+	"context"
+	"io"
+	"iter"
+	"time"
+)
 
+// Service is the go-iam API. It is implemented by the service returned by
+// NewService and by golangtest.FakeService. Minor releases may add methods to
+// Service, never change or remove them; mocks and wrappers implementing it
+// should embed UnimplementedService, or depend on ServiceV1 instead.
 type Service interface {
+	ServiceV1
+	Doctor(ctx context.Context, opts DoctorOptions) *DoctorReport
+	GetUsers(ctx context.Context, ids []string, token string) ([]User, error)
+	ResolveTokens(ctx context.Context, tokens []string) ([]TokenResolution, error)
+	GetRiskSignals(ctx context.Context, userID string, token string) (*RiskAssessment, error)
+	GetLockoutStatus(ctx context.Context, userID string, token string) (*LockoutStatus, error)
+	UnlockUser(ctx context.Context, userID string, token string) error
+	RevokeTokens(ctx context.Context, filter TokenFilter, token string) (*TokenRevocation, error)
+	GetUserMetadata(ctx context.Context, userID string, token string) (map[string]any, error)
+	UpdateUserMetadata(ctx context.Context, userID string, metadata map[string]any, token string) (map[string]any, error)
+	SearchUsers(ctx context.Context, query SearchUsersQuery, token string) (*UserList, error)
+	ScanUsers(ctx context.Context, opts ScanOptions, token string) iter.Seq2[User, error]
+	ExportUserData(ctx context.Context, userID string, w io.Writer, token string) error
+	EraseUser(ctx context.Context, userID string, reason string, token string) (*ErasureReceipt, error)
+	FindPossibleDuplicates(ctx context.Context, userID string, token string) ([]DuplicateCandidate, error)
+	MergeUsers(ctx context.Context, primaryID string, duplicateID string, token string) (*User, error)
+	GetResourceUsage(ctx context.Context, userID string, since time.Time, token string) ([]ResourceUsage, error)
+	FindUnusedGrants(ctx context.Context, userID string, window time.Duration, token string) (*UnusedGrantReport, error)
+	ListConsents(ctx context.Context, userID string, token string) ([]Consent, error)
+	RevokeConsent(ctx context.Context, userID string, clientID string, token string) error
+	EvaluateWithContext(ctx context.Context, resourceKey string, attrs AccessAttributes, token string) (*Evaluation, error)
+	GetBranding(ctx context.Context, projectID string, token string) (*Branding, error)
+	UpdateBranding(ctx context.Context, branding *Branding, token string) error
+	CreateTemplate(ctx context.Context, template *Template, token string) error
+	GetTemplate(ctx context.Context, id string, token string) (*Template, error)
+	ListTemplates(ctx context.Context, query ListTemplatesQuery, token string) (*TemplateList, error)
+	UpdateTemplate(ctx context.Context, template *Template, token string) error
+	DeleteTemplate(ctx context.Context, id string, token string) error
+	CreateOrganization(ctx context.Context, org *Organization, token string) error
+	GetOrganization(ctx context.Context, id string, token string) (*Organization, error)
+	ListOrganizations(ctx context.Context, token string) ([]Organization, error)
+	UpdateOrganization(ctx context.Context, org *Organization, token string) error
+	DeleteOrganization(ctx context.Context, id string, token string) error
+	ListOrganizationProjects(ctx context.Context, orgID string, token string) ([]Project, error)
+	CreateOrganizationRole(ctx context.Context, orgID string, role *Role, token string) error
+	ListOrganizationRoles(ctx context.Context, orgID string, token string) ([]Role, error)
+	GetClientConfig(ctx context.Context, clientID string, token string) (*ClientConfig, error)
+	UpdateClientConfig(ctx context.Context, config *ClientConfig, token string) error
+	ListClientSecrets(ctx context.Context, clientID string, token string) ([]ClientSecret, error)
+	AddClientSecret(ctx context.Context, clientID string, projectIDs []string, token string) (*ClientSecret, error)
+	RetireClientSecret(ctx context.Context, clientID string, secretID string, overlap time.Duration, token string) (*ClientSecret, error)
+	GetClaimsConfig(ctx context.Context, clientID string, token string) (*ClaimsConfig, error)
+	UpdateClaimsConfig(ctx context.Context, config *ClaimsConfig, token string) error
+	GetResource(ctx context.Context, id string, token string) (*Resource, error)
+	GetResourceByExternalID(ctx context.Context, externalID string, token string) (*Resource, error)
+	EnsureResource(ctx context.Context, resource *Resource, token string) (bool, error)
+	UpdateResource(ctx context.Context, resource *Resource, token string) error
+	ListResources(ctx context.Context, query ListResourcesQuery, token string) (*ResourceList, error)
+	ScanResources(ctx context.Context, opts ScanOptions, token string) iter.Seq2[Resource, error]
+	SyncResources(ctx context.Context, resources []Resource, opts SyncOptions, token string) (*SyncReport, error)
+	CreateRole(ctx context.Context, role *Role, token string) error
+	UpdateRole(ctx context.Context, role *Role, token string) error
+	GetRole(ctx context.Context, id string, token string) (*Role, error)
+	GetRoleByExternalID(ctx context.Context, externalID string, token string) (*Role, error)
+	EnsureRole(ctx context.Context, role *Role, token string) (bool, error)
+	ListRoles(ctx context.Context, query ListRolesQuery, token string) (*RoleList, error)
+	AddResourceToRole(ctx context.Context, roleID string, resource RoleResource, token string) error
+	RemoveResourceFromRole(ctx context.Context, roleID string, resourceID string, token string) error
+	ListPolicies(ctx context.Context, query ListPoliciesQuery, token string) (*PolicyList, error)
+	AttachPolicyToUser(ctx context.Context, userID string, policyID string, mapping UserPolicyMapping, token string) error
+	CreateDelegation(ctx context.Context, scope *DelegationScope, token string) error
+	ListDelegations(ctx context.Context, userID string, token string) ([]DelegationScope, error)
+	RevokeDelegation(ctx context.Context, id string, token string) error
+	GrantTemporaryAccess(ctx context.Context, userID string, resourceKey string, duration time.Duration, reason string, token string) (*TemporaryGrant, error)
+	ListTemporaryGrants(ctx context.Context, userID string, token string) ([]TemporaryGrant, error)
+	RevokeTemporaryGrant(ctx context.Context, id string, token string) error
+	RequestAccess(ctx context.Context, resourceKey string, reason string, token string) (*AccessRequest, error)
+	ListAccessRequests(ctx context.Context, query ListAccessRequestsQuery, token string) (*AccessRequestList, error)
+	ApproveAccessRequest(ctx context.Context, id string, comment string, token string) (*AccessRequest, error)
+	DenyAccessRequest(ctx context.Context, id string, comment string, token string) (*AccessRequest, error)
+	CreateReviewCampaign(ctx context.Context, campaign *ReviewCampaign, token string) error
+	ListPendingReviewItems(ctx context.Context, campaignID string, token string) ([]ReviewItem, error)
+	RecordReviewDecision(ctx context.Context, itemID string, decision ReviewDecision, comment string, token string) (*ReviewItem, error)
+	RequestSupportAccess(ctx context.Context, userID string, reason string, duration time.Duration, token string) (*SupportSession, error)
+	ListSupportSessions(ctx context.Context, userID string, token string) ([]SupportSession, error)
+	ApproveSupportAccess(ctx context.Context, id string, token string) (*SupportSession, error)
+	DenySupportAccess(ctx context.Context, id string, token string) (*SupportSession, error)
+	StartSupportSession(ctx context.Context, id string, token string) (*SupportToken, error)
+	EndSupportSession(ctx context.Context, id string, token string) (*SupportSession, error)
+}
+
+// ServiceV1 is the method set of Service in the first v1 release. It is
+// frozen: methods are never added to it, changed or removed, so code
+// implementing it keeps compiling across every v1 release. Code that only
+// needs these methods should depend on ServiceV1 rather than Service.
+type ServiceV1 interface {
 	Verify(ctx context.Context, code string) (string, error)
 	Me(ctx context.Context, token string) (*User, error)
 	ListProjects(ctx context.Context, token string) ([]Project, error)
@@ -2,172 +2,298 @@ package golang
 
 import (
 	"context"
-	"encoding/json"
+	"crypto/tls"
 	"fmt"
 	"net/http"
-	"strings"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
 )
 
 type serviceImpl struct {
-	baseURL  string
-	clientID string
-	secret   string
+	baseURL          string
+	credential       Credential
+	credentials      map[string]Credential
+	httpClient       *http.Client
+	timeout          time.Duration
+	retry            retryPolicy
+	requestHooks     []RequestHook
+	responseHooks    []ResponseHook
+	limiter          *rateLimiter
+	breaker          *circuitBreaker
+	tokenSource      TokenSource
+	redirect         *RedirectPolicy
+	maxResponseBytes int64
+	featureOverrides map[Feature]bool
+	features         featureSet
+	panicHandler     PanicHandler
+	cache            *objectCache
+	metrics          *Metrics
+	ids              IDGenerator
+	regions          map[string]string
+	envelopeAdapter  EnvelopeAdapter
+	dpop             *DPoPKey
+	clientCert       *tls.Certificate
+	cacheStore       CacheStore
+	serverless       bool
 }
 
 // NewService creates a new instance of the service with the provided base URL, client ID, and secret.
 // It returns a Service interface that can be used to interact with the API.
-func NewService(baseURL, clientID, secret string) Service {
-	return &serviceImpl{
-		baseURL:  baseURL,
-		clientID: clientID,
-		secret:   secret,
+// The client ID and secret form the default credential; options can register more.
+func NewService(baseURL, clientID, secret string, opts ...Option) Service {
+	return newService(baseURL, clientID, secret, opts...)
+}
+
+func newService(baseURL, clientID, secret string, opts ...Option) *serviceImpl {
+	s := &serviceImpl{
+		baseURL:          baseURL,
+		credential:       Credential{ClientID: clientID, Secret: secret},
+		credentials:      map[string]Credential{},
+		httpClient:       http.DefaultClient,
+		maxResponseBytes: DefaultMaxResponseBytes,
+		featureOverrides: map[Feature]bool{},
+		ids:              RandomIDs,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.features = resolveFeatures(os.Getenv(FeaturesEnv), s.featureOverrides)
+	if (s.serverless || s.cacheStore != nil) && s.cache == nil {
+		s.cache = newObjectCache(CacheTTLs{})
+	}
+	if s.serverless && s.httpClient == http.DefaultClient {
+		s.httpClient = serverlessClient()
+	}
+	if s.timeout > 0 || s.redirect != nil || s.clientCert != nil {
+		client := *s.httpClient
+		if s.timeout > 0 {
+			client.Timeout = s.timeout
+		}
+		if s.redirect != nil {
+			client.CheckRedirect = s.redirect.checkRedirect
+		}
+		if s.clientCert != nil {
+			if transport := withClientCertificate(client.Transport, *s.clientCert); transport != nil {
+				client.Transport = transport
+			}
+		}
+		s.httpClient = &client
 	}
+	return s
 }
 
 // Verify sends a verification request with the provided code and returns the access token if successful.
 func (s *serviceImpl) Verify(ctx context.Context, code string) (string, error) {
-	url := fmt.Sprintf("%s/auth/v1/verify?code=%s", s.baseURL, code)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return "", fmt.Errorf("error creating request: %w", err)
-	}
-
-	req.SetBasicAuth(s.clientID, s.secret)
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("error making request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	var statusError error
-	if resp.StatusCode != http.StatusOK {
-		statusError = fmt.Errorf("failed to verify code: %s", resp.Status)
-	}
-
 	result := AuthCallbackResponse{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		if statusError != nil {
-			return "", fmt.Errorf("%w: %s", statusError, err)
-		}
-		return "", fmt.Errorf("error decoding response: %w", err)
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodGet,
+		path:   "/auth/v1/verify",
+		query:  url.Values{"code": {code}},
+		basic:  true,
+		action: "verify code",
+	}, &result)
+	if err != nil {
+		return "", err
 	}
-
-	if !result.Success {
-		return "", fmt.Errorf("failed to verify code: %s. Status: %s", result.Message, resp.Status)
+	if result.Data == nil {
+		return "", fmt.Errorf("failed to verify code: empty response. Status: %s", resp.Status)
 	}
 
 	return result.Data.AccessToken, nil
 }
 
 // Me retrieves the user information associated with the provided token.
+// The result is memoized on contexts carrying a request cache, see WithRequestCache
+// and FeatureRequestCache.
 func (s *serviceImpl) Me(ctx context.Context, token string) (*User, error) {
-	url := fmt.Sprintf("%s/me/v1/", s.baseURL)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("error creating request: %w", err)
+	if !s.features.enabled(FeatureRequestCache) || minConsistency(ctx) != "" {
+		return s.cachedMe(ctx, token)
 	}
+	return memoize(ctx, memoKey("Me", token), func() (*User, error) {
+		return s.cachedMe(ctx, token)
+	})
+}
+
+func (s *serviceImpl) cachedMe(ctx context.Context, token string) (*User, error) {
+	return cached(ctx, s, cacheUsers, "", token, func() (*User, error) {
+		return s.me(ctx, token)
+	})
+}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
-	resp, err := http.DefaultClient.Do(req)
+func (s *serviceImpl) me(ctx context.Context, token string) (*User, error) {
+	result := UserResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodGet,
+		path:   "/me/v1/",
+		token:  token,
+		action: "fetch user information",
+	}, &result)
 	if err != nil {
-		return nil, fmt.Errorf("error making request: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
-
-	var statusError error
-	if resp.StatusCode != http.StatusOK {
-		statusError = fmt.Errorf("failed to fetch user information: %s", resp.Status)
+	if result.Data != nil && result.Data.ConsistencyToken == "" {
+		result.Data.ConsistencyToken = resp.Header.Get(ConsistencyTokenHeader)
 	}
 
-	var user UserResponse
-	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
-		if statusError != nil {
-			return nil, fmt.Errorf("%w: %s", statusError, err)
-		}
-		return nil, fmt.Errorf("error decoding response: %w", err)
+	return result.Data, nil
+}
+
+// GetUsers fetches the users with the provided IDs in a single request.
+// Unknown IDs are omitted from the result, see MissingUsersErr.
+func (s *serviceImpl) GetUsers(ctx context.Context, ids []string, token string) ([]User, error) {
+	if len(ids) == 0 {
+		return nil, nil
 	}
 
-	if !user.Success {
-		return nil, fmt.Errorf("failed to fetch user information: %s. Status: %s", user.Message, resp.Status)
+	result := UsersResponse{}
+	if _, err := s.call(ctx, apiRequest{
+		method: http.MethodPost,
+		path:   "/user/v1/batch",
+		body:   GetUsersRequest{Ids: ids},
+		token:  token,
+		action: "fetch users",
+		retry:  retrySafe,
+	}, &result); err != nil {
+		return nil, err
 	}
 
-	return user.Data, nil
+	return result.Data, nil
 }
 
-// ListProjects fetches all projects available to the caller.
-func (s *serviceImpl) ListProjects(ctx context.Context, token string) ([]Project, error) {
-	url := fmt.Sprintf("%s/project/v1/", s.baseURL)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("error creating request: %w", err)
+// MissingUsersErr returns a *BulkError listing the ids GetUsers returned no
+// user for, by position in ids, each matching ErrNotFound, or nil if every
+// user was found.
+func MissingUsersErr(ids []string, users []User) error {
+	found := make(map[string]bool, len(users))
+	for _, u := range users {
+		found[u.Id] = true
+	}
+	var failed []*ItemError
+	for i, id := range ids {
+		if !found[id] {
+			failed = append(failed, &ItemError{Index: i, ID: id, Err: ErrNotFound})
+		}
 	}
+	return bulkError("fetch users", failed)
+}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %w", err)
+// ResolveTokens resolves many access tokens to their users in a single request,
+// authenticating with the client credentials like Verify. Every token gets a
+// TokenResolution in the result; invalid tokens carry no user and a message,
+// see ResolutionsErr.
+func (s *serviceImpl) ResolveTokens(ctx context.Context, tokens []string) ([]TokenResolution, error) {
+	if len(tokens) == 0 {
+		return nil, nil
 	}
-	defer resp.Body.Close()
 
-	var statusError error
-	if resp.StatusCode != http.StatusOK {
-		statusError = fmt.Errorf("failed to list projects: %s", resp.Status)
+	result := TokenResolutionsResponse{}
+	if _, err := s.call(ctx, apiRequest{
+		method: http.MethodPost,
+		path:   "/me/v1/batch",
+		body:   ResolveTokensRequest{Tokens: tokens},
+		basic:  true,
+		action: "resolve tokens",
+		retry:  retrySafe,
+	}, &result); err != nil {
+		return nil, err
 	}
 
-	result := ProjectsResponse{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		if statusError != nil {
-			return nil, fmt.Errorf("%w: %s", statusError, err)
+	return result.Data, nil
+}
+
+// ResolutionsErr returns a *BulkError listing the tokens ResolveTokens could
+// not resolve, by position and without the tokens themselves, each matching
+// ErrUnauthorized, or nil if every token was resolved.
+func ResolutionsErr(resolutions []TokenResolution) error {
+	var failed []*ItemError
+	for i, r := range resolutions {
+		if r.User != nil {
+			continue
+		}
+		err := ErrUnauthorized
+		if r.Message != "" {
+			err = fmt.Errorf("%w: %s", ErrUnauthorized, r.Message)
 		}
-		return nil, fmt.Errorf("error decoding response: %w", err)
+		failed = append(failed, &ItemError{Index: i, Err: err})
 	}
+	return bulkError("resolve tokens", failed)
+}
+
+// EvaluateWithContext asks the server whether the token's user may access the
+// resource given the runtime attributes, including policy conditions that
+// depend on server-side state. The result is memoized on contexts carrying a
+// request cache, see WithRequestCache and FeatureRequestCache.
+func (s *serviceImpl) EvaluateWithContext(ctx context.Context, resourceKey string, attrs AccessAttributes, token string) (*Evaluation, error) {
+	if !s.features.enabled(FeatureRequestCache) || minConsistency(ctx) != "" {
+		return s.evaluateWithContext(ctx, resourceKey, attrs, token)
+	}
+	return memoize(ctx, memoKey("EvaluateWithContext", resourceKey, attrs, token), func() (*Evaluation, error) {
+		return s.evaluateWithContext(ctx, resourceKey, attrs, token)
+	})
+}
+
+func (s *serviceImpl) evaluateWithContext(ctx context.Context, resourceKey string, attrs AccessAttributes, token string) (*Evaluation, error) {
+	result := EvaluationResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodPost,
+		path:   "/policy/v1/evaluate",
+		body:   EvaluationRequest{ResourceKey: resourceKey, Attributes: attrs},
+		token:  token,
+		action: "evaluate access",
+		retry:  retrySafe,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Data == nil {
+		return nil, fmt.Errorf("failed to evaluate access: empty response. Status: %s", resp.Status)
+	}
+	if result.Data.ConsistencyToken == "" {
+		result.Data.ConsistencyToken = resp.Header.Get(ConsistencyTokenHeader)
+	}
+
+	return result.Data, nil
+}
 
-	if !result.Success {
-		return nil, fmt.Errorf("failed to list projects: %s. Status: %s", result.Message, resp.Status)
+// ListProjects fetches all projects available to the caller.
+func (s *serviceImpl) ListProjects(ctx context.Context, token string) ([]Project, error) {
+	result := ProjectsResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodGet,
+		path:   "/project/v1/",
+		token:  token,
+		action: "list projects",
+	}, &result)
+	if err != nil {
+		return nil, err
 	}
+	capturePagination(ctx, listPagination(resp, len(result.Data)))
 
 	return result.Data, nil
 }
 
 // CreateProject creates a new project with the provided details and token.
+// A project with a Region is created through that region's endpoint if the
+// service has region endpoints, see WithRegionEndpoints.
 func (s *serviceImpl) CreateProject(ctx context.Context, project *Project, token string) error {
 	if project == nil {
 		return fmt.Errorf("project cannot be nil")
 	}
-
-	url := fmt.Sprintf("%s/project/v1/", s.baseURL)
-	body, err := json.Marshal(project)
-	if err != nil {
-		return fmt.Errorf("error marshalling project: %w", err)
-	}
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(string(body)))
-	if err != nil {
-		return fmt.Errorf("error creating request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("error making request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	var statusError error
-	if resp.StatusCode != http.StatusOK {
-		statusError = fmt.Errorf("failed to create project: %s", resp.Status)
+	if project.Region != "" && len(s.regions) > 0 && RegionFromContext(ctx) == "" {
+		ctx = WithRegion(ctx, project.Region)
 	}
 
 	result := ProjectResponse{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		if statusError != nil {
-			return fmt.Errorf("%w: %s", statusError, err)
-		}
-		return fmt.Errorf("error decoding response: %w", err)
-	}
-	if !result.Success {
-		return fmt.Errorf("failed to create project: %s. Status: %s", result.Message, resp.Status)
+	if _, err := s.call(ctx, apiRequest{
+		method: http.MethodPost,
+		path:   "/project/v1/",
+		body:   project,
+		token:  token,
+		action: "create project",
+	}, &result); err != nil {
+		return err
 	}
 
 	if result.Data != nil {
@@ -183,39 +309,15 @@ func (s *serviceImpl) UpdateProject(ctx context.Context, id string, project *Pro
 		return fmt.Errorf("project cannot be nil")
 	}
 
-	url := fmt.Sprintf("%s/project/v1/%s", s.baseURL, id)
-	body, err := json.Marshal(project)
-	if err != nil {
-		return fmt.Errorf("error marshalling project: %w", err)
-	}
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, strings.NewReader(string(body)))
-	if err != nil {
-		return fmt.Errorf("error creating request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("error making request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	var statusError error
-	if resp.StatusCode != http.StatusOK {
-		statusError = fmt.Errorf("failed to update project: %s", resp.Status)
-	}
-
 	result := ProjectResponse{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		if statusError != nil {
-			return fmt.Errorf("%w: %s", statusError, err)
-		}
-		return fmt.Errorf("error decoding response: %w", err)
-	}
-	if !result.Success {
-		return fmt.Errorf("failed to update project: %s. Status: %s", result.Message, resp.Status)
+	if _, err := s.call(ctx, apiRequest{
+		method: http.MethodPut,
+		path:   "/project/v1/" + url.PathEscape(id),
+		body:   project,
+		token:  token,
+		action: "update project",
+	}, &result); err != nil {
+		return err
 	}
 
 	if result.Data != nil {
@@ -228,76 +330,143 @@ func (s *serviceImpl) UpdateProject(ctx context.Context, id string, project *Pro
 // CreateResource creates a new resource with the provided details and token.
 // It returns an error if the creation fails. Resource argument will be updated with the created resource details.
 func (s *serviceImpl) CreateResource(ctx context.Context, resource *Resource, token string) error {
-	url := fmt.Sprintf("%s/resource/v1/", s.baseURL)
-	body, err := json.Marshal(resource)
-	if err != nil {
-		return fmt.Errorf("error marshalling resource: %w", err)
+	if resource == nil {
+		return fmt.Errorf("resource cannot be nil")
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(string(body)))
-	if err != nil {
-		return fmt.Errorf("error creating request: %w", err)
+	result := ResourceResponse{}
+	if _, err := s.call(ctx, apiRequest{
+		method: http.MethodPost,
+		path:   "/resource/v1/",
+		body:   resource,
+		token:  token,
+		action: "create resource",
+	}, &result); err != nil {
+		return err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
-	resp, err := http.DefaultClient.Do(req)
+	if result.Data != nil {
+		*resource = *result.Data
+	}
+
+	return nil
+}
+
+// GetResource fetches the resource with the provided ID.
+// The result is cached if the service has a cache, see WithCache.
+func (s *serviceImpl) GetResource(ctx context.Context, id string, token string) (*Resource, error) {
+	return cached(ctx, s, cacheResources, id, token, func() (*Resource, error) {
+		return s.getResource(ctx, id, token)
+	})
+}
+
+func (s *serviceImpl) getResource(ctx context.Context, id string, token string) (*Resource, error) {
+	result := ResourceResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodGet,
+		path:   "/resource/v1/" + url.PathEscape(id),
+		token:  token,
+		action: "fetch resource",
+	}, &result)
 	if err != nil {
-		return fmt.Errorf("error making request: %w", err)
+		return nil, err
+	}
+	if result.Data == nil {
+		return nil, fmt.Errorf("failed to fetch resource: empty response. Status: %s", resp.Status)
 	}
-	defer resp.Body.Close()
 
-	var statusError error
-	if resp.StatusCode != http.StatusOK {
-		statusError = fmt.Errorf("failed to fetch user information: %s", resp.Status)
+	return result.Data, nil
+}
+
+// UpdateResource updates the resource identified by resource.ID with the provided details.
+// Resource argument will be updated with the stored resource details.
+func (s *serviceImpl) UpdateResource(ctx context.Context, resource *Resource, token string) error {
+	if resource == nil {
+		return fmt.Errorf("resource cannot be nil")
 	}
+	if resource.ID == "" {
+		return fmt.Errorf("resource ID cannot be empty")
+	}
+
+	defer s.invalidate(cacheResources, resource.ID)
 
 	result := ResourceResponse{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		if statusError != nil {
-			return fmt.Errorf("%w: %s", statusError, err)
-		}
-		return fmt.Errorf("error decoding response: %w", err)
+	if _, err := s.call(ctx, apiRequest{
+		method: http.MethodPut,
+		path:   "/resource/v1/" + url.PathEscape(resource.ID),
+		body:   resource,
+		token:  token,
+		action: "update resource",
+	}, &result); err != nil {
+		return err
 	}
-	if !result.Success {
-		return fmt.Errorf("failed to create resource: %s. Status: %s", result.Message, resp.Status)
+
+	if result.Data != nil {
+		*resource = *result.Data
 	}
 
 	return nil
 }
 
-// DeleteResource deletes a resource with the provided ID and token.
-// It returns an error if the deletion fails.
-func (s *serviceImpl) DeleteResource(ctx context.Context, resourceID string, token string) error {
-	url := fmt.Sprintf("%s/resource/v1/%s", s.baseURL, resourceID)
-	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
+// ListResources searches the resources matching the query, one page at a time.
+func (s *serviceImpl) ListResources(ctx context.Context, query ListResourcesQuery, token string) (*ResourceList, error) {
+	result := ResourceListResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodGet,
+		path:   "/resource/v1/search",
+		query:  query.values(),
+		token:  token,
+		action: "list resources",
+	}, &result)
 	if err != nil {
-		return fmt.Errorf("error creating request: %w", err)
+		return nil, err
 	}
-
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("error making request: %w", err)
+	if result.Data == nil {
+		return nil, fmt.Errorf("failed to list resources: empty response. Status: %s", resp.Status)
 	}
-	defer resp.Body.Close()
+	result.Data.complete(resp, query.Page, query.Limit, len(result.Data.Resources))
+	capturePagination(ctx, result.Data.Pagination)
 
-	var statusError error
-	if resp.StatusCode != http.StatusOK {
-		statusError = fmt.Errorf("failed to delete resource: %s", resp.Status)
-	}
+	return result.Data, nil
+}
 
-	result := ResourceResponse{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		if statusError != nil {
-			return fmt.Errorf("%w: %s", statusError, err)
-		}
-		return fmt.Errorf("error decoding response: %w", err)
-	}
+// DeleteResource deletes a resource with the provided ID and token.
+// It returns an error if the deletion fails.
+func (s *serviceImpl) DeleteResource(ctx context.Context, resourceID string, token string) error {
+	defer s.invalidate(cacheResources, resourceID)
 
-	if !result.Success {
-		return fmt.Errorf("failed to delete resource: %s. Status: %s", result.Message, resp.Status)
+	result := ResourceResponse{}
+	if _, err := s.call(ctx, apiRequest{
+		method: http.MethodDelete,
+		path:   "/resource/v1/" + url.PathEscape(resourceID),
+		token:  token,
+		action: "delete resource",
+	}, &result); err != nil {
+		return err
 	}
 
 	return nil
 }
+
+func (q ListResourcesQuery) values() url.Values {
+	v := url.Values{}
+	if q.Name != "" {
+		v.Set("name", q.Name)
+	}
+	if q.Key != "" {
+		v.Set("key", q.Key)
+	}
+	if q.Enabled != nil {
+		v.Set("enabled", strconv.FormatBool(*q.Enabled))
+	}
+	if q.After != "" {
+		v.Set("after", q.After)
+	}
+	if q.Page > 0 {
+		v.Set("page", strconv.Itoa(q.Page))
+	}
+	if q.Limit > 0 {
+		v.Set("limit", strconv.Itoa(q.Limit))
+	}
+	return v
+}
@@ -317,3 +317,280 @@ func TestUpdateProject(t *testing.T) {
 		}
 	})
 }
+
+func TestGetUsers(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Fatalf("expected POST method, got %s", r.Method)
+		}
+		if r.URL.Path != "/user/v1/batch" {
+			t.Fatalf("expected path /user/v1/batch, got %s", r.URL.Path)
+		}
+
+		var payload GetUsersRequest
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("expected valid payload, got %v", err)
+		}
+		if len(payload.Ids) != 2 {
+			t.Fatalf("unexpected ids: %+v", payload.Ids)
+		}
+
+		if r.Header.Get("Authorization") == "Bearer valid-token" {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"success":true,"data":[{"id":"user-1"},{"id":"user-2"}]}`))
+		} else {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"success":false,"message":"Invalid token"}`))
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret")
+
+	t.Run("Valid Token", func(t *testing.T) {
+		users, err := service.GetUsers(context.Background(), []string{"user-1", "user-2"}, "valid-token")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(users) != 2 || users[1].Id != "user-2" {
+			t.Fatalf("unexpected users: %+v", users)
+		}
+	})
+
+	t.Run("Invalid Token", func(t *testing.T) {
+		_, err := service.GetUsers(context.Background(), []string{"user-1", "user-2"}, "invalid-token")
+		if err == nil {
+			t.Fatal("expected an error, got none")
+		}
+	})
+}
+
+func TestResolveTokens(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/me/v1/batch" {
+			t.Fatalf("expected path /me/v1/batch, got %s", r.URL.Path)
+		}
+		if clientID, secret, _ := r.BasicAuth(); clientID != "client-id" || secret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"success":false,"message":"Invalid client"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"success":true,"data":[{"token":"token-1","user":{"id":"user-1"}},{"token":"token-2","message":"token expired"}]}`))
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	t.Run("Valid Credentials", func(t *testing.T) {
+		service := NewService(ts.URL, "client-id", "secret")
+		resolutions, err := service.ResolveTokens(context.Background(), []string{"token-1", "token-2"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(resolutions) != 2 {
+			t.Fatalf("expected 2 resolutions, got %d", len(resolutions))
+		}
+		if resolutions[0].User == nil || resolutions[0].User.Id != "user-1" {
+			t.Fatalf("expected token-1 to resolve to user-1, got %+v", resolutions[0])
+		}
+		if resolutions[1].User != nil || resolutions[1].Message == "" {
+			t.Fatalf("expected token-2 to be unresolved, got %+v", resolutions[1])
+		}
+	})
+
+	t.Run("Invalid Credentials", func(t *testing.T) {
+		service := NewService(ts.URL, "client-id", "wrong-secret")
+		_, err := service.ResolveTokens(context.Background(), []string{"token-1"})
+		if err == nil {
+			t.Fatal("expected an error, got none")
+		}
+	})
+}
+
+func TestEvaluateWithContext(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/policy/v1/evaluate" {
+			t.Fatalf("expected path /policy/v1/evaluate, got %s", r.URL.Path)
+		}
+
+		var payload EvaluationRequest
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("expected valid payload, got %v", err)
+		}
+		if payload.ResourceKey != "reports" || payload.Attributes.IP != "10.0.0.1" {
+			t.Fatalf("unexpected payload: %+v", payload)
+		}
+
+		if r.Header.Get("Authorization") == "Bearer valid-token" {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"success":true,"data":{"allowed":false,"reason":"outside business hours"}}`))
+		} else {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"success":false,"message":"Invalid token"}`))
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret")
+	attrs := AccessAttributes{IP: "10.0.0.1"}
+
+	t.Run("Valid Token", func(t *testing.T) {
+		eval, err := service.EvaluateWithContext(context.Background(), "reports", attrs, "valid-token")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if eval.Allowed || eval.Reason != "outside business hours" {
+			t.Fatalf("unexpected evaluation: %+v", eval)
+		}
+	})
+
+	t.Run("Invalid Token", func(t *testing.T) {
+		_, err := service.EvaluateWithContext(context.Background(), "reports", attrs, "invalid-token")
+		if err == nil {
+			t.Fatal("expected an error, got none")
+		}
+	})
+}
+
+func TestGetResource(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Fatalf("expected GET method, got %s", r.Method)
+		}
+		if r.URL.Path != "/resource/v1/resource-id" {
+			t.Fatalf("expected path /resource/v1/resource-id, got %s", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") == "Bearer valid-token" {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"success":true,"data":{"id":"resource-id","name":"Test Resource","key":"test-key"}}`))
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"success":false,"message":"Resource not found"}`))
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret")
+
+	t.Run("Valid Token", func(t *testing.T) {
+		resource, err := service.GetResource(context.Background(), "resource-id", "valid-token")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if resource.Key != "test-key" {
+			t.Fatalf("expected resource key to be 'test-key', got %v", resource.Key)
+		}
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		_, err := service.GetResource(context.Background(), "resource-id", "invalid-token")
+		if err == nil {
+			t.Fatal("expected an error, got none")
+		}
+	})
+}
+
+func TestUpdateResource(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Fatalf("expected PUT method, got %s", r.Method)
+		}
+		if r.URL.Path != "/resource/v1/resource-id" {
+			t.Fatalf("expected path /resource/v1/resource-id, got %s", r.URL.Path)
+		}
+
+		var payload Resource
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("expected valid resource payload, got %v", err)
+		}
+		if payload.Name != "Updated Resource" {
+			t.Fatalf("unexpected resource payload: %+v", payload)
+		}
+
+		if r.Header.Get("Authorization") == "Bearer valid-token" {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"success":true,"data":{"id":"resource-id","name":"Updated Resource","updated_by":"admin"}}`))
+		} else {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"success":false,"message":"Invalid token"}`))
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret")
+
+	t.Run("Valid Token", func(t *testing.T) {
+		resource := &Resource{ID: "resource-id", Name: "Updated Resource"}
+		if err := service.UpdateResource(context.Background(), resource, "valid-token"); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if resource.UpdatedBy != "admin" {
+			t.Fatalf("expected resource to be updated from the response, got %+v", resource)
+		}
+	})
+
+	t.Run("Invalid Token", func(t *testing.T) {
+		resource := &Resource{ID: "resource-id", Name: "Updated Resource"}
+		if err := service.UpdateResource(context.Background(), resource, "invalid-token"); err == nil {
+			t.Fatal("expected an error, got none")
+		}
+	})
+
+	t.Run("Missing ID", func(t *testing.T) {
+		if err := service.UpdateResource(context.Background(), &Resource{}, "valid-token"); err == nil {
+			t.Fatal("expected an error, got none")
+		}
+	})
+}
+
+func TestListResources(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/resource/v1/search" {
+			t.Fatalf("expected path /resource/v1/search, got %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("key") != "billing" || q.Get("enabled") != "true" || q.Get("page") != "2" || q.Get("limit") != "10" {
+			t.Fatalf("unexpected query: %s", r.URL.RawQuery)
+		}
+		if r.Header.Get("Authorization") == "Bearer valid-token" {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"success":true,"data":{"resources":[{"id":"resource-id","key":"billing:read"}],"total":11,"skip":10,"limit":10}}`))
+		} else {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"success":false,"message":"Invalid token"}`))
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret")
+	enabled := true
+	query := ListResourcesQuery{Key: "billing", Enabled: &enabled, Page: 2, Limit: 10}
+
+	t.Run("Valid Token", func(t *testing.T) {
+		list, err := service.ListResources(context.Background(), query, "valid-token")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if list.Total != 11 || len(list.Resources) != 1 || list.Resources[0].Key != "billing:read" {
+			t.Fatalf("unexpected resource list: %+v", list)
+		}
+	})
+
+	t.Run("Invalid Token", func(t *testing.T) {
+		_, err := service.ListResources(context.Background(), query, "invalid-token")
+		if err == nil {
+			t.Fatal("expected an error, got none")
+		}
+	})
+}
@@ -0,0 +1,60 @@
+package golang
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Snapshot is a point-in-time copy of IAM state meant to be stored, e.g. in
+// git, and diffed to detect drift.
+type Snapshot struct {
+	Users     []User     `json:"users"`
+	Resources []Resource `json:"resources"`
+	Roles     []Role     `json:"roles"`
+}
+
+// CanonicalJSON encodes the snapshot with CanonicalJSON after sorting users,
+// resources and roles by ID, so equal states always encode to the same bytes
+// regardless of the order they were listed in.
+func (s Snapshot) CanonicalJSON() ([]byte, error) {
+	sorted := Snapshot{
+		Users:     slices.Clone(s.Users),
+		Resources: slices.Clone(s.Resources),
+		Roles:     slices.Clone(s.Roles),
+	}
+	slices.SortStableFunc(sorted.Users, func(a, b User) int { return strings.Compare(a.Id, b.Id) })
+	slices.SortStableFunc(sorted.Resources, func(a, b Resource) int { return strings.Compare(a.ID, b.ID) })
+	slices.SortStableFunc(sorted.Roles, func(a, b Role) int { return strings.Compare(a.Id, b.Id) })
+	return CanonicalJSON(sorted)
+}
+
+// CanonicalJSON encodes v as JSON with the keys of every object sorted, two
+// spaces of indentation, no HTML escaping and a trailing newline. The output
+// only depends on the encoded values, making it suitable for snapshots that
+// are diffed line by line. Numbers are kept exactly as encoded.
+func CanonicalJSON(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling value: %w", err)
+	}
+
+	// Objects decoded into maps are re-encoded with sorted keys.
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var generic any
+	if err := dec.Decode(&generic); err != nil {
+		return nil, fmt.Errorf("error decoding value: %w", err)
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(generic); err != nil {
+		return nil, fmt.Errorf("error encoding value: %w", err)
+	}
+	return buf.Bytes(), nil
+}
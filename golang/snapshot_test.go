@@ -0,0 +1,65 @@
+package golang
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCanonicalJSON(t *testing.T) {
+	got, err := CanonicalJSON(map[string]any{
+		"b":     []any{2, 1.5, "<a&b>"},
+		"a":     struct{ Z, Y int }{Z: 1, Y: 2},
+		"large": uint64(12345678901234567890),
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	want := `{
+  "a": {
+    "Y": 2,
+    "Z": 1
+  },
+  "b": [
+    2,
+    1.5,
+    "<a&b>"
+  ],
+  "large": 12345678901234567890
+}
+`
+	if string(got) != want {
+		t.Fatalf("expected\n%s\ngot\n%s", want, got)
+	}
+}
+
+func TestSnapshotCanonicalJSON(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	a := Snapshot{
+		Users: []User{
+			{Id: "user-2", Roles: map[string]UserRole{"role-b": {Id: "role-b"}, "role-a": {Id: "role-a"}}},
+			{Id: "user-1", CreatedAt: &created},
+		},
+		Resources: []Resource{{ID: "res-2", Key: "reports:read"}, {ID: "res-1", Key: "billing:read"}},
+		Roles:     []Role{{Id: "role-b"}, {Id: "role-a"}},
+	}
+	b := Snapshot{
+		Users:     []User{a.Users[1], a.Users[0]},
+		Resources: []Resource{a.Resources[1], a.Resources[0]},
+		Roles:     []Role{a.Roles[1], a.Roles[0]},
+	}
+
+	first, err := a.CanonicalJSON()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	second, err := b.CanonicalJSON()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if string(first) != string(second) {
+		t.Fatalf("expected identical snapshots, got\n%s\nand\n%s", first, second)
+	}
+	if a.Users[0].Id != "user-2" {
+		t.Fatal("expected the snapshot not to be modified")
+	}
+}
@@ -0,0 +1,182 @@
+package golang
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// SupportSessionStatus is the state of a support session.
+type SupportSessionStatus string
+
+const (
+	SupportSessionPending  SupportSessionStatus = "pending"  // Waiting for the user's consent
+	SupportSessionApproved SupportSessionStatus = "approved" // The agent may act as the user until the session expires
+	SupportSessionDenied   SupportSessionStatus = "denied"   // The user refused access
+	SupportSessionEnded    SupportSessionStatus = "ended"    // Ended early by the user or the agent
+)
+
+// SupportSession lets a support agent act as a user for a limited time once
+// the user consents, without sharing passwords. Every step is recorded in the
+// audit log, and calls made with the session's token are attributed to the
+// agent through User.ImpersonatedBy.
+type SupportSession struct {
+	Id              string               `json:"id"`                   // Unique identifier of the session
+	ProjectId       string               `json:"project_id"`           // Project the session belongs to
+	UserId          string               `json:"user_id"`              // User being assisted
+	AgentId         string               `json:"agent_id"`             // Support agent requesting access
+	Reason          string               `json:"reason"`               // Why access is needed, shown to the user
+	DurationSeconds int64                `json:"duration_seconds"`     // How long access lasts once approved
+	Status          SupportSessionStatus `json:"status"`               // Current state of the session
+	DecidedAt       *time.Time           `json:"decided_at,omitempty"` // When the user approved or denied access
+	ExpiresAt       *time.Time           `json:"expires_at,omitempty"` // When approved access ends
+	EndedAt         *time.Time           `json:"ended_at,omitempty"`   // When the session was ended early
+	EndedBy         string               `json:"ended_by,omitempty"`   // ID of the user who ended the session
+	CreatedAt       *time.Time           `json:"created_at"`           // Timestamp when the session was requested
+}
+
+// SupportToken authenticates a support agent as the user of a session.
+type SupportToken struct {
+	AccessToken string     `json:"access_token"` // Token to make calls as the user with
+	SessionId   string     `json:"session_id"`   // Session the token belongs to
+	ExpiresAt   *time.Time `json:"expires_at"`   // When the token stops working
+}
+
+type supportRequestInput struct {
+	UserId          string `json:"user_id"`
+	Reason          string `json:"reason"`
+	DurationSeconds int64  `json:"duration_seconds"`
+}
+
+type SupportSessionResponse struct {
+	Success bool            `json:"success"`
+	Message string          `json:"message"`
+	Data    *SupportSession `json:"data,omitempty"`
+}
+
+type SupportSessionsResponse struct {
+	Success bool             `json:"success"`
+	Message string           `json:"message"`
+	Data    []SupportSession `json:"data,omitempty"`
+}
+
+type SupportTokenResponse struct {
+	Success bool          `json:"success"`
+	Message string        `json:"message"`
+	Data    *SupportToken `json:"data,omitempty"`
+}
+
+// Active reports whether the session lets the agent act as the user at the
+// given time.
+func (s SupportSession) Active(at time.Time) bool {
+	return s.Status == SupportSessionApproved && (s.ExpiresAt == nil || at.Before(*s.ExpiresAt))
+}
+
+// RequestSupportAccess asks the user for consent to let the support agent the
+// token belongs to act as them for the given duration once approved. The
+// reason is required and is shown to the user.
+func (s *serviceImpl) RequestSupportAccess(ctx context.Context, userID string, reason string, duration time.Duration, token string) (*SupportSession, error) {
+	if duration < time.Second {
+		return nil, fmt.Errorf("support session duration must be at least one second, got %v", duration)
+	}
+	if reason == "" {
+		return nil, fmt.Errorf("support session reason cannot be empty")
+	}
+
+	result := SupportSessionResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodPost,
+		path:   "/support/v1/",
+		body:   supportRequestInput{UserId: userID, Reason: reason, DurationSeconds: int64(duration / time.Second)},
+		token:  token,
+		action: "request support access",
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Data == nil {
+		return nil, fmt.Errorf("failed to request support access: empty response. Status: %s", resp.Status)
+	}
+
+	return result.Data, nil
+}
+
+// ListSupportSessions fetches the support sessions of the user with the
+// provided ID, e.g. to show pending requests to the user.
+func (s *serviceImpl) ListSupportSessions(ctx context.Context, userID string, token string) ([]SupportSession, error) {
+	result := SupportSessionsResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodGet,
+		path:   "/support/v1/",
+		query:  url.Values{"user_id": {userID}},
+		token:  token,
+		action: "list support sessions",
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	capturePagination(ctx, listPagination(resp, len(result.Data)))
+
+	return result.Data, nil
+}
+
+// ApproveSupportAccess approves the pending support session with the provided
+// ID. The token must belong to the user being assisted.
+func (s *serviceImpl) ApproveSupportAccess(ctx context.Context, id string, token string) (*SupportSession, error) {
+	return s.supportSessionAction(ctx, id, "approve", "approve support access", token)
+}
+
+// DenySupportAccess denies the pending support session with the provided ID.
+// The token must belong to the user being assisted.
+func (s *serviceImpl) DenySupportAccess(ctx context.Context, id string, token string) (*SupportSession, error) {
+	return s.supportSessionAction(ctx, id, "deny", "deny support access", token)
+}
+
+// EndSupportSession ends the support session with the provided ID before it
+// expires and revokes its token. Either the user or the agent may end it.
+func (s *serviceImpl) EndSupportSession(ctx context.Context, id string, token string) (*SupportSession, error) {
+	defer s.purge(ctx)
+	return s.supportSessionAction(ctx, id, "end", "end support session", token)
+}
+
+func (s *serviceImpl) supportSessionAction(ctx context.Context, id string, action string, description string, token string) (*SupportSession, error) {
+	result := SupportSessionResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodPost,
+		path:   "/support/v1/" + url.PathEscape(id) + "/" + action,
+		token:  token,
+		action: description,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Data == nil {
+		return nil, fmt.Errorf("failed to %s: empty response. Status: %s", description, resp.Status)
+	}
+
+	return result.Data, nil
+}
+
+// StartSupportSession issues the token the support agent acts as the user
+// with, valid until the approved session expires. The token must belong to
+// the agent who requested the session. It fails with ErrForbidden unless the
+// session is active.
+func (s *serviceImpl) StartSupportSession(ctx context.Context, id string, token string) (*SupportToken, error) {
+	result := SupportTokenResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodPost,
+		path:   "/support/v1/" + url.PathEscape(id) + "/token",
+		token:  token,
+		action: "start support session",
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Data == nil {
+		return nil, fmt.Errorf("failed to start support session: empty response. Status: %s", resp.Status)
+	}
+
+	return result.Data, nil
+}
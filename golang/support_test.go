@@ -0,0 +1,88 @@
+package golang
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSupportAccess(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if auth != "Bearer agent-token" && auth != "Bearer user-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"success":false,"message":"Invalid token"}`))
+			return
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "POST /support/v1/":
+			var payload supportRequestInput
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil || payload.DurationSeconds != 1800 || payload.UserId != "user-id" {
+				t.Fatalf("unexpected payload %+v, %v", payload, err)
+			}
+			w.Write([]byte(`{"success":true,"data":{"id":"session-id","user_id":"user-id","agent_id":"agent-id","status":"pending"}}`))
+		case "GET /support/v1/":
+			if r.URL.Query().Get("user_id") != "user-id" {
+				t.Fatalf("unexpected query %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"success":true,"data":[{"id":"session-id","status":"pending"}]}`))
+		case "POST /support/v1/session-id/approve":
+			if auth != "Bearer user-token" {
+				w.WriteHeader(http.StatusForbidden)
+				w.Write([]byte(`{"success":false,"message":"Only the user can approve"}`))
+				return
+			}
+			w.Write([]byte(`{"success":true,"data":{"id":"session-id","status":"approved","expires_at":"2030-01-01T00:00:00Z"}}`))
+		case "POST /support/v1/session-id/token":
+			w.Write([]byte(`{"success":true,"data":{"access_token":"support-token","session_id":"session-id"}}`))
+		case "POST /support/v1/session-id/end":
+			w.Write([]byte(`{"success":true,"data":{"id":"session-id","status":"ended","ended_by":"user-id"}}`))
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret")
+	ctx := context.Background()
+
+	session, err := service.RequestSupportAccess(ctx, "user-id", "Ticket #1234", 30*time.Minute, "agent-token")
+	if err != nil || session.Status != SupportSessionPending {
+		t.Fatalf("expected a pending session, got %+v, %v", session, err)
+	}
+	if sessions, err := service.ListSupportSessions(ctx, "user-id", "user-token"); err != nil || len(sessions) != 1 {
+		t.Fatalf("expected one session, got %+v, %v", sessions, err)
+	}
+	if _, err := service.ApproveSupportAccess(ctx, session.Id, "agent-token"); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+	session, err = service.ApproveSupportAccess(ctx, session.Id, "user-token")
+	if err != nil || !session.Active(time.Date(2029, 1, 1, 0, 0, 0, 0, time.UTC)) || session.Active(time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected an approved session until 2030, got %+v, %v", session, err)
+	}
+	if token, err := service.StartSupportSession(ctx, session.Id, "agent-token"); err != nil || token.AccessToken != "support-token" {
+		t.Fatalf("expected a support token, got %+v, %v", token, err)
+	}
+	if session, err := service.EndSupportSession(ctx, session.Id, "user-token"); err != nil || session.Status != SupportSessionEnded {
+		t.Fatalf("expected an ended session, got %+v, %v", session, err)
+	}
+
+	invalid := []struct {
+		reason   string
+		duration time.Duration
+	}{
+		{"", time.Minute},
+		{"Ticket #1234", 0},
+	}
+	for _, tt := range invalid {
+		if _, err := service.RequestSupportAccess(ctx, "user-id", tt.reason, tt.duration, "agent-token"); err == nil {
+			t.Fatalf("expected an error for %+v, got none", tt)
+		}
+	}
+}
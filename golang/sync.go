@@ -0,0 +1,185 @@
+package golang
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+)
+
+// defaultSyncConcurrency is the number of concurrent calls SyncResources
+// makes when SyncOptions.Concurrency is not set.
+const defaultSyncConcurrency = 4
+
+// syncPageSize is the page size SyncResources lists existing resources with.
+const syncPageSize = 100
+
+// SyncOptions configures SyncResources.
+type SyncOptions struct {
+	Concurrency int  // Maximum number of concurrent calls, 4 if zero
+	Delete      bool // Delete existing resources whose key is not in the desired set
+}
+
+// SyncAction is what SyncResources did with a resource.
+type SyncAction string
+
+const (
+	SyncCreated SyncAction = "created"
+	SyncUpdated SyncAction = "updated"
+	SyncDeleted SyncAction = "deleted"
+	SyncSkipped SyncAction = "skipped" // Already up to date
+	SyncFailed  SyncAction = "failed"
+)
+
+// SyncItem is the outcome of syncing a single resource.
+type SyncItem struct {
+	Key      string     // Key of the resource
+	Action   SyncAction // What was done with the resource
+	Resource *Resource  // The resource as stored on the server, nil if failed or deleted
+	Err      error      // Why the resource failed to sync, if Action is SyncFailed
+}
+
+// SyncReport lists the outcome of every resource synced by SyncResources,
+// desired resources first in input order, then deleted resources by key.
+type SyncReport struct {
+	Items []SyncItem
+}
+
+// Count returns the number of items with the given action.
+func (r *SyncReport) Count(action SyncAction) int {
+	n := 0
+	for _, item := range r.Items {
+		if item.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// Err returns a *BulkError listing the failed items by their position in
+// Items and their key, or nil if none failed.
+func (r *SyncReport) Err() error {
+	var failed []*ItemError
+	for i, item := range r.Items {
+		if item.Err != nil {
+			failed = append(failed, &ItemError{Index: i, ID: item.Key, Err: item.Err})
+		}
+	}
+	return bulkError("sync resources", failed)
+}
+
+// SyncResources makes the project's resources match the desired ones,
+// matching resources by Key so that repeated runs converge: missing resources
+// are created, resources whose name, description, enabled flag or external ID
+// differ are updated, and with SyncOptions.Delete resources that are not
+// desired are deleted. Calls run concurrently up to SyncOptions.Concurrency.
+// Failures of single resources are reported in the report; the returned
+// error is only set when the existing resources cannot be listed.
+func (s *serviceImpl) SyncResources(ctx context.Context, resources []Resource, opts SyncOptions, token string) (*SyncReport, error) {
+	existing, err := s.allResources(scopeIdempotencyKey(ctx, ""), token)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &SyncReport{Items: make([]SyncItem, len(resources))}
+	desired := make(map[string]bool, len(resources))
+	var tasks []func()
+	for i, r := range resources {
+		item := &report.Items[i]
+		item.Key = r.Key
+		switch {
+		case r.Key == "":
+			item.Action, item.Err = SyncFailed, errors.New("resource key cannot be empty")
+			continue
+		case desired[r.Key]:
+			item.Action, item.Err = SyncFailed, errors.New("duplicate resource key")
+			continue
+		}
+		desired[r.Key] = true
+
+		current, ok := existing[r.Key]
+		switch {
+		case !ok:
+			tasks = append(tasks, func() {
+				item.Action, item.Err = SyncCreated, s.CreateResource(scopeIdempotencyKey(ctx, "create:"+r.Key), &r, token)
+				item.Resource = &r
+			})
+		case current.Name == r.Name && current.Description == r.Description && current.Enabled == r.Enabled &&
+			current.ExternalId == r.ExternalId:
+			item.Action, item.Resource = SyncSkipped, &current
+		default:
+			r.ID = current.ID
+			tasks = append(tasks, func() {
+				item.Action, item.Err = SyncUpdated, s.UpdateResource(scopeIdempotencyKey(ctx, "update:"+r.Key), &r, token)
+				item.Resource = &r
+			})
+		}
+	}
+
+	var deleted []SyncItem
+	if opts.Delete {
+		var stale []string
+		for key := range existing {
+			if !desired[key] {
+				stale = append(stale, key)
+			}
+		}
+		sort.Strings(stale)
+		deleted = make([]SyncItem, len(stale))
+		for i, key := range stale {
+			item, id := &deleted[i], existing[key].ID
+			item.Key = key
+			tasks = append(tasks, func() {
+				item.Action, item.Err = SyncDeleted, s.DeleteResource(scopeIdempotencyKey(ctx, "delete:"+key), id, token)
+			})
+		}
+	}
+
+	runConcurrently(tasks, opts.Concurrency)
+
+	report.Items = append(report.Items, deleted...)
+	for i := range report.Items {
+		if report.Items[i].Err != nil {
+			report.Items[i].Action, report.Items[i].Resource = SyncFailed, nil
+		}
+	}
+	return report, nil
+}
+
+// allResources lists every resource of the project, keyed by Key. It stops
+// at a short page, or once the total is reached if the server reports one.
+func (s *serviceImpl) allResources(ctx context.Context, token string) (map[string]Resource, error) {
+	resources := map[string]Resource{}
+	for page := 1; ; page++ {
+		list, err := s.ListResources(ctx, ListResourcesQuery{Page: page, Limit: syncPageSize}, token)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range list.Resources {
+			resources[r.Key] = r
+		}
+		if len(list.Resources) < syncPageSize || (list.Total > 0 && int64(page*syncPageSize) >= list.Total) {
+			return resources, nil
+		}
+	}
+}
+
+// runConcurrently runs the tasks with at most limit running at a time, or
+// defaultSyncConcurrency if limit is not positive, and waits for all of them.
+func runConcurrently(tasks []func(), limit int) {
+	if limit <= 0 {
+		limit = defaultSyncConcurrency
+	}
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for _, task := range tasks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			task()
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,154 @@
+package golang
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// newResourceServer serves the resource endpoints from an in-memory store
+// keyed by resource ID.
+func newResourceServer(t *testing.T, store map[string]Resource, fail string) *httptest.Server {
+	t.Helper()
+	var mu sync.Mutex
+	next := 0
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		reply := func(data any) {
+			json.NewEncoder(w).Encode(map[string]any{"success": true, "data": data})
+		}
+		var payload Resource
+		if r.Body != nil {
+			json.NewDecoder(r.Body).Decode(&payload)
+		}
+		if payload.Key == fail {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"success":false,"message":"Internal error"}`))
+			return
+		}
+		id := strings.TrimPrefix(r.URL.Path, "/resource/v1/")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/resource/v1/search":
+			list := []Resource{}
+			for _, res := range store {
+				list = append(list, res)
+			}
+			reply(ResourceList{Resources: list, Pagination: Pagination{Total: int64(len(list))}})
+		case r.Method == http.MethodPost:
+			next++
+			payload.ID = fmt.Sprintf("id-%d", next)
+			store[payload.ID] = payload
+			reply(payload)
+		case r.Method == http.MethodPut:
+			store[id] = payload
+			reply(payload)
+		case r.Method == http.MethodDelete:
+			delete(store, id)
+			reply(nil)
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+}
+
+func TestSyncResources(t *testing.T) {
+	store := map[string]Resource{
+		"old-1": {ID: "old-1", Key: "billing:read", Name: "Read billing", Enabled: true},
+		"old-2": {ID: "old-2", Key: "billing:write", Name: "Write billing"},
+		"old-3": {ID: "old-3", Key: "legacy", Name: "Legacy"},
+	}
+	ts := newResourceServer(t, store, "broken")
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret")
+	desired := []Resource{
+		{Key: "billing:read", Name: "Read billing", Enabled: true},
+		{Key: "billing:write", Name: "Write billing", Enabled: true},
+		{Key: "reports:read", Name: "Read reports", Enabled: true},
+		{Key: "reports:read", Name: "Duplicate"},
+		{Key: "broken", Name: "Fails on the server"},
+		{Name: "No key"},
+	}
+
+	report, err := service.SyncResources(context.Background(), desired, SyncOptions{Delete: true, Concurrency: 2}, "token")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := []SyncAction{SyncSkipped, SyncUpdated, SyncCreated, SyncFailed, SyncFailed, SyncFailed, SyncDeleted}
+	if len(report.Items) != len(want) {
+		t.Fatalf("expected %d items, got %+v", len(want), report.Items)
+	}
+	for i, action := range want {
+		if report.Items[i].Action != action {
+			t.Errorf("item %d (%q): expected %s, got %s (%v)", i, report.Items[i].Key, action, report.Items[i].Action, report.Items[i].Err)
+		}
+	}
+	if report.Items[6].Key != "legacy" || report.Count(SyncFailed) != 3 || report.Err() == nil {
+		t.Fatalf("unexpected report %+v", report.Items)
+	}
+	var bulk *BulkError
+	if err := report.Err(); !errors.As(err, &bulk) || !slices.Equal(bulk.Indexes(), []int{3, 4, 5}) || !errors.Is(err, ErrServer) {
+		t.Fatalf("expected items 3 to 5 to fail, one with ErrServer, got %v", err)
+	}
+	if report.Items[2].Resource == nil || report.Items[2].Resource.ID == "" {
+		t.Fatalf("expected created resource with ID, got %+v", report.Items[2])
+	}
+
+	// A second run converges: everything left is already up to date.
+	report, err = service.SyncResources(context.Background(), desired[:3], SyncOptions{Delete: true}, "token")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if report.Count(SyncSkipped) != 3 || len(report.Items) != 3 {
+		t.Fatalf("expected all resources skipped, got %+v", report.Items)
+	}
+}
+
+func TestSyncResourcesExternalID(t *testing.T) {
+	store := map[string]Resource{
+		"old-1": {ID: "old-1", Key: "billing:read", Name: "Read billing", ExternalId: "tf-old"},
+	}
+	ts := newResourceServer(t, store, "broken")
+	defer ts.Close()
+
+	desired := []Resource{{Key: "billing:read", Name: "Read billing", ExternalId: "tf-billing"}}
+	report, err := NewService(ts.URL, "client-id", "secret").SyncResources(context.Background(), desired, SyncOptions{}, "token")
+	if err != nil || report.Count(SyncUpdated) != 1 || store["old-1"].ExternalId != "tf-billing" {
+		t.Fatalf("expected the external ID to be updated, got %+v, %v", report, err)
+	}
+}
+
+func TestSyncResourcesWithoutTotal(t *testing.T) {
+	var existing []Resource
+	for i := range syncPageSize + 1 {
+		existing = append(existing, Resource{ID: fmt.Sprintf("id-%d", i), Key: fmt.Sprintf("key-%d", i)})
+	}
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Fatalf("expected no changes, got %s %s", r.Method, r.URL.Path)
+		}
+		// The server pages the resources without reporting their total.
+		page, limit := 1, syncPageSize
+		fmt.Sscan(r.URL.Query().Get("page"), &page)
+		fmt.Sscan(r.URL.Query().Get("limit"), &limit)
+		start := min((page-1)*limit, len(existing))
+		json.NewEncoder(w).Encode(map[string]any{"success": true, "data": ResourceList{Resources: existing[start:min(start+limit, len(existing))]}})
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	report, err := NewService(ts.URL, "client-id", "secret").SyncResources(context.Background(), existing[syncPageSize:], SyncOptions{}, "token")
+	if err != nil || report.Count(SyncSkipped) != 1 {
+		t.Fatalf("expected the resource of the second page to be found, got %+v, %v", report, err)
+	}
+}
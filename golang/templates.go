@@ -0,0 +1,236 @@
+package golang
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// TemplateKind is the message a template renders.
+type TemplateKind string
+
+const (
+	TemplateInvite        TemplateKind = "invite"         // Invitation to join a project
+	TemplateVerification  TemplateKind = "verification"   // Email address or phone number verification
+	TemplatePasswordReset TemplateKind = "password_reset" // Password reset link
+)
+
+// TemplateChannel is how a templated message is delivered.
+type TemplateChannel string
+
+const (
+	TemplateChannelEmail TemplateChannel = "email"
+	TemplateChannelSMS   TemplateChannel = "sms"
+)
+
+// ErrInvalidTemplate is returned by Template.Validate.
+var ErrInvalidTemplate = errors.New("invalid template")
+
+// Template is a message go-iam sends to users, in one locale. There is at
+// most one template per kind, channel and locale in a project.
+type Template struct {
+	Id        string          `json:"id"`                // Unique identifier for the template
+	ProjectId string          `json:"project_id"`        // Project the template belongs to
+	Kind      TemplateKind    `json:"kind"`              // Message the template renders
+	Channel   TemplateChannel `json:"channel"`           // How the message is delivered
+	Locale    string          `json:"locale"`            // BCP 47 language tag, e.g. "en" or "pt-BR"
+	Subject   string          `json:"subject,omitempty"` // Subject line of emails, empty for SMS
+	Body      string          `json:"body"`              // Message body, with {{.Name}} style placeholders
+	CreatedAt *time.Time      `json:"created_at"`        // Timestamp when template was created
+	CreatedBy string          `json:"created_by"`        // ID of the user who created this template
+	UpdatedAt *time.Time      `json:"updated_at"`        // Timestamp when template was last updated
+	UpdatedBy string          `json:"updated_by"`        // ID of the user who last updated this template
+}
+
+// ListTemplatesQuery filters and paginates ListTemplates.
+type ListTemplatesQuery struct {
+	Kind    TemplateKind    // Only templates of this kind
+	Channel TemplateChannel // Only templates for this channel
+	Locale  string          // Only templates in this locale
+	Page    int             // 1-based page number, the first page if zero
+	Limit   int             // Maximum number of templates per page, the server default if zero
+}
+
+// TemplateList is a page of templates returned by ListTemplates.
+type TemplateList struct {
+	Templates []Template `json:"templates"` // Templates on this page
+	Pagination
+}
+
+type TemplateResponse struct {
+	Success bool      `json:"success"`
+	Message string    `json:"message"`
+	Data    *Template `json:"data,omitempty"`
+}
+
+type TemplateListResponse struct {
+	Success bool          `json:"success"`
+	Message string        `json:"message"`
+	Data    *TemplateList `json:"data,omitempty"`
+}
+
+// Validate checks that the template has a known kind and channel, a locale
+// and a body, and a subject only if it is an email.
+func (t Template) Validate() error {
+	switch t.Kind {
+	case TemplateInvite, TemplateVerification, TemplatePasswordReset:
+	default:
+		return fmt.Errorf("%w: unknown kind %q", ErrInvalidTemplate, t.Kind)
+	}
+	switch {
+	case t.Channel != TemplateChannelEmail && t.Channel != TemplateChannelSMS:
+		return fmt.Errorf("%w: unknown channel %q", ErrInvalidTemplate, t.Channel)
+	case t.Locale == "":
+		return fmt.Errorf("%w: locale cannot be empty", ErrInvalidTemplate)
+	case t.Body == "":
+		return fmt.Errorf("%w: body cannot be empty", ErrInvalidTemplate)
+	case t.Channel == TemplateChannelEmail && t.Subject == "":
+		return fmt.Errorf("%w: email subject cannot be empty", ErrInvalidTemplate)
+	case t.Channel == TemplateChannelSMS && t.Subject != "":
+		return fmt.Errorf("%w: SMS cannot have a subject", ErrInvalidTemplate)
+	}
+	return nil
+}
+
+// CreateTemplate creates a new template after validating it. It fails with
+// ErrConflict if the project already has a template of the same kind,
+// channel and locale.
+// Template argument will be updated with the created template details.
+func (s *serviceImpl) CreateTemplate(ctx context.Context, template *Template, token string) error {
+	if template == nil {
+		return fmt.Errorf("template cannot be nil")
+	}
+	if err := template.Validate(); err != nil {
+		return err
+	}
+
+	result := TemplateResponse{}
+	if _, err := s.call(ctx, apiRequest{
+		method: http.MethodPost,
+		path:   "/template/v1/",
+		body:   template,
+		token:  token,
+		action: "create template",
+	}, &result); err != nil {
+		return err
+	}
+
+	if result.Data != nil {
+		*template = *result.Data
+	}
+
+	return nil
+}
+
+// GetTemplate fetches the template with the provided ID.
+func (s *serviceImpl) GetTemplate(ctx context.Context, id string, token string) (*Template, error) {
+	result := TemplateResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodGet,
+		path:   "/template/v1/" + url.PathEscape(id),
+		token:  token,
+		action: "fetch template",
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Data == nil {
+		return nil, fmt.Errorf("failed to fetch template: empty response. Status: %s", resp.Status)
+	}
+
+	return result.Data, nil
+}
+
+// ListTemplates searches the templates matching the query, one page at a time.
+func (s *serviceImpl) ListTemplates(ctx context.Context, query ListTemplatesQuery, token string) (*TemplateList, error) {
+	result := TemplateListResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodGet,
+		path:   "/template/v1/search",
+		query:  query.values(),
+		token:  token,
+		action: "list templates",
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Data == nil {
+		return nil, fmt.Errorf("failed to list templates: empty response. Status: %s", resp.Status)
+	}
+	result.Data.complete(resp, query.Page, query.Limit, len(result.Data.Templates))
+	capturePagination(ctx, result.Data.Pagination)
+
+	return result.Data, nil
+}
+
+// UpdateTemplate updates the template identified by template.Id after
+// validating it.
+// Template argument will be updated with the stored template details.
+func (s *serviceImpl) UpdateTemplate(ctx context.Context, template *Template, token string) error {
+	if template == nil {
+		return fmt.Errorf("template cannot be nil")
+	}
+	if template.Id == "" {
+		return fmt.Errorf("template ID cannot be empty")
+	}
+	if err := template.Validate(); err != nil {
+		return err
+	}
+
+	result := TemplateResponse{}
+	if _, err := s.call(ctx, apiRequest{
+		method: http.MethodPut,
+		path:   "/template/v1/" + url.PathEscape(template.Id),
+		body:   template,
+		token:  token,
+		action: "update template",
+	}, &result); err != nil {
+		return err
+	}
+
+	if result.Data != nil {
+		*template = *result.Data
+	}
+
+	return nil
+}
+
+// DeleteTemplate deletes the template with the provided ID. go-iam falls back
+// to its built-in message for the kind, channel and locale.
+func (s *serviceImpl) DeleteTemplate(ctx context.Context, id string, token string) error {
+	result := TemplateResponse{}
+	if _, err := s.call(ctx, apiRequest{
+		method: http.MethodDelete,
+		path:   "/template/v1/" + url.PathEscape(id),
+		token:  token,
+		action: "delete template",
+	}, &result); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (q ListTemplatesQuery) values() url.Values {
+	v := url.Values{}
+	if q.Kind != "" {
+		v.Set("kind", string(q.Kind))
+	}
+	if q.Channel != "" {
+		v.Set("channel", string(q.Channel))
+	}
+	if q.Locale != "" {
+		v.Set("locale", q.Locale)
+	}
+	if q.Page > 0 {
+		v.Set("page", strconv.Itoa(q.Page))
+	}
+	if q.Limit > 0 {
+		v.Set("limit", strconv.Itoa(q.Limit))
+	}
+	return v
+}
@@ -0,0 +1,107 @@
+package golang
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTemplates(t *testing.T) {
+	stored := map[string]Template{}
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer valid-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"success":false,"message":"Invalid token"}`))
+			return
+		}
+		id := r.URL.Path[len("/template/v1/"):]
+		switch {
+		case r.Method == http.MethodPost && id == "", r.Method == http.MethodPut:
+			var payload Template
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("expected valid template payload, got %v", err)
+			}
+			if payload.Id == "" {
+				payload.Id = "template-id"
+			}
+			stored[payload.Id] = payload
+			json.NewEncoder(w).Encode(TemplateResponse{Success: true, Data: &payload})
+		case r.Method == http.MethodGet && id == "search":
+			if r.URL.Query().Get("locale") != "pt-BR" || r.URL.Query().Get("kind") != "invite" {
+				t.Fatalf("unexpected query %s", r.URL.RawQuery)
+			}
+			list := TemplateList{Pagination: Pagination{Total: int64(len(stored))}}
+			for _, tmpl := range stored {
+				list.Templates = append(list.Templates, tmpl)
+			}
+			json.NewEncoder(w).Encode(TemplateListResponse{Success: true, Data: &list})
+		case r.Method == http.MethodGet:
+			tmpl, ok := stored[id]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"success":false,"message":"Template not found"}`))
+				return
+			}
+			json.NewEncoder(w).Encode(TemplateResponse{Success: true, Data: &tmpl})
+		case r.Method == http.MethodDelete:
+			delete(stored, id)
+			w.Write([]byte(`{"success":true}`))
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret")
+	ctx := context.Background()
+
+	template := &Template{Kind: TemplateInvite, Channel: TemplateChannelEmail, Locale: "pt-BR", Subject: "Convite", Body: "Olá {{.Name}}"}
+	if err := service.CreateTemplate(ctx, template, "valid-token"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if template.Id != "template-id" {
+		t.Fatalf("expected template ID to be 'template-id', got %v", template.Id)
+	}
+
+	template.Body = "Olá {{.Name}}, bem-vindo"
+	if err := service.UpdateTemplate(ctx, template, "valid-token"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got, err := service.GetTemplate(ctx, "template-id", "valid-token"); err != nil || got.Body != template.Body {
+		t.Fatalf("expected the updated template, got %+v, %v", got, err)
+	}
+
+	list, err := service.ListTemplates(ctx, ListTemplatesQuery{Kind: TemplateInvite, Locale: "pt-BR"}, "valid-token")
+	if err != nil || len(list.Templates) != 1 || list.Total != 1 {
+		t.Fatalf("unexpected template list %+v, %v", list, err)
+	}
+
+	if err := service.DeleteTemplate(ctx, "template-id", "valid-token"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := service.GetTemplate(ctx, "template-id", "valid-token"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if err := service.DeleteTemplate(ctx, "template-id", "invalid-token"); err == nil {
+		t.Fatal("expected an error, got none")
+	}
+
+	invalid := []Template{
+		{Kind: "welcome", Channel: TemplateChannelEmail, Locale: "en", Subject: "Hi", Body: "Hi"},
+		{Kind: TemplateInvite, Channel: "push", Locale: "en", Body: "Hi"},
+		{Kind: TemplateInvite, Channel: TemplateChannelSMS, Body: "Hi"},
+		{Kind: TemplateInvite, Channel: TemplateChannelSMS, Locale: "en"},
+		{Kind: TemplateInvite, Channel: TemplateChannelEmail, Locale: "en", Body: "Hi"},
+		{Kind: TemplateInvite, Channel: TemplateChannelSMS, Locale: "en", Subject: "Hi", Body: "Hi"},
+	}
+	for _, tmpl := range invalid {
+		if err := service.CreateTemplate(ctx, &tmpl, "valid-token"); !errors.Is(err, ErrInvalidTemplate) {
+			t.Fatalf("expected ErrInvalidTemplate for %+v, got %v", tmpl, err)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package golang
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// tokenRefreshMargin is how long before expiry ClientCredentialsTokenSource
+// starts refreshing a token. Up to half of it again is added as jitter so
+// that many processes sharing a client do not refresh at the same moment.
+const tokenRefreshMargin = time.Minute
+
+// tokenExchangeTimeout bounds a token exchange, which outlives the context of
+// the caller that started it.
+const tokenExchangeTimeout = 30 * time.Second
+
+// Token is an access token and its expiry.
+type Token struct {
+	AccessToken string    // Bearer token sent to the API
+	Expiry      time.Time // When the token expires, never if zero
+}
+
+// Valid reports whether the token is set and not expired at the given time.
+func (t *Token) Valid(at time.Time) bool {
+	return t != nil && t.AccessToken != "" && (t.Expiry.IsZero() || at.Before(t.Expiry))
+}
+
+// TokenSource supplies access tokens. Implementations must be safe for
+// concurrent use.
+type TokenSource interface {
+	Token(ctx context.Context) (*Token, error)
+}
+
+// WithTokenSource makes the service authenticate calls made with an empty
+// token with a token from ts, so backend jobs can omit the token argument.
+func WithTokenSource(ts TokenSource) Option {
+	return func(s *serviceImpl) {
+		s.tokenSource = ts
+	}
+}
+
+// ClientCredentialsTokenSource exchanges a client ID and secret for service
+// tokens. Tokens are cached and refreshed shortly before they expire; while
+// a refresh is in progress, callers keep using the current token if it is
+// still valid, and at most one exchange runs at a time.
+type ClientCredentialsTokenSource struct {
+	service *serviceImpl
+	now     func() time.Time
+
+	mu        sync.Mutex
+	token     *Token
+	refreshAt time.Time
+	inflight  *tokenCall
+}
+
+// tokenCall is an exchange shared by all callers waiting for a token.
+type tokenCall struct {
+	done  chan struct{}
+	token *Token
+	err   error
+}
+
+type ClientCredentialsData struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"` // Lifetime of the token in seconds
+}
+
+type ClientCredentialsResponse struct {
+	Success bool                   `json:"success"`
+	Message string                 `json:"message"`
+	Data    *ClientCredentialsData `json:"data,omitempty"`
+}
+
+// NewClientCredentialsTokenSource creates a token source exchanging the
+// client ID and secret at the go-iam server at baseURL. Options configure the
+// HTTP calls of the exchange as they do for NewService.
+func NewClientCredentialsTokenSource(baseURL, clientID, secret string, opts ...Option) *ClientCredentialsTokenSource {
+	return &ClientCredentialsTokenSource{
+		service: newService(baseURL, clientID, secret, opts...),
+		now:     time.Now,
+	}
+}
+
+// Token returns the cached token, exchanging the client credentials for a new
+// one when it is due for refresh. A canceled ctx stops the wait for the
+// exchange, not the exchange itself, which other callers may share; the
+// exchange is abandoned after 30 seconds. Since its result is shared, the
+// exchange does not use the values of ctx, such as WithRegion, WithCredential
+// or WithIdempotencyKey: it always uses the credentials of the source.
+func (ts *ClientCredentialsTokenSource) Token(ctx context.Context) (*Token, error) {
+	ts.mu.Lock()
+	now := ts.now()
+	current := ts.token
+	if current.Valid(now) && (ts.refreshAt.IsZero() || now.Before(ts.refreshAt)) {
+		ts.mu.Unlock()
+		return current, nil
+	}
+	call := ts.inflight
+	if call == nil {
+		call = &tokenCall{done: make(chan struct{})}
+		ts.inflight = call
+		go ts.refresh(context.Background(), call)
+	}
+	ts.mu.Unlock()
+
+	if current.Valid(now) {
+		return current, nil
+	}
+	select {
+	case <-call.done:
+		return call.token, call.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (ts *ClientCredentialsTokenSource) refresh(ctx context.Context, call *tokenCall) {
+	ctx, cancel := context.WithTimeout(ctx, tokenExchangeTimeout)
+	defer cancel()
+	call.token, call.err = ts.exchange(ctx)
+
+	ts.mu.Lock()
+	if call.err == nil {
+		ts.token = call.token
+		ts.refreshAt = refreshTime(ts.now(), call.token.Expiry)
+	}
+	ts.inflight = nil
+	ts.mu.Unlock()
+	close(call.done)
+}
+
+func (ts *ClientCredentialsTokenSource) exchange(ctx context.Context) (*Token, error) {
+	data, err := ts.service.exchangeClientCredentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token := &Token{AccessToken: data.AccessToken}
+	if data.ExpiresIn > 0 {
+		token.Expiry = ts.now().Add(time.Duration(data.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
+
+// exchangeClientCredentials exchanges the credential selected on ctx for a
+// service token.
+func (s *serviceImpl) exchangeClientCredentials(ctx context.Context) (*ClientCredentialsData, error) {
+	result := ClientCredentialsResponse{}
+	resp, err := s.call(ctx, apiRequest{
+		method: http.MethodPost,
+		path:   "/auth/v1/token",
+		body:   map[string]string{"grant_type": "client_credentials"},
+		basic:  true,
+		action: "exchange client credentials",
+		retry:  retrySafe,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Data == nil || result.Data.AccessToken == "" {
+		return nil, fmt.Errorf("failed to exchange client credentials: empty response. Status: %s", resp.Status)
+	}
+
+	return result.Data, nil
+}
+
+// refreshTime returns when a token obtained at now and expiring at expiry is
+// due for refresh: tokenRefreshMargin plus jitter before expiry, or half way
+// through the lifetime of short-lived tokens. Tokens without expiry are
+// never refreshed, which is reported as the zero time.
+func refreshTime(now, expiry time.Time) time.Time {
+	if expiry.IsZero() {
+		return time.Time{}
+	}
+	lifetime := expiry.Sub(now)
+	margin := tokenRefreshMargin + rand.N(tokenRefreshMargin/2)
+	if margin > lifetime/2 {
+		margin = lifetime / 2
+	}
+	return expiry.Add(-margin)
+}
@@ -0,0 +1,153 @@
+package golang
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTokenServer(t *testing.T, exchanges *int32, release <-chan struct{}) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/v1/token":
+			if id, secret, ok := r.BasicAuth(); !ok || id != "client-id" || secret != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"success":false,"message":"Invalid client"}`))
+				return
+			}
+			if release != nil {
+				<-release
+			}
+			n := atomic.AddInt32(exchanges, 1)
+			fmt.Fprintf(w, `{"success":true,"data":{"access_token":"service-token-%d","expires_in":600}}`, n)
+		case "/me/v1/":
+			w.Write([]byte(`{"success":true,"data":{"id":"` + r.Header.Get("Authorization") + `"}}`))
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+}
+
+func TestClientCredentialsTokenSource(t *testing.T) {
+	var exchanges int32
+	ts := newTokenServer(t, &exchanges, nil)
+	defer ts.Close()
+
+	now := time.Now()
+	source := NewClientCredentialsTokenSource(ts.URL, "client-id", "secret")
+	source.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	token, err := source.Token(ctx)
+	if err != nil || token.AccessToken != "service-token-1" {
+		t.Fatalf("expected first token, got %+v, %v", token, err)
+	}
+	if !token.Expiry.Equal(now.Add(10 * time.Minute)) {
+		t.Fatalf("unexpected expiry %v", token.Expiry)
+	}
+	if token, _ := source.Token(ctx); token.AccessToken != "service-token-1" || exchanges != 1 {
+		t.Fatalf("expected cached token, got %+v after %d exchanges", token, exchanges)
+	}
+
+	// Inside the refresh window the current token is still served while a
+	// refresh runs in the background.
+	now = now.Add(9 * time.Minute)
+	if token, _ := source.Token(ctx); token.AccessToken != "service-token-1" {
+		t.Fatalf("expected current token during refresh, got %+v", token)
+	}
+	deadline := time.Now().Add(time.Second)
+	for {
+		if token, _ := source.Token(ctx); token.AccessToken == "service-token-2" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected token to be refreshed")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// Once expired, callers wait for the new token.
+	now = now.Add(time.Hour)
+	if token, err := source.Token(ctx); err != nil || token.AccessToken != "service-token-3" {
+		t.Fatalf("expected new token after expiry, got %+v, %v", token, err)
+	}
+}
+
+func TestClientCredentialsTokenSourceSingleFlight(t *testing.T) {
+	var exchanges int32
+	release := make(chan struct{})
+	ts := newTokenServer(t, &exchanges, release)
+	defer ts.Close()
+
+	source := NewClientCredentialsTokenSource(ts.URL, "client-id", "secret")
+
+	var wg sync.WaitGroup
+	tokens := make([]*Token, 20)
+	for i := range tokens {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tokens[i], _ = source.Token(context.Background())
+		}(i)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if exchanges != 1 {
+		t.Fatalf("expected a single exchange, got %d", exchanges)
+	}
+	for _, token := range tokens {
+		if token == nil || token.AccessToken != "service-token-1" {
+			t.Fatalf("expected every caller to get the token, got %+v", token)
+		}
+	}
+}
+
+func TestWithTokenSource(t *testing.T) {
+	var exchanges int32
+	ts := newTokenServer(t, &exchanges, nil)
+	defer ts.Close()
+
+	source := NewClientCredentialsTokenSource(ts.URL, "client-id", "secret")
+	service := NewService(ts.URL, "client-id", "secret", WithTokenSource(source))
+
+	user, err := service.Me(context.Background(), "")
+	if err != nil || user.Id != "Bearer service-token-1" {
+		t.Fatalf("expected call with service token, got %+v, %v", user, err)
+	}
+	user, err = service.Me(context.Background(), "user-token")
+	if err != nil || user.Id != "Bearer user-token" {
+		t.Fatalf("expected explicit token to win, got %+v, %v", user, err)
+	}
+
+	bad := NewService(ts.URL, "client-id", "secret", WithTokenSource(NewClientCredentialsTokenSource(ts.URL, "client-id", "wrong")))
+	if _, err := bad.Me(context.Background(), ""); err == nil {
+		t.Fatal("expected an error, got none")
+	}
+}
+
+func TestClientCredentialsTokenSourceIgnoresCallerContext(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if key := r.Header.Get(IdempotencyKeyHeader); key == "caller-key" {
+			t.Errorf("expected the exchange not to reuse the caller's idempotency key")
+		}
+		if id, _, _ := r.BasicAuth(); id != "client-id" {
+			t.Errorf("expected the credentials of the source, got %q", id)
+		}
+		w.Write([]byte(`{"success":true,"data":{"access_token":"service-token","expires_in":600}}`))
+	}))
+	defer ts.Close()
+
+	ctx := WithIdempotencyKey(WithCredential(WithRegion(context.Background(), "eu"), "other"), "caller-key")
+	token, err := NewClientCredentialsTokenSource(ts.URL, "client-id", "secret").Token(ctx)
+	if err != nil || token.AccessToken != "service-token" {
+		t.Fatalf("expected the token, got %+v, %v", token, err)
+	}
+}
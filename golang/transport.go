@@ -0,0 +1,393 @@
+package golang
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// retryPolicy holds the settings of WithRetry.
+type retryPolicy struct {
+	max           int
+	backoff       time.Duration
+	nonIdempotent bool
+}
+
+// retrySafety classifies a call by whether retrying it automatically could
+// apply it twice.
+type retrySafety int
+
+const (
+	retryByMethod retrySafety = iota // Safe for GET, HEAD, OPTIONS, PUT and DELETE
+	retrySafe                        // Reads and writes that have no further effect when repeated
+	retryUnsafe                      // Writes that could be applied twice
+)
+
+// do sends the request, retrying it according to the service's retry policy
+// if it is safe to retry, or WithRetryNonIdempotent allows unsafe retries.
+// Every attempt goes through the admission controls and the hooks.
+func (s *serviceImpl) do(req *http.Request, safety retrySafety) (*http.Response, error) {
+	ctx := req.Context()
+	attempts := 1
+	if s.retry.max > 0 && (safety == retrySafe || s.retry.nonIdempotent) {
+		attempts += s.retry.max
+	}
+
+	for attempt := 0; ; attempt++ {
+		r := req
+		if attempt > 0 {
+			if err := sleep(ctx, s.retry.delay(attempt)); err != nil {
+				return nil, err
+			}
+			var err error
+			if r, err = rewind(req); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := s.send(r)
+		if err == nil && s.dpop != nil && s.dpop.challenged(resp) {
+			// The server rejected the proof for lacking its current nonce
+			// without processing the request, resend it once with the nonce.
+			s.drain(resp)
+			if r, err = rewind(req); err != nil {
+				return nil, err
+			}
+			resp, err = s.send(r)
+		}
+		if attempt+1 >= attempts || !shouldRetry(ctx, resp, err) {
+			return resp, err
+		}
+		if resp != nil {
+			s.drain(resp)
+		}
+	}
+}
+
+// send performs a single attempt.
+func (s *serviceImpl) send(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	prio := PriorityFromContext(ctx)
+
+	if s.dpop != nil {
+		if err := s.dpop.prove(req, s.ids.NewID(IDNonce)); err != nil {
+			return nil, err
+		}
+	}
+	for _, hook := range s.requestHooks {
+		if err := Protect("request hook", s.panicHandler, func() { hook(req) }); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.limiter != nil {
+		if err := s.limiter.wait(ctx, prio); err != nil {
+			return nil, err
+		}
+	}
+	if s.breaker != nil {
+		if err := s.breaker.allow(prio); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := s.httpClient.Do(req)
+	switch {
+	case s.breaker == nil:
+	case err != nil && (ctx.Err() != nil || errors.Is(err, context.Canceled)):
+		// The caller gave up on the call, the server did not fail it.
+		s.breaker.release()
+	default:
+		s.breaker.record(err == nil && resp.StatusCode < http.StatusInternalServerError)
+	}
+
+	for _, hook := range s.responseHooks {
+		if perr := Protect("response hook", s.panicHandler, func() { hook(req, resp, err) }); perr != nil {
+			if resp != nil {
+				resp.Body.Close()
+			}
+			return nil, perr
+		}
+	}
+	return resp, err
+}
+
+// drain discards the rest of a response that will not be used, up to the
+// response size limit, so the connection can be reused, and closes it.
+func (s *serviceImpl) drain(resp *http.Response) {
+	body := io.Reader(resp.Body)
+	if s.maxResponseBytes > 0 {
+		body = io.LimitReader(body, s.maxResponseBytes)
+	}
+	io.Copy(io.Discard, body)
+	resp.Body.Close()
+}
+
+// readBody reads the body of resp, failing with a *ResponseTooLargeError
+// without buffering it if it exceeds the response size limit.
+func (s *serviceImpl) readBody(resp *http.Response) ([]byte, error) {
+	if s.maxResponseBytes <= 0 {
+		return io.ReadAll(resp.Body)
+	}
+	tooLarge := &ResponseTooLargeError{Limit: s.maxResponseBytes, StatusCode: resp.StatusCode}
+	if resp.ContentLength > s.maxResponseBytes {
+		return nil, tooLarge
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, s.maxResponseBytes+1))
+	if int64(len(data)) > s.maxResponseBytes {
+		return nil, tooLarge
+	}
+	return data, err
+}
+
+// retrySafety returns whether the call may be retried automatically. Calls
+// not annotated otherwise are safe if their method is idempotent or the
+// caller set an idempotency key the server deduplicates them with.
+func (r apiRequest) retrySafety(ctx context.Context) retrySafety {
+	switch {
+	case r.retry != retryByMethod:
+		return r.retry
+	case isIdempotent(r.method), idempotencyKey(ctx) != "":
+		return retrySafe
+	}
+	return retryUnsafe
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		var perr *PanicError
+		return !errors.Is(err, ErrCircuitOpen) && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) && !errors.As(err, &perr)
+	}
+	return resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests
+}
+
+// delay returns the wait before the given retry attempt: the backoff doubled
+// for each earlier retry, with up to 20% jitter to spread out retries.
+func (p retryPolicy) delay(attempt int) time.Duration {
+	d := p.backoff << (attempt - 1)
+	if d <= 0 {
+		return 0
+	}
+	return d + rand.N(d/5+1)
+}
+
+// rewind returns a copy of req with a fresh body for another attempt.
+func rewind(req *http.Request) (*http.Request, error) {
+	r := req.Clone(req.Context())
+	if req.Body != nil && req.Body != http.NoBody {
+		if req.GetBody == nil {
+			return nil, errors.New("request body cannot be replayed")
+		}
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		r.Body = body
+	}
+	return r, nil
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// apiRequest describes a call to the go-iam API.
+type apiRequest struct {
+	method string
+	path   string      // Path below the base URL, e.g. /resource/v1/
+	query  url.Values  // Optional query parameters
+	body   any         // Optional request payload, sent as JSON
+	token  string      // Bearer token authenticating the call, from the token source if empty
+	basic  bool        // Authenticate with the client credentials instead of a token
+	action string      // Describes the call in errors, e.g. "create resource"
+	retry  retrySafety // Whether the call may be retried automatically, from the method if unset
+
+	// download receives the body of a 200 response as is, without size
+	// limit, instead of it being decoded. Other responses fail as usual.
+	download io.Writer
+}
+
+// envelope holds the fields shared by every go-iam response.
+type envelope struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	Code    string `json:"code,omitempty"` // Machine readable error code, if the server sends one
+}
+
+// call sends r and decodes the response into out, which must be a pointer to
+// one of the *Response types. It returns the response, whose body has been
+// consumed, so callers can inspect status and headers.
+//
+// The call is bound to ctx throughout: canceling it aborts waiting for
+// admission or a retry, sending the request and reading the response body,
+// and the returned error then matches ctx.Err() with errors.Is.
+func (s *serviceImpl) call(ctx context.Context, r apiRequest, out any) (*http.Response, error) {
+	s.metrics.begin()
+	resp, err := s.roundTrip(ctx, r, out)
+	err = contextError(ctx, err)
+	s.metrics.end(err)
+	return resp, err
+}
+
+func (s *serviceImpl) roundTrip(ctx context.Context, r apiRequest, out any) (*http.Response, error) {
+	base, err := s.endpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	u := base + r.path
+	if len(r.query) > 0 {
+		u += "?" + r.query.Encode()
+	}
+
+	var body io.Reader
+	if r.body != nil {
+		data, err := json.Marshal(r.body)
+		if err != nil {
+			return nil, fmt.Errorf("error marshalling request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, r.method, u, body)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	if r.body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if r.basic {
+		cred, err := s.credentialFor(ctx)
+		if err != nil {
+			return nil, err
+		}
+		req.SetBasicAuth(cred.ClientID, cred.Secret)
+	} else {
+		token := r.token
+		if token == "" && s.tokenSource != nil {
+			var t *Token
+			perr := Protect("token source", s.panicHandler, func() { t, err = s.tokenSource.Token(ctx) })
+			if perr != nil {
+				err = perr
+			}
+			if err != nil {
+				return nil, fmt.Errorf("error obtaining token: %w", err)
+			}
+			token = t.AccessToken
+		}
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	}
+
+	requestID := s.ids.NewID(IDRequest)
+	req.Header.Set(RequestIDHeader, requestID)
+	if key := idempotencyKey(ctx); key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	} else if !isIdempotent(r.method) {
+		req.Header.Set(IdempotencyKeyHeader, s.ids.NewID(IDIdempotencyKey))
+	}
+	applyConsistency(ctx, req)
+	s.prepareRequest(req)
+
+	resp, err := s.do(req, r.retrySafety(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	defer resp.Body.Close()
+	observeConsistency(ctx, resp)
+
+	if r.download != nil && resp.StatusCode == http.StatusOK {
+		if _, err := io.Copy(r.download, resp.Body); err != nil {
+			return resp, fmt.Errorf("error downloading response: %w", err)
+		}
+		return resp, nil
+	}
+
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Method:     r.method,
+		Path:       r.path,
+		Action:     r.action,
+		RequestID:  requestID,
+	}
+
+	data, err := s.readBody(resp)
+	var tooLarge *ResponseTooLargeError
+	if errors.As(err, &tooLarge) {
+		tooLarge.Action = r.action
+		return resp, tooLarge
+	}
+	if err != nil {
+		if resp.StatusCode != http.StatusOK {
+			apiErr.Err = err
+			return resp, apiErr
+		}
+		return resp, fmt.Errorf("error reading response: %w", err)
+	}
+
+	if s.envelopeAdapter != nil {
+		adapted := data
+		perr := Protect("envelope adapter", s.panicHandler, func() { adapted, err = s.envelopeAdapter(resp.StatusCode, data) })
+		if perr != nil {
+			return resp, perr
+		}
+		if err != nil {
+			if resp.StatusCode != http.StatusOK {
+				apiErr.Err = err
+				return resp, apiErr
+			}
+			return resp, fmt.Errorf("error adapting response: %w", err)
+		}
+		data = adapted
+	}
+
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		if nonJSON := nonJSONResponse(resp.Header.Get("Content-Type"), data); nonJSON != nil {
+			err = nonJSON
+		}
+		if resp.StatusCode != http.StatusOK {
+			apiErr.Err = err
+			return resp, apiErr
+		}
+		return resp, fmt.Errorf("error decoding response: %w", err)
+	}
+	if !env.Success {
+		apiErr.Message, apiErr.Code = env.Message, env.Code
+		return resp, apiErr
+	}
+
+	if out != nil {
+		if err := json.Unmarshal(data, out); err != nil {
+			return resp, fmt.Errorf("error decoding response: %w", err)
+		}
+	}
+	return resp, nil
+}
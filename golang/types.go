@@ -9,27 +9,99 @@ type UserResponse struct {
 }
 
 type User struct {
-	Id             string                  `json:"id"`
-	ProjectId      string                  `json:"project_id"`
-	Name           string                  `json:"name"`
-	Email          string                  `json:"email"`
-	Phone          string                  `json:"phone"`
-	Enabled        bool                    `json:"enabled"`
-	ProfilePic     string                  `json:"profile_pic"`
-	LinkedClientId string                  `json:"linked_client_id,omitempty"`
-	Expiry         *time.Time              `json:"expiry"`
-	Roles          map[string]UserRole     `json:"roles"`
-	Resources      map[string]UserResource `json:"resources"`
-	Policies       map[string]UserPolicy   `json:"policies"`
-	CreatedAt      *time.Time              `json:"created_at"`
-	CreatedBy      string                  `json:"created_by"`
-	UpdatedAt      *time.Time              `json:"updated_at"`
-	UpdatedBy      string                  `json:"updated_by"`
+	Id               string                  `json:"id"`
+	ProjectId        string                  `json:"project_id"`
+	Name             string                  `json:"name"`
+	Email            string                  `json:"email"`
+	Phone            string                  `json:"phone"`
+	Enabled          bool                    `json:"enabled"`
+	ProfilePic       string                  `json:"profile_pic"`
+	LinkedClientId   string                  `json:"linked_client_id,omitempty"`
+	Expiry           *time.Time              `json:"expiry"`
+	Roles            map[string]UserRole     `json:"roles"`
+	Resources        map[string]UserResource `json:"resources"`
+	Policies         map[string]UserPolicy   `json:"policies"`
+	Metadata         map[string]any          `json:"metadata,omitempty"`
+	ConsistencyToken string                  `json:"consistency_token,omitempty"`
+	ImpersonatedBy   string                  `json:"impersonated_by,omitempty"`
+	CreatedAt        *time.Time              `json:"created_at"`
+	CreatedBy        string                  `json:"created_by"`
+	UpdatedAt        *time.Time              `json:"updated_at"`
+	UpdatedBy        string                  `json:"updated_by"`
+}
+
+type UsersResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	Data    []User `json:"data,omitempty"`
+}
+
+type GetUsersRequest struct {
+	Ids []string `json:"ids"`
+}
+
+type ResolveTokensRequest struct {
+	Tokens []string `json:"tokens"`
+}
+
+// TokenResolution is the outcome of resolving a single token of a batch.
+type TokenResolution struct {
+	Token   string `json:"token"`             // Token that was resolved
+	User    *User  `json:"user,omitempty"`    // User the token belongs to, nil if the token is invalid
+	Message string `json:"message,omitempty"` // Reason the token could not be resolved
+}
+
+type TokenResolutionsResponse struct {
+	Success bool              `json:"success"`
+	Message string            `json:"message"`
+	Data    []TokenResolution `json:"data,omitempty"`
 }
 
 type UserPolicy struct {
-	Name    string            `json:"name"`
-	Mapping UserPolicyMapping `json:"mapping,omitempty"`
+	Name       string            `json:"name"`
+	Mapping    UserPolicyMapping `json:"mapping,omitempty"`
+	Conditions []PolicyCondition `json:"conditions,omitempty"`
+}
+
+// PolicyCondition restricts when a policy grants access. Every field that is
+// set must hold for the condition to be met.
+type PolicyCondition struct {
+	TimeWindow *TimeWindow       `json:"time_window,omitempty"` // Time of day and days of week access is allowed
+	IPRanges   []string          `json:"ip_ranges,omitempty"`   // CIDR ranges the caller's IP must fall in
+	Attributes map[string]string `json:"attributes,omitempty"`  // Resource attributes that must match exactly
+}
+
+// TimeWindow is a recurring window of time, such as business hours.
+type TimeWindow struct {
+	Start    string   `json:"start"`              // Start time of day as HH:MM
+	End      string   `json:"end"`                // End time of day as HH:MM, before Start for overnight windows
+	Days     []string `json:"days,omitempty"`     // Allowed days as mon, tue, ..., sun; empty allows every day
+	Timezone string   `json:"timezone,omitempty"` // IANA time zone of the window, UTC if empty
+}
+
+// AccessAttributes are the runtime facts policy conditions are evaluated against.
+type AccessAttributes struct {
+	Time     time.Time         `json:"time"`               // Time of the access, now if zero
+	IP       string            `json:"ip,omitempty"`       // IP address of the caller
+	Resource map[string]string `json:"resource,omitempty"` // Attributes of the accessed resource
+}
+
+// Evaluation is the outcome of evaluating access to a resource.
+type Evaluation struct {
+	Allowed          bool   `json:"allowed"`                     // Whether access is allowed
+	Reason           string `json:"reason,omitempty"`            // Why access was denied
+	ConsistencyToken string `json:"consistency_token,omitempty"` // Version of the data the decision is based on
+}
+
+type EvaluationRequest struct {
+	ResourceKey string           `json:"resource_key"`
+	Attributes  AccessAttributes `json:"attributes"`
+}
+
+type EvaluationResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    *Evaluation `json:"data,omitempty"`
 }
 
 type UserPolicyMapping struct {
@@ -67,6 +139,7 @@ type Resource struct {
 	Name        string     `json:"name"`
 	Description string     `json:"description"`
 	Key         string     `json:"key"`
+	ExternalId  string     `json:"external_id,omitempty"`
 	Enabled     bool       `json:"enabled"`
 	ProjectId   string     `json:"project_id"`
 	CreatedAt   *time.Time `json:"created_at"`
@@ -82,18 +155,42 @@ type ResourceResponse struct {
 	Data    *Resource `json:"data,omitempty"`
 }
 
+// ListResourcesQuery filters and paginates ListResources.
+type ListResourcesQuery struct {
+	Name    string // Only resources whose name contains Name
+	Key     string // Only resources whose key contains Key
+	Enabled *bool  // Only enabled or disabled resources, both if nil
+	After   string // Only resources whose ID sorts after After, for paginating by ID instead of Page
+	Page    int    // 1-based page number, the first page if zero
+	Limit   int    // Maximum number of resources per page, the server default if zero
+}
+
+// ResourceList is a page of resources returned by ListResources.
+type ResourceList struct {
+	Resources []Resource `json:"resources"` // Resources on this page
+	Pagination
+}
+
+type ResourceListResponse struct {
+	Success bool          `json:"success"`
+	Message string        `json:"message"`
+	Data    *ResourceList `json:"data,omitempty"`
+}
+
 // Project represents a project in the Go IAM system.
 // Projects provide multi-tenant isolation, ensuring that users, clients,
 // and other resources are scoped to specific organizational units.
 type Project struct {
-	Id          string     `json:"id"`          // Unique identifier for the project
-	Name        string     `json:"name"`        // Display name of the project
-	Tags        []string   `json:"tags"`        // Tags for categorizing the project
-	Description string     `json:"description"` // Description of the project's purpose
-	CreatedAt   *time.Time `json:"created_at"`  // Timestamp when project was created
-	CreatedBy   string     `json:"created_by"`  // ID of the user who created this project
-	UpdatedAt   *time.Time `json:"updated_at"`  // Timestamp when project was last updated
-	UpdatedBy   string     `json:"updated_by"`  // ID of the user who last updated this project
+	Id          string     `json:"id"`               // Unique identifier for the project
+	OrgId       string     `json:"org_id,omitempty"` // Organization the project belongs to, if any
+	Region      string     `json:"region,omitempty"` // Data region storing the project's data, set on creation
+	Name        string     `json:"name"`             // Display name of the project
+	Tags        []string   `json:"tags"`             // Tags for categorizing the project
+	Description string     `json:"description"`      // Description of the project's purpose
+	CreatedAt   *time.Time `json:"created_at"`       // Timestamp when project was created
+	CreatedBy   string     `json:"created_by"`       // ID of the user who created this project
+	UpdatedAt   *time.Time `json:"updated_at"`       // Timestamp when project was last updated
+	UpdatedBy   string     `json:"updated_by"`       // ID of the user who last updated this project
 }
 
 // ProjectResponse represents an API response containing a single project.
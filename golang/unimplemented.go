@@ -0,0 +1,369 @@
+package golang
+
+import (
+	"context"
+	"errors"
+	"io"
+	"iter"
+	"time"
+)
+
+// ErrNotImplemented is returned by the methods of UnimplementedService.
+var ErrNotImplemented = errors.New("not implemented")
+
+// UnimplementedService implements every method of Service by failing with
+// ErrNotImplemented. Embed it in mocks and wrappers of Service so they keep
+// compiling when minor releases add methods to Service, overriding only the
+// methods they use:
+//
+//	type mockService struct {
+//		golang.UnimplementedService
+//		user *golang.User
+//	}
+//
+//	func (m *mockService) Me(ctx context.Context, token string) (*golang.User, error) {
+//		return m.user, nil
+//	}
+type UnimplementedService struct{}
+
+var _ Service = UnimplementedService{}
+
+func (UnimplementedService) Doctor(ctx context.Context, opts DoctorOptions) *DoctorReport {
+	return &DoctorReport{Checks: []DoctorCheck{{Name: CheckConnectivity, Status: CheckFailed, Err: ErrNotImplemented}}}
+}
+
+func (UnimplementedService) Verify(ctx context.Context, code string) (string, error) {
+	return "", ErrNotImplemented
+}
+
+func (UnimplementedService) Me(ctx context.Context, token string) (*User, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) GetUsers(ctx context.Context, ids []string, token string) ([]User, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) ResolveTokens(ctx context.Context, tokens []string) ([]TokenResolution, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) GetRiskSignals(ctx context.Context, userID string, token string) (*RiskAssessment, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) GetLockoutStatus(ctx context.Context, userID string, token string) (*LockoutStatus, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) UnlockUser(ctx context.Context, userID string, token string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedService) RevokeTokens(ctx context.Context, filter TokenFilter, token string) (*TokenRevocation, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) GetUserMetadata(ctx context.Context, userID string, token string) (map[string]any, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) UpdateUserMetadata(ctx context.Context, userID string, metadata map[string]any, token string) (map[string]any, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) SearchUsers(ctx context.Context, query SearchUsersQuery, token string) (*UserList, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) ScanUsers(ctx context.Context, opts ScanOptions, token string) iter.Seq2[User, error] {
+	return func(yield func(User, error) bool) { yield(User{}, ErrNotImplemented) }
+}
+
+func (UnimplementedService) ExportUserData(ctx context.Context, userID string, w io.Writer, token string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedService) EraseUser(ctx context.Context, userID string, reason string, token string) (*ErasureReceipt, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) FindPossibleDuplicates(ctx context.Context, userID string, token string) ([]DuplicateCandidate, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) MergeUsers(ctx context.Context, primaryID string, duplicateID string, token string) (*User, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) GetResourceUsage(ctx context.Context, userID string, since time.Time, token string) ([]ResourceUsage, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) FindUnusedGrants(ctx context.Context, userID string, window time.Duration, token string) (*UnusedGrantReport, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) ListConsents(ctx context.Context, userID string, token string) ([]Consent, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) RevokeConsent(ctx context.Context, userID string, clientID string, token string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedService) EvaluateWithContext(ctx context.Context, resourceKey string, attrs AccessAttributes, token string) (*Evaluation, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) ListProjects(ctx context.Context, token string) ([]Project, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) CreateProject(ctx context.Context, project *Project, token string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedService) UpdateProject(ctx context.Context, id string, project *Project, token string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedService) GetBranding(ctx context.Context, projectID string, token string) (*Branding, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) UpdateBranding(ctx context.Context, branding *Branding, token string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedService) CreateTemplate(ctx context.Context, template *Template, token string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedService) GetTemplate(ctx context.Context, id string, token string) (*Template, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) ListTemplates(ctx context.Context, query ListTemplatesQuery, token string) (*TemplateList, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) UpdateTemplate(ctx context.Context, template *Template, token string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedService) DeleteTemplate(ctx context.Context, id string, token string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedService) CreateOrganization(ctx context.Context, org *Organization, token string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedService) GetOrganization(ctx context.Context, id string, token string) (*Organization, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) ListOrganizations(ctx context.Context, token string) ([]Organization, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) UpdateOrganization(ctx context.Context, org *Organization, token string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedService) DeleteOrganization(ctx context.Context, id string, token string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedService) ListOrganizationProjects(ctx context.Context, orgID string, token string) ([]Project, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) CreateOrganizationRole(ctx context.Context, orgID string, role *Role, token string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedService) ListOrganizationRoles(ctx context.Context, orgID string, token string) ([]Role, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) GetClientConfig(ctx context.Context, clientID string, token string) (*ClientConfig, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) UpdateClientConfig(ctx context.Context, config *ClientConfig, token string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedService) ListClientSecrets(ctx context.Context, clientID string, token string) ([]ClientSecret, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) AddClientSecret(ctx context.Context, clientID string, projectIDs []string, token string) (*ClientSecret, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) RetireClientSecret(ctx context.Context, clientID string, secretID string, overlap time.Duration, token string) (*ClientSecret, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) GetClaimsConfig(ctx context.Context, clientID string, token string) (*ClaimsConfig, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) UpdateClaimsConfig(ctx context.Context, config *ClaimsConfig, token string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedService) CreateResource(ctx context.Context, resource *Resource, token string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedService) GetResource(ctx context.Context, id string, token string) (*Resource, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) GetResourceByExternalID(ctx context.Context, externalID string, token string) (*Resource, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) EnsureResource(ctx context.Context, resource *Resource, token string) (bool, error) {
+	return false, ErrNotImplemented
+}
+
+func (UnimplementedService) UpdateResource(ctx context.Context, resource *Resource, token string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedService) ListResources(ctx context.Context, query ListResourcesQuery, token string) (*ResourceList, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) DeleteResource(ctx context.Context, resourceID string, token string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedService) ScanResources(ctx context.Context, opts ScanOptions, token string) iter.Seq2[Resource, error] {
+	return func(yield func(Resource, error) bool) { yield(Resource{}, ErrNotImplemented) }
+}
+
+func (UnimplementedService) SyncResources(ctx context.Context, resources []Resource, opts SyncOptions, token string) (*SyncReport, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) CreateRole(ctx context.Context, role *Role, token string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedService) UpdateRole(ctx context.Context, role *Role, token string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedService) GetRole(ctx context.Context, id string, token string) (*Role, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) GetRoleByExternalID(ctx context.Context, externalID string, token string) (*Role, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) EnsureRole(ctx context.Context, role *Role, token string) (bool, error) {
+	return false, ErrNotImplemented
+}
+
+func (UnimplementedService) ListRoles(ctx context.Context, query ListRolesQuery, token string) (*RoleList, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) AddResourceToRole(ctx context.Context, roleID string, resource RoleResource, token string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedService) RemoveResourceFromRole(ctx context.Context, roleID string, resourceID string, token string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedService) ListPolicies(ctx context.Context, query ListPoliciesQuery, token string) (*PolicyList, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) AttachPolicyToUser(ctx context.Context, userID string, policyID string, mapping UserPolicyMapping, token string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedService) CreateDelegation(ctx context.Context, scope *DelegationScope, token string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedService) ListDelegations(ctx context.Context, userID string, token string) ([]DelegationScope, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) RevokeDelegation(ctx context.Context, id string, token string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedService) GrantTemporaryAccess(ctx context.Context, userID string, resourceKey string, duration time.Duration, reason string, token string) (*TemporaryGrant, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) ListTemporaryGrants(ctx context.Context, userID string, token string) ([]TemporaryGrant, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) RevokeTemporaryGrant(ctx context.Context, id string, token string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedService) RequestAccess(ctx context.Context, resourceKey string, reason string, token string) (*AccessRequest, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) ListAccessRequests(ctx context.Context, query ListAccessRequestsQuery, token string) (*AccessRequestList, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) ApproveAccessRequest(ctx context.Context, id string, comment string, token string) (*AccessRequest, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) DenyAccessRequest(ctx context.Context, id string, comment string, token string) (*AccessRequest, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) CreateReviewCampaign(ctx context.Context, campaign *ReviewCampaign, token string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedService) ListPendingReviewItems(ctx context.Context, campaignID string, token string) ([]ReviewItem, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) RecordReviewDecision(ctx context.Context, itemID string, decision ReviewDecision, comment string, token string) (*ReviewItem, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) RequestSupportAccess(ctx context.Context, userID string, reason string, duration time.Duration, token string) (*SupportSession, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) ListSupportSessions(ctx context.Context, userID string, token string) ([]SupportSession, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) ApproveSupportAccess(ctx context.Context, id string, token string) (*SupportSession, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) DenySupportAccess(ctx context.Context, id string, token string) (*SupportSession, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) StartSupportSession(ctx context.Context, id string, token string) (*SupportToken, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedService) EndSupportSession(ctx context.Context, id string, token string) (*SupportSession, error) {
+	return nil, ErrNotImplemented
+}
@@ -0,0 +1,36 @@
+package golang
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// partialService overrides a single method, like a mock of Service would.
+type partialService struct {
+	UnimplementedService
+}
+
+func (partialService) Me(ctx context.Context, token string) (*User, error) {
+	return &User{Id: "user-id"}, nil
+}
+
+func TestUnimplementedService(t *testing.T) {
+	var service Service = partialService{}
+	ctx := context.Background()
+
+	if user, err := service.Me(ctx, "token"); err != nil || user.Id != "user-id" {
+		t.Fatalf("expected the overridden method to be called, got %+v, %v", user, err)
+	}
+	if _, err := service.GetUsers(ctx, []string{"user-id"}, "token"); !errors.Is(err, ErrNotImplemented) {
+		t.Fatalf("expected ErrNotImplemented, got %v", err)
+	}
+	for _, err := range service.ScanUsers(ctx, ScanOptions{}, "token") {
+		if !errors.Is(err, ErrNotImplemented) {
+			t.Fatalf("expected the scan to fail with ErrNotImplemented, got %v", err)
+		}
+	}
+	if report := service.Doctor(ctx, DoctorOptions{}); report.OK() || !errors.Is(report.Err(), ErrNotImplemented) {
+		t.Fatalf("expected the doctor to fail with ErrNotImplemented, got %v", report.Err())
+	}
+}
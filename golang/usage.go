@@ -0,0 +1,122 @@
+package golang
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+)
+
+// ResourceUsage is how often a user exercised a resource over a period,
+// according to the server's access logs.
+type ResourceUsage struct {
+	ResourceKey  string     `json:"resource_key"`             // Resource that was accessed
+	Count        int64      `json:"count"`                    // Number of accesses over the period
+	LastAccessAt *time.Time `json:"last_access_at,omitempty"` // Most recent access over the period
+}
+
+// UnusedGrant is a grant of a user that was not exercised over a period, a
+// candidate for removal in least-privilege cleanups.
+type UnusedGrant struct {
+	Grant  string   `json:"grant"`           // Key of the grant, possibly a wildcard such as "billing:*"
+	Name   string   `json:"name,omitempty"`  // Name of the granted resource
+	Roles  []string `json:"roles,omitempty"` // IDs of the roles the grant comes from, sorted
+	Direct bool     `json:"direct"`          // Whether the grant comes from no role
+}
+
+// UnusedGrantReport lists the grants of a user not exercised over a period.
+type UnusedGrantReport struct {
+	UserId string        `json:"user_id"` // User the report is about
+	Since  time.Time     `json:"since"`   // Start of the period
+	Until  time.Time     `json:"until"`   // End of the period
+	Unused []UnusedGrant `json:"unused"`  // Grants not exercised, sorted by key
+}
+
+type ResourceUsageResponse struct {
+	Success bool            `json:"success"`
+	Message string          `json:"message"`
+	Data    []ResourceUsage `json:"data,omitempty"`
+}
+
+// UnusedGrants returns the user's grants not exercised according to usage,
+// sorted by key. A wildcard grant counts as exercised if any resource it
+// covers was accessed.
+func (u *User) UnusedGrants(usage []ResourceUsage) []UnusedGrant {
+	if u == nil {
+		return nil
+	}
+	var unused []UnusedGrant
+	for k, r := range u.Resources {
+		used := false
+		for _, use := range usage {
+			if use.Count > 0 && (grants(k, use.ResourceKey) || grants(r.Key, use.ResourceKey)) {
+				used = true
+				break
+			}
+		}
+		if used {
+			continue
+		}
+
+		grant := UnusedGrant{Grant: r.Key, Name: r.Name}
+		if grant.Grant == "" {
+			grant.Grant = k
+		}
+		for id, enabled := range r.RoleIds {
+			if enabled {
+				grant.Roles = append(grant.Roles, id)
+			}
+		}
+		sort.Strings(grant.Roles)
+		grant.Direct = len(grant.Roles) == 0
+		unused = append(unused, grant)
+	}
+	sort.Slice(unused, func(i, j int) bool { return unused[i].Grant < unused[j].Grant })
+	return unused
+}
+
+// GetResourceUsage fetches how often the user with the provided ID accessed
+// each resource since the given time. Resources not accessed are omitted.
+func (s *serviceImpl) GetResourceUsage(ctx context.Context, userID string, since time.Time, token string) ([]ResourceUsage, error) {
+	result := ResourceUsageResponse{}
+	if _, err := s.call(ctx, apiRequest{
+		method: http.MethodGet,
+		path:   "/user/v1/" + url.PathEscape(userID) + "/usage",
+		query:  url.Values{"since": {since.UTC().Format(time.RFC3339)}},
+		token:  token,
+		action: "fetch resource usage",
+	}, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
+}
+
+// FindUnusedGrants reports the grants of the user with the provided ID that
+// were not exercised over the window ending now, by correlating the user's
+// grants with their resource usage.
+func (s *serviceImpl) FindUnusedGrants(ctx context.Context, userID string, window time.Duration, token string) (*UnusedGrantReport, error) {
+	if window <= 0 {
+		return nil, fmt.Errorf("window must be positive, got %v", window)
+	}
+
+	ctx = scopeIdempotencyKey(ctx, "")
+	until := time.Now()
+	report := &UnusedGrantReport{UserId: userID, Since: until.Add(-window), Until: until}
+	users, err := s.GetUsers(ctx, []string{userID}, token)
+	if err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return nil, fmt.Errorf("failed to find unused grants: user %q: %w", userID, ErrNotFound)
+	}
+	usage, err := s.GetResourceUsage(ctx, userID, report.Since, token)
+	if err != nil {
+		return nil, err
+	}
+	report.Unused = users[0].UnusedGrants(usage)
+
+	return report, nil
+}
@@ -0,0 +1,83 @@
+package golang
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestUnusedGrants(t *testing.T) {
+	user := &User{Resources: map[string]UserResource{
+		"billing:read":  {Key: "billing:read", Name: "Billing", RoleIds: map[string]bool{"role-2": true, "role-1": true}},
+		"billing:write": {Key: "billing:write", RoleIds: map[string]bool{"role-1": true}},
+		"reports:*":     {Key: "reports:*"},
+		"admin":         {Key: "admin", RoleIds: map[string]bool{"role-3": false}},
+	}}
+	usage := []ResourceUsage{
+		{ResourceKey: "billing:write", Count: 3},
+		{ResourceKey: "reports:monthly", Count: 1},
+		{ResourceKey: "admin", Count: 0},
+	}
+
+	want := []UnusedGrant{
+		{Grant: "admin", Direct: true},
+		{Grant: "billing:read", Name: "Billing", Roles: []string{"role-1", "role-2"}},
+	}
+	if got := user.UnusedGrants(usage); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+	if got := (*User)(nil).UnusedGrants(usage); got != nil {
+		t.Fatalf("expected no grants for a nil user, got %+v", got)
+	}
+}
+
+func TestFindUnusedGrants(t *testing.T) {
+	var since time.Time
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer valid-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"success":false,"message":"Invalid token"}`))
+			return
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "POST /user/v1/batch":
+			w.Write([]byte(`{"success":true,"data":[{"id":"user-id","resources":{"billing:read":{"key":"billing:read"},"billing:write":{"key":"billing:write"}}}]}`))
+		case "GET /user/v1/user-id/usage":
+			var err error
+			if since, err = time.Parse(time.RFC3339, r.URL.Query().Get("since")); err != nil {
+				t.Fatalf("unexpected query %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"success":true,"data":[{"resource_key":"billing:read","count":12}]}`))
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(handler))
+	defer ts.Close()
+
+	service := NewService(ts.URL, "client-id", "secret")
+	ctx := context.Background()
+
+	report, err := service.FindUnusedGrants(ctx, "user-id", 90*24*time.Hour, "valid-token")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(report.Unused) != 1 || report.Unused[0].Grant != "billing:write" {
+		t.Fatalf("expected billing:write to be unused, got %+v", report.Unused)
+	}
+	if d := report.Until.Sub(report.Since); d != 90*24*time.Hour || report.Since.Sub(since).Abs() > time.Second {
+		t.Fatalf("unexpected period %v to %v, queried since %v", report.Since, report.Until, since)
+	}
+
+	if _, err := service.FindUnusedGrants(ctx, "user-id", 0, "valid-token"); err == nil {
+		t.Fatal("expected an error for an empty window, got none")
+	}
+	if _, err := service.FindUnusedGrants(ctx, "user-id", time.Hour, "invalid-token"); !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized, got %v", err)
+	}
+}
@@ -0,0 +1,179 @@
+package golang
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrUnknownPolicy is returned by PolicyEvaluator when a grant depends on a
+// policy that has no registered PolicyFunc.
+var ErrUnknownPolicy = errors.New("policy is not registered with the evaluator")
+
+// ErrMissingPolicyArgument is returned by PolicyEvaluator when a policy
+// argument has neither a static value nor a runtime value.
+var ErrMissingPolicyArgument = errors.New("policy argument has no value")
+
+// HasResource reports whether the resource key has been granted to the user
+// exactly, either as the map key or the Key field of one of its resources.
+func (u *User) HasResource(key string) bool {
+	_, ok := u.exactResource(key)
+	return ok
+}
+
+// HasRole reports whether the user has been assigned the role ID.
+func (u *User) HasRole(id string) bool {
+	if u == nil {
+		return false
+	}
+	if _, ok := u.Roles[id]; ok {
+		return true
+	}
+	for _, r := range u.Roles {
+		if r.Id == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Can reports whether the user has been granted the resource key, exactly or
+// through a wildcard grant. A granted key ending in "*" covers every key with
+// the same prefix, so "billing:*" covers "billing:read" and "*" covers all
+// keys. Policy conditions are not checked, use EvaluateWithContext for that.
+func (u *User) Can(resourceKey string) bool {
+	_, ok := u.resource(resourceKey)
+	return ok
+}
+
+// resource returns the user's grant for the resource key. An exact grant is
+// preferred, otherwise the wildcard grant with the longest prefix is used.
+func (u *User) resource(key string) (UserResource, bool) {
+	r, _, ok := u.match(key)
+	return r, ok
+}
+
+// match is resource also returning the granted key or wildcard pattern that
+// matched, empty for an exact match.
+func (u *User) match(key string) (UserResource, string, bool) {
+	if r, ok := u.exactResource(key); ok {
+		return r, "", true
+	}
+	if u == nil {
+		return UserResource{}, "", false
+	}
+	var (
+		best        UserResource
+		bestPattern string
+		bestLen     = -1
+	)
+	for k, r := range u.Resources {
+		for _, pattern := range [...]string{k, r.Key} {
+			prefix, ok := strings.CutSuffix(pattern, "*")
+			if ok && len(prefix) > bestLen && strings.HasPrefix(key, prefix) {
+				best, bestPattern, bestLen = r, pattern, len(prefix)
+			}
+		}
+	}
+	return best, bestPattern, bestLen >= 0
+}
+
+// exactResource looks a grant up by its map key first and by its Key field
+// otherwise.
+func (u *User) exactResource(key string) (UserResource, bool) {
+	if u == nil {
+		return UserResource{}, false
+	}
+	if r, ok := u.Resources[key]; ok {
+		return r, true
+	}
+	for _, r := range u.Resources {
+		if r.Key == key {
+			return r, true
+		}
+	}
+	return UserResource{}, false
+}
+
+// PolicyFunc decides a policy given its resolved arguments.
+type PolicyFunc func(args map[string]string) bool
+
+// PolicyEvaluator decides locally whether a user may access a resource by
+// running the policies attached to the grant. The logic of each policy is
+// supplied by the application through Register, keyed by policy ID.
+//
+// Register must not be called concurrently with Evaluate.
+type PolicyEvaluator struct {
+	policies map[string]PolicyFunc
+}
+
+// NewPolicyEvaluator creates a PolicyEvaluator without registered policies.
+func NewPolicyEvaluator() *PolicyEvaluator {
+	return &PolicyEvaluator{policies: map[string]PolicyFunc{}}
+}
+
+// Register sets the function deciding the policy with the given ID.
+func (e *PolicyEvaluator) Register(policyID string, fn PolicyFunc) {
+	e.policies[policyID] = fn
+}
+
+// ResolveArguments returns the arguments of the user's policy. Arguments with
+// a static value in the policy mapping use it, the others are looked up by
+// name in runtime.
+func ResolveArguments(policy UserPolicy, runtime map[string]string) (map[string]string, error) {
+	args := make(map[string]string, len(policy.Mapping.Arguments))
+	for name, v := range policy.Mapping.Arguments {
+		if v.Static != "" {
+			args[name] = v.Static
+			continue
+		}
+		value, ok := runtime[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrMissingPolicyArgument, name)
+		}
+		args[name] = value
+	}
+	return args, nil
+}
+
+// Evaluate reports whether the user may access the resource. The resource
+// must be granted to the user, see User.Can, and every policy attached to the
+// grant must allow access given its resolved arguments. Runtime supplies the
+// arguments that are not mapped to static values, e.g. the owner of the
+// record being accessed. A panicking policy function fails the evaluation
+// with a *PanicError.
+func (e *PolicyEvaluator) Evaluate(u *User, resourceKey string, runtime map[string]string) (*Evaluation, error) {
+	res, ok := u.resource(resourceKey)
+	if !ok {
+		return &Evaluation{Reason: fmt.Sprintf("resource %q is not granted", resourceKey)}, nil
+	}
+
+	policyIds := make([]string, 0, len(res.PolicyIds))
+	for id, enabled := range res.PolicyIds {
+		if enabled {
+			policyIds = append(policyIds, id)
+		}
+	}
+	sort.Strings(policyIds)
+
+	for _, id := range policyIds {
+		fn, ok := e.policies[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownPolicy, id)
+		}
+		args, err := ResolveArguments(u.Policies[id], runtime)
+		if err != nil {
+			return nil, fmt.Errorf("error resolving policy %q: %w", id, err)
+		}
+		var allowed bool
+		if err := Protect(fmt.Sprintf("policy %q", id), nil, func() { allowed = fn(args) }); err != nil {
+			return nil, err
+		}
+		if !allowed {
+			return &Evaluation{Reason: fmt.Sprintf("denied by policy %q", id)}, nil
+		}
+	}
+
+	return &Evaluation{Allowed: true}, nil
+}
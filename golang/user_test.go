@@ -0,0 +1,123 @@
+package golang
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestUserCan(t *testing.T) {
+	u := &User{
+		Resources: map[string]UserResource{
+			"res-1": {Key: "billing:read"},
+			"res-2": {Key: "reports:*"},
+			"res-3": {Key: "admin:users:*"},
+		},
+		Roles: map[string]UserRole{"role-key": {Id: "role-1"}},
+	}
+
+	tests := []struct {
+		key         string
+		hasResource bool
+		can         bool
+	}{
+		{"billing:read", true, true},
+		{"res-1", true, true},
+		{"billing:write", false, false},
+		{"reports:monthly", false, true},
+		{"reports:", false, true},
+		{"reports", false, false},
+		{"reports:*", true, true},
+		{"admin:users:delete", false, true},
+		{"admin:roles:delete", false, false},
+		{"", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := u.HasResource(tt.key); got != tt.hasResource {
+				t.Fatalf("HasResource(%q) = %v, want %v", tt.key, got, tt.hasResource)
+			}
+			if got := u.Can(tt.key); got != tt.can {
+				t.Fatalf("Can(%q) = %v, want %v", tt.key, got, tt.can)
+			}
+		})
+	}
+
+	if !u.HasRole("role-1") || !u.HasRole("role-key") || u.HasRole("role-2") {
+		t.Fatal("unexpected role check result")
+	}
+
+	var nobody *User
+	if nobody.Can("billing:read") || nobody.HasRole("role-1") {
+		t.Fatal("expected nil user to be denied")
+	}
+}
+
+func TestUserCanPrefersMostSpecificWildcard(t *testing.T) {
+	u := &User{Resources: map[string]UserResource{
+		"all":     {Key: "*", PolicyIds: map[string]bool{"broad": true}},
+		"billing": {Key: "billing:*", PolicyIds: map[string]bool{"narrow": true}},
+	}}
+
+	res, ok := u.resource("billing:read")
+	if !ok || !res.PolicyIds["narrow"] {
+		t.Fatalf("expected billing:* grant, got %+v", res)
+	}
+	if res, _ := u.resource("reports:read"); !res.PolicyIds["broad"] {
+		t.Fatalf("expected * grant, got %+v", res)
+	}
+}
+
+func TestPolicyEvaluator(t *testing.T) {
+	u := &User{
+		Id: "user-id",
+		Resources: map[string]UserResource{
+			"res-1": {Key: "records:*", PolicyIds: map[string]bool{"owner": true}},
+			"res-2": {Key: "reports:read", PolicyIds: map[string]bool{"unknown": true}},
+			"res-3": {Key: "status:read"},
+		},
+		Policies: map[string]UserPolicy{
+			"owner": {Mapping: UserPolicyMapping{Arguments: map[string]UserPolicyMappingValue{
+				"@userId":  {Static: "user-id"},
+				"@ownerId": {},
+			}}},
+		},
+	}
+
+	e := NewPolicyEvaluator()
+	e.Register("owner", func(args map[string]string) bool {
+		return args["@userId"] == args["@ownerId"]
+	})
+
+	tests := []struct {
+		name    string
+		key     string
+		runtime map[string]string
+		allowed bool
+		err     error
+	}{
+		{"owner allowed", "records:1", map[string]string{"@ownerId": "user-id"}, true, nil},
+		{"static value wins", "records:1", map[string]string{"@ownerId": "user-id", "@userId": "other"}, true, nil},
+		{"other owner denied", "records:1", map[string]string{"@ownerId": "other"}, false, nil},
+		{"missing runtime argument", "records:1", nil, false, ErrMissingPolicyArgument},
+		{"unregistered policy", "reports:read", nil, false, ErrUnknownPolicy},
+		{"no policies", "status:read", nil, true, nil},
+		{"not granted", "billing:read", nil, false, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eval, err := e.Evaluate(u, tt.key, tt.runtime)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("expected %v, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if eval.Allowed != tt.allowed {
+				t.Fatalf("expected allowed %v, got %+v", tt.allowed, eval)
+			}
+		})
+	}
+}